@@ -108,6 +108,7 @@ type TemplateEngineVersion string
 const (
 	TemplateEngineV1 TemplateEngineVersion = "v1"
 	TemplateEngineV2 TemplateEngineVersion = "v2"
+	TemplateEngineV3 TemplateEngineVersion = "v3"
 )
 
 // +kubebuilder:validation:MinProperties=1
@@ -182,6 +183,11 @@ type ExternalSecretDataRemoteRef struct {
 	// Used to define a conversion Strategy
 	// +kubebuilder:default="Default"
 	ConversionStrategy ExternalSecretConversionStrategy `json:"conversionStrategy,omitempty"`
+
+	// +optional
+	// Used to define a decoding Strategy
+	// +kubebuilder:default="None"
+	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
 }
 
 type ExternalSecretMetadataPolicy string
@@ -198,6 +204,19 @@ const (
 	ExternalSecretConversionUnicode ExternalSecretConversionStrategy = "Unicode"
 )
 
+// ExternalSecretDecodingStrategy defines how the values of the provider are decoded.
+// Auto decodes strict, padded base64 or base64url encoded values and keeps all other values as they are.
+// +kubebuilder:validation:Enum=None;Base64;Base64URL;Hex;Auto
+type ExternalSecretDecodingStrategy string
+
+const (
+	ExternalSecretDecodeNone      ExternalSecretDecodingStrategy = "None"
+	ExternalSecretDecodeBase64    ExternalSecretDecodingStrategy = "Base64"
+	ExternalSecretDecodeBase64URL ExternalSecretDecodingStrategy = "Base64URL"
+	ExternalSecretDecodeHex       ExternalSecretDecodingStrategy = "Hex"
+	ExternalSecretDecodeAuto      ExternalSecretDecodingStrategy = "Auto"
+)
+
 // +kubebuilder:validation:MinProperties=1
 // +kubebuilder:validation:MaxProperties=1
 type ExternalSecretDataFromRemoteRef struct {
@@ -225,6 +244,11 @@ type ExternalSecretFind struct {
 	// Used to define a conversion Strategy
 	// +kubebuilder:default="Default"
 	ConversionStrategy ExternalSecretConversionStrategy `json:"conversionStrategy,omitempty"`
+
+	// +optional
+	// Used to define a decoding Strategy
+	// +kubebuilder:default="None"
+	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
 }
 
 type FindName struct {
@@ -29,11 +29,36 @@ type AkeylessProvider struct {
 }
 
 type AkeylessAuth struct {
-	SecretRef AkeylessAuthSecretRef `json:"secretRef"`
+	// Reference to a Secret that contains the details
+	// to authenticate with Akeyless.
+	// +optional
+	SecretRef AkeylessAuthSecretRef `json:"secretRef,omitempty"`
+
+	// Kubernetes authenticates with Akeyless by passing the ServiceAccount
+	// token of the referenced ServiceAccount.
+	// +optional
+	KubernetesAuth *AkeylessKubernetesAuth `json:"kubernetesAuth,omitempty"`
+}
+
+// AkeylessKubernetesAuth authenticates with Akeyless using a Kubernetes ServiceAccount token.
+type AkeylessKubernetesAuth struct {
+	// the Akeyless Kubernetes auth-method access-id
+	AccessID string `json:"accessID"`
+
+	// Kubernetes-auth configuration name in Akeyless-Gateway
+	K8sConfName string `json:"k8sConfName"`
+
+	// Service account field containing the name of a kubernetes ServiceAccount.
+	// A token of the ServiceAccount is requested through the TokenRequest API.
+	// Required in a SecretStore. In a ClusterSecretStore the namespace defaults
+	// to the namespace of the ExternalSecret and, if the service account is
+	// not specified, the token of the controller is used.
+	// +optional
+	ServiceAccountRef *esmeta.ServiceAccountSelector `json:"serviceAccountRef,omitempty"`
 }
 
 // AkeylessAuthSecretRef
-//AKEYLESS_ACCESS_TYPE_PARAM: AZURE_OBJ_ID OR GCP_AUDIENCE OR ACCESS_KEY OR KUB_CONFIG_NAME.
+// AKEYLESS_ACCESS_TYPE_PARAM: AZURE_OBJ_ID OR GCP_AUDIENCE OR ACCESS_KEY OR KUB_CONFIG_NAME.
 type AkeylessAuthSecretRef struct {
 	// The SecretAccessID is used for authentication
 	AccessID        esmeta.SecretKeySelector `json:"accessID,omitempty"`
@@ -18,9 +18,13 @@ import (
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
-// AlibabaAuth contains a secretRef for credentials.
+// AlibabaAuth contains a secretRef for credentials or RRSA to assume a RAM role.
 type AlibabaAuth struct {
-	SecretRef AlibabaAuthSecretRef `json:"secretRef"`
+	// +optional
+	SecretRef *AlibabaAuthSecretRef `json:"secretRef,omitempty"`
+	// RRSA assumes a RAM role with the OIDC token of a service account (RAM Roles for Service Accounts).
+	// +optional
+	RRSAAuth *AlibabaRRSAAuth `json:"rrsa,omitempty"`
 }
 
 // AlibabaAuthSecretRef holds secret references for Alibaba credentials.
@@ -31,6 +35,20 @@ type AlibabaAuthSecretRef struct {
 	AccessKeySecret esmeta.SecretKeySelector `json:"accessKeySecretSecretRef"`
 }
 
+// AlibabaRRSAAuth authenticates against Alibaba using RRSA.
+type AlibabaRRSAAuth struct {
+	// OIDCProviderARN is the ARN of the OIDC identity provider of the cluster.
+	OIDCProviderARN string `json:"oidcProviderArn"`
+	// ServiceAccountRef is the ServiceAccount whose token is exchanged for the RAM role.
+	// The token is requested with the TokenRequest API for the audience sts.aliyuncs.com.
+	ServiceAccountRef esmeta.ServiceAccountSelector `json:"serviceAccountRef"`
+	// RoleARN is the ARN of the RAM role to assume.
+	RoleARN string `json:"roleArn"`
+	// SessionName is the name of the role session, defaults to external-secrets.
+	// +optional
+	SessionName string `json:"sessionName,omitempty"`
+}
+
 // AlibabaProvider configures a store to sync secrets using the Alibaba Secret Manager provider.
 type AlibabaProvider struct {
 	Auth *AlibabaAuth `json:"auth"`
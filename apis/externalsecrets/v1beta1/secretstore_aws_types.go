@@ -73,4 +73,12 @@ type AWSProvider struct {
 
 	// AWS Region to be used for the provider
 	Region string `json:"region"`
+
+	// Endpoints overrides the AWS service endpoints used by this store,
+	// e.g. to point it to LocalStack or to VPC endpoints.
+	// Supported keys are secretsmanager, ssm and sts. Services without an entry
+	// fall back to the AWS_SECRETSMANAGER_ENDPOINT, AWS_SSM_ENDPOINT and
+	// AWS_STS_ENDPOINT environment variables of the controller.
+	// +optional
+	Endpoints map[string]string `json:"endpoints,omitempty"`
 }
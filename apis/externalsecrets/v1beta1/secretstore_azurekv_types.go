@@ -34,6 +34,19 @@ const (
 	AzureWorkloadIdentity AzureAuthType = "WorkloadIdentity"
 )
 
+// AzureCertFormat describes how certificates (cert/ objects) are returned.
+// +kubebuilder:validation:Enum=DER;PEM
+type AzureCertFormat string
+
+const (
+	// Returns the public certificate in DER format.
+	AzureCertFormatDER AzureCertFormat = "DER"
+
+	// Returns the full certificate chain and private key in PEM format
+	// by resolving the backing secret of the certificate.
+	AzureCertFormatPEM AzureCertFormat = "PEM"
+)
+
 // Configures an store to sync secrets using Azure KV.
 type AzureKVProvider struct {
 	// Auth type defines how to authenticate to the keyvault service.
@@ -63,6 +76,15 @@ type AzureKVProvider struct {
 	// If multiple Managed Identity is assigned to the pod, you can select the one to be used
 	// +optional
 	IdentityID *string `json:"identityId,omitempty"`
+
+	// CertFormat defines how certificates (cert/ objects) are returned.
+	// Valid values are:
+	// - "DER" (default): the public certificate in DER format
+	// - "PEM": the ordered certificate chain as tls.crt and the private key as tls.key.
+	//   This reads the backing secret of the certificate and requires the secret get permission.
+	// +optional
+	// +kubebuilder:default=DER
+	CertFormat AzureCertFormat `json:"certFormat,omitempty"`
 }
 
 // Configuration used to authenticate with Azure.
@@ -0,0 +1,55 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// BitwardenSecretsManagerProvider configures a store to sync secrets with Bitwarden Secrets Manager.
+type BitwardenSecretsManagerProvider struct {
+	// APIURL is the URL of the Bitwarden API. Defaults to https://api.bitwarden.com.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// IdentityURL is the URL of the Bitwarden identity service used to log in.
+	// Defaults to https://identity.bitwarden.com.
+	// +optional
+	IdentityURL string `json:"identityURL,omitempty"`
+
+	// OrganizationID is the ID of the organization owning the secrets.
+	OrganizationID string `json:"organizationID"`
+
+	// ProjectID limits the secrets looked up by name and found with dataFrom.find to a project.
+	// +optional
+	ProjectID string `json:"projectID,omitempty"`
+
+	// PEM encoded CA bundle used to validate the certificate of a self-hosted server.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Auth configures how the operator authenticates with Bitwarden.
+	Auth BitwardenSecretsManagerAuth `json:"auth"`
+}
+
+// BitwardenSecretsManagerAuth configures the machine account used to read secrets.
+type BitwardenSecretsManagerAuth struct {
+	SecretRef BitwardenSecretsManagerSecretRef `json:"secretRef"`
+}
+
+type BitwardenSecretsManagerSecretRef struct {
+	// AccessToken is the access token of a machine account.
+	AccessToken esmeta.SecretKeySelector `json:"accessToken"`
+}
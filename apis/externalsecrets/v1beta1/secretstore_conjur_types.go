@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// ConjurProvider configures a store to sync secrets with CyberArk Conjur.
+type ConjurProvider struct {
+	// URL is the address of the Conjur server, e.g: "https://conjur.example.com".
+	URL string `json:"url"`
+
+	// Account is the Conjur organization account.
+	Account string `json:"account"`
+
+	// PEM encoded CA bundle used to validate the Conjur server certificate.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// The provider for the CA bundle to use to validate the Conjur server certificate.
+	// +optional
+	CAProvider *CAProvider `json:"caProvider,omitempty"`
+
+	// Auth configures how the operator authenticates with Conjur.
+	// Only one of `apikey` or `jwt` can be specified.
+	Auth ConjurAuth `json:"auth"`
+}
+
+// ConjurAuth configures the authenticator used to get an access token.
+type ConjurAuth struct {
+	// APIKey authenticates a host or user with its API key.
+	// +optional
+	APIKey *ConjurAPIKey `json:"apikey,omitempty"`
+
+	// Jwt authenticates with the authn-jwt authenticator.
+	// +optional
+	Jwt *ConjurJWT `json:"jwt,omitempty"`
+}
+
+// ConjurAPIKey authenticates with the login and API key of a host or user.
+type ConjurAPIKey struct {
+	// UserRef references the login of the host or user, e.g: "host/external-secrets".
+	UserRef esmeta.SecretKeySelector `json:"userRef"`
+
+	// APIKeyRef references the API key of the host or user.
+	APIKeyRef esmeta.SecretKeySelector `json:"apiKeyRef"`
+}
+
+// ConjurJWT authenticates with the authn-jwt authenticator using a JWT stored in a
+// Kubernetes Secret resource or a Kubernetes service account token retrieved via `TokenRequest`.
+type ConjurJWT struct {
+	// ServiceID is the ID of the authn-jwt authenticator, e.g: "kubernetes".
+	ServiceID string `json:"serviceID"`
+
+	// HostID is the host to authenticate as. Only needed if the authenticator
+	// does not read the host from a claim of the token.
+	// +optional
+	HostID string `json:"hostID,omitempty"`
+
+	// Optional SecretRef that refers to a key in a Secret resource containing the JWT.
+	// +optional
+	SecretRef *esmeta.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// Optional KubernetesServiceAccountToken specifies the Kubernetes service account for which to request
+	// a token for with the `TokenRequest` API.
+	// +optional
+	KubernetesServiceAccountToken *ConjurKubernetesServiceAccountTokenAuth `json:"kubernetesServiceAccountToken,omitempty"`
+}
+
+// ConjurKubernetesServiceAccountTokenAuth authenticates with Conjur using a temporary
+// Kubernetes service account token retrieved by the `TokenRequest` API.
+type ConjurKubernetesServiceAccountTokenAuth struct {
+	// Service account field containing the name of a kubernetes ServiceAccount.
+	ServiceAccountRef esmeta.ServiceAccountSelector `json:"serviceAccountRef"`
+
+	// Optional audiences field that will be used to request a temporary Kubernetes service
+	// account token for the service account referenced by `serviceAccountRef`.
+	// Defaults to a single audience `conjur` if not specified.
+	// +optional
+	Audiences *[]string `json:"audiences,omitempty"`
+
+	// Optional expiration time in seconds that will be used to request a temporary
+	// Kubernetes service account token for the service account referenced by
+	// `serviceAccountRef`.
+	// Defaults to 10 minutes.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// ConsulProvider configures a store to sync secrets with the HashiCorp Consul KV store.
+type ConsulProvider struct {
+	// Server is the address of the Consul HTTP API, e.g: "https://consul.example.com:8501".
+	Server string `json:"server"`
+
+	// Auth configures how the operator authenticates with Consul.
+	// +optional
+	Auth ConsulAuth `json:"auth,omitempty"`
+
+	// Namespace of the KV store. Namespaces are a Consul Enterprise feature.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Partition of the KV store. Admin partitions are a Consul Enterprise feature.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// Datacenter to read the KV store from. Defaults to the datacenter of the agent.
+	// +optional
+	Datacenter string `json:"datacenter,omitempty"`
+
+	// PEM encoded CA bundle used to validate the Consul server certificate.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// The provider for the CA bundle to use to validate the Consul server certificate.
+	// +optional
+	CAProvider *CAProvider `json:"caProvider,omitempty"`
+}
+
+// ConsulAuth configures the ACL token sent to Consul.
+type ConsulAuth struct {
+	// TokenSecretRef references the ACL token used to read the KV store.
+	// +optional
+	TokenSecretRef *esmeta.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
@@ -0,0 +1,50 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// DopplerProvider configures a store to sync secrets with Doppler.
+type DopplerProvider struct {
+	// APIURL is the URL of the Doppler API. Defaults to https://api.doppler.com.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// Project is the Doppler project. Not needed for service tokens, they are scoped to a config.
+	// +optional
+	Project string `json:"project,omitempty"`
+
+	// Config is the Doppler config, e.g: "prd". Not needed for service tokens, they are scoped to a config.
+	// +optional
+	Config string `json:"config,omitempty"`
+
+	// NameTransformer renames the secrets returned by dataFrom, e.g: "lower-kebab".
+	// +optional
+	NameTransformer SecretNameTransformer `json:"nameTransformer,omitempty"`
+
+	// Auth configures how the operator authenticates with Doppler.
+	Auth DopplerAuth `json:"auth"`
+}
+
+type DopplerAuth struct {
+	SecretRef DopplerAuthSecretRef `json:"secretRef"`
+}
+
+type DopplerAuthSecretRef struct {
+	// DopplerToken is a service token, or a service account or personal token with access to the project.
+	DopplerToken esmeta.SecretKeySelector `json:"dopplerToken"`
+}
@@ -14,9 +14,17 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // FakeProvider configures a fake provider that returns static values.
 type FakeProvider struct {
 	Data []FakeProviderData `json:"data"`
+
+	// Latency is added to every request to the provider.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
 }
 
 type FakeProviderData struct {
@@ -24,4 +32,35 @@ type FakeProviderData struct {
 	Value    string            `json:"value,omitempty"`
 	ValueMap map[string]string `json:"valueMap,omitempty"`
 	Version  string            `json:"version,omitempty"`
+
+	// Tags are matched by dataFrom.find.tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Failure makes requests for this key fail.
+	// +optional
+	Failure *FakeProviderFailure `json:"failure,omitempty"`
+}
+
+// FakeProviderFailureMode is the error returned by a failing key.
+// +kubebuilder:validation:Enum=NotFound;AuthError;Timeout
+type FakeProviderFailureMode string
+
+const (
+	// FakeProviderFailureNotFound reports the secret as missing.
+	FakeProviderFailureNotFound FakeProviderFailureMode = "NotFound"
+	// FakeProviderFailureAuthError reports a permission error.
+	FakeProviderFailureAuthError FakeProviderFailureMode = "AuthError"
+	// FakeProviderFailureTimeout reports an exceeded deadline.
+	FakeProviderFailureTimeout FakeProviderFailureMode = "Timeout"
+)
+
+// FakeProviderFailure configures how and when requests for a key fail.
+type FakeProviderFailure struct {
+	Mode FakeProviderFailureMode `json:"mode"`
+
+	// AfterCalls is the number of requests for the key that succeed before it starts failing.
+	// Requests are counted per store generation, so updating the store resets the count.
+	// +optional
+	AfterCalls int `json:"afterCalls,omitempty"`
 }
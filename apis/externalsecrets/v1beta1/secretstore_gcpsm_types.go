@@ -46,4 +46,10 @@ type GCPSMProvider struct {
 
 	// ProjectID project where secret is located
 	ProjectID string `json:"projectID,omitempty"`
+
+	// Location optionally defines a location for regional secrets,
+	// e.g. europe-west1. If set, the regional Secret Manager endpoint
+	// of that location is used instead of the global one.
+	// +optional
+	Location string `json:"location,omitempty"`
 }
@@ -28,6 +28,17 @@ type GitlabProvider struct {
 
 	// ProjectID specifies a project where secrets are located.
 	ProjectID string `json:"projectID,omitempty"`
+
+	// GroupIDs specify, which gitlab groups to pull secrets from. Group variables
+	// are looked up in the given order after the project variables.
+	// +optional
+	GroupIDs []string `json:"groupIDs,omitempty"`
+
+	// Environment environment_scope of gitlab CI/CD variables.
+	// Variables scoped to "*" match any environment.
+	// If not set, variables of all scopes are considered.
+	// +optional
+	Environment string `json:"environment,omitempty"`
 }
 
 type GitlabAuth struct {
@@ -26,6 +26,11 @@ type IBMProvider struct {
 
 	// ServiceURL is the Endpoint URL that is specific to the Secrets Manager service instance
 	ServiceURL *string `json:"serviceUrl,omitempty"`
+
+	// SecretGroupID is the ID of the secret group to find secrets in,
+	// when find.path is not set.
+	// +optional
+	SecretGroupID string `json:"secretGroupID,omitempty"`
 }
 
 type IBMAuth struct {
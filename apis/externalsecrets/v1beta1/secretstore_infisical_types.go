@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// InfisicalProvider configures a store to sync secrets with Infisical.
+type InfisicalProvider struct {
+	// HostAPI is the URL of the Infisical instance. Defaults to https://app.infisical.com.
+	// +optional
+	HostAPI string `json:"hostAPI,omitempty"`
+
+	// ProjectID is the ID of the Infisical project.
+	ProjectID string `json:"projectID"`
+
+	// Environment is the slug of the environment, e.g: "prod".
+	Environment string `json:"environment"`
+
+	// SecretPath is the folder of the secrets. Defaults to "/".
+	// +optional
+	SecretPath string `json:"secretPath,omitempty"`
+
+	// NameTransformer renames the secrets returned by dataFrom, e.g: "lower-kebab".
+	// +optional
+	NameTransformer SecretNameTransformer `json:"nameTransformer,omitempty"`
+
+	// Auth configures how the operator authenticates with Infisical.
+	Auth InfisicalAuth `json:"auth"`
+}
+
+type InfisicalAuth struct {
+	SecretRef InfisicalAuthSecretRef `json:"secretRef"`
+}
+
+type InfisicalAuthSecretRef struct {
+	// ServiceToken is a service token with read access to the environment and path.
+	ServiceToken esmeta.SecretKeySelector `json:"serviceToken"`
+}
@@ -33,17 +33,36 @@ type KubernetesServer struct {
 	// +optional
 	CAProvider *CAProvider `json:"caProvider,omitempty"`
 
-	// there's still room for impersonation or proxy settings:
-	// Impersonate-User
-	// Impersonate-Group
-	// Impersonate-Extra-( extra name )
-	// Impersonate-Uid
-	// Proxy Settings
+	// Impersonate a user, group or service account with the credentials of auth
+	// +optional
+	Impersonate *KubernetesImpersonation `json:"impersonate,omitempty"`
+
+	// there's still room for proxy settings
+}
+
+// KubernetesImpersonation sets the Impersonate-* headers of the requests, the
+// authenticated user needs the impersonate permission for them.
+type KubernetesImpersonation struct {
+	// User to impersonate, e.g: system:serviceaccount:team-a:reader
+	UserName string `json:"userName"`
+
+	// UID of the user to impersonate
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// Groups to impersonate
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Extra fields of the user to impersonate
+	// +optional
+	Extra map[string][]string `json:"extra,omitempty"`
 }
 
 // Configures a store to sync secrets with a Kubernetes instance.
 type KubernetesProvider struct {
-	// configures the Kubernetes server Address.
+	// configures the Kubernetes server Address. Without caBundle and caProvider
+	// the secrets are read from the cluster the operator runs in.
 	Server KubernetesServer `json:"server,omitempty"`
 
 	// Auth configures how secret-manager authenticates with a Kubernetes instance.
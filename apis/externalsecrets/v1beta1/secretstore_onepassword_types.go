@@ -24,17 +24,31 @@ type OnePasswordAuth struct {
 }
 
 // OnePasswordAuthSecretRef holds secret references for 1Password credentials.
+// Exactly one of ConnectToken and ServiceAccountToken must be set.
 type OnePasswordAuthSecretRef struct {
 	// The ConnectToken is used for authentication to a 1Password Connect Server.
-	ConnectToken esmeta.SecretKeySelector `json:"connectTokenSecretRef"`
+	// +optional
+	ConnectToken esmeta.SecretKeySelector `json:"connectTokenSecretRef,omitempty"`
+
+	// The ServiceAccountToken is used for authentication against the
+	// 1Password service account API. No Connect Server is required.
+	// +optional
+	ServiceAccountToken *esmeta.SecretKeySelector `json:"serviceAccountTokenSecretRef,omitempty"`
 }
 
 // OnePasswordProvider configures a store to sync secrets using the 1Password Secret Manager provider.
 type OnePasswordProvider struct {
 	// Auth defines the information necessary to authenticate against OnePassword Connect Server
 	Auth *OnePasswordAuth `json:"auth"`
-	// ConnectHost defines the OnePassword Connect Server to connect to
-	ConnectHost string `json:"connectHost"`
+	// ConnectHost defines the OnePassword Connect Server to connect to.
+	// Required when authenticating with a ConnectToken.
+	// +optional
+	ConnectHost string `json:"connectHost,omitempty"`
+	// ServiceAccountURL defines the 1Password API to connect to
+	// when authenticating with a ServiceAccountToken.
+	// Defaults to https://api.1password.com.
+	// +optional
+	ServiceAccountURL string `json:"serviceAccountURL,omitempty"`
 	// Vaults defines which OnePassword vaults to search in which order
 	Vaults map[string]int `json:"vaults"`
 }
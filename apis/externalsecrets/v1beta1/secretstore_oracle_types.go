@@ -25,8 +25,14 @@ type OracleProvider struct {
 	// Vault is the vault's OCID of the specific vault where secret is located.
 	Vault string `json:"vault"`
 
+	// Compartment is the OCID of the compartment the vault is located in.
+	// It is required to find secrets by name or tags.
+	// +optional
+	Compartment string `json:"compartment,omitempty"`
+
 	// Auth configures how secret-manager authenticates with the Oracle Vault.
-	// If empty, use the instance principal, otherwise the user credentials specified in Auth.
+	// If empty, use the resource principal when running as a workload with a resource principal,
+	// otherwise the instance principal. If set, the user credentials specified in Auth are used.
 	// +optional
 	Auth *OracleAuth `json:"auth,omitempty"`
 }
@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// SopsProvider configures a store to sync secrets from SOPS encrypted documents.
+type SopsProvider struct {
+	// Source holds the encrypted documents
+	Source SopsSource `json:"source"`
+
+	// Auth references the private keys used to decrypt the documents
+	Auth SopsAuth `json:"auth"`
+}
+
+// SopsSource is the ConfigMap, Secret or file bundle holding the encrypted documents.
+// Every key is a document in YAML or JSON format, like the files of a
+// directory added with `kubectl create configmap --from-file`.
+// Exactly one of ConfigMapRef, SecretRef and Path must be set.
+type SopsSource struct {
+	// ConfigMap holding the encrypted documents
+	// +optional
+	ConfigMapRef *SopsSourceRef `json:"configMapRef,omitempty"`
+
+	// Secret holding the encrypted documents
+	// +optional
+	SecretRef *SopsSourceRef `json:"secretRef,omitempty"`
+
+	// Path of a file or directory on the controller filesystem holding the encrypted documents,
+	// e.g. a volume with a Git checkout. Documents are named by their path relative to the directory.
+	// Only allowed in a ClusterSecretStore
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// SopsSourceRef references a ConfigMap or Secret.
+type SopsSourceRef struct {
+	// Name of the ConfigMap or Secret
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap or Secret, only used and required in a ClusterSecretStore
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// SopsAuth references the keys able to decrypt the data key of the documents.
+// At least one of AgeSecretRef and PGPSecretRef must be set.
+type SopsAuth struct {
+	// The age identities, one per line as written by age-keygen
+	// +optional
+	AgeSecretRef *esmeta.SecretKeySelector `json:"ageSecretRef,omitempty"`
+
+	// The ASCII armored PGP private keys
+	// +optional
+	PGPSecretRef *esmeta.SecretKeySelector `json:"pgpSecretRef,omitempty"`
+
+	// The passphrase of the PGP private keys, if they are protected by one
+	// +optional
+	PGPPassphraseSecretRef *esmeta.SecretKeySelector `json:"pgpPassphraseSecretRef,omitempty"`
+}
@@ -105,8 +105,46 @@ type SecretStoreProvider struct {
 	// Senhasegura configures this store to sync secrets using senhasegura provider
 	// +optional
 	Senhasegura *SenhaseguraProvider `json:"senhasegura,omitempty"`
+
+	// Sops configures this store to sync secrets from SOPS encrypted documents
+	// +optional
+	Sops *SopsProvider `json:"sops,omitempty"`
+
+	// Consul configures this store to sync secrets from the HashiCorp Consul KV store
+	// +optional
+	Consul *ConsulProvider `json:"consul,omitempty"`
+
+	// BitwardenSecretsManager configures this store to sync secrets using Bitwarden Secrets Manager
+	// +optional
+	BitwardenSecretsManager *BitwardenSecretsManagerProvider `json:"bitwardensecretsmanager,omitempty"`
+
+	// Conjur configures this store to sync secrets using CyberArk Conjur
+	// +optional
+	Conjur *ConjurProvider `json:"conjur,omitempty"`
+
+	// Doppler configures this store to sync secrets using the Doppler provider
+	// +optional
+	Doppler *DopplerProvider `json:"doppler,omitempty"`
+
+	// Infisical configures this store to sync secrets using the Infisical provider
+	// +optional
+	Infisical *InfisicalProvider `json:"infisical,omitempty"`
 }
 
+// SecretNameTransformer renames the secrets of providers whose secret names follow a
+// convention, e.g. DB_PASSWORD becomes db-password with lower-kebab.
+// +kubebuilder:validation:Enum=upper-snake;lower-snake;lower-kebab;camel;upper-camel;tf-var
+type SecretNameTransformer string
+
+const (
+	SecretNameTransformerUpperSnake SecretNameTransformer = "upper-snake"
+	SecretNameTransformerLowerSnake SecretNameTransformer = "lower-snake"
+	SecretNameTransformerLowerKebab SecretNameTransformer = "lower-kebab"
+	SecretNameTransformerCamel      SecretNameTransformer = "camel"
+	SecretNameTransformerUpperCamel SecretNameTransformer = "upper-camel"
+	SecretNameTransformerTFVar      SecretNameTransformer = "tf-var"
+)
+
 type SecretStoreRetrySettings struct {
 	MaxRetries    *int32  `json:"maxRetries,omitempty"`
 	RetryInterval *string `json:"retryInterval,omitempty"`
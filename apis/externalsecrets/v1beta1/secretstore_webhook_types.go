@@ -59,6 +59,63 @@ type WebhookProvider struct {
 	// The provider for the CA bundle to use to validate webhook server certificate.
 	// +optional
 	CAProvider *WebhookCAProvider `json:"caProvider,omitempty"`
+
+	// Client certificate and key presented to the webhook server for mutual TLS.
+	// +optional
+	ClientTLS *WebhookClientTLS `json:"clientTLS,omitempty"`
+
+	// Find configures the request used by dataFrom.find.
+	// +optional
+	Find *WebhookFind `json:"find,omitempty"`
+
+	// Retry configures retries of failed requests.
+	// +optional
+	Retry *WebhookRetry `json:"retry,omitempty"`
+}
+
+// WebhookClientTLS references the PEM encoded client certificate and key.
+type WebhookClientTLS struct {
+	// Secret ref to the client certificate
+	CertSecretRef esmeta.SecretKeySelector `json:"certSecretRef"`
+
+	// Secret ref to the client private key
+	KeySecretRef esmeta.SecretKeySelector `json:"keySecretRef"`
+}
+
+// WebhookFind defines the request made for dataFrom.find.
+// The templates can use find.name, find.path and the find tags under tags.
+type WebhookFind struct {
+	// Webhook Method, defaults to the store method
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Webhook url to call
+	URL string `json:"url"`
+
+	// Body
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Result formatting, the json path must select an object whose
+	// properties are returned as keys
+	// +optional
+	Result WebhookResult `json:"result,omitempty"`
+}
+
+// WebhookRetry defines how failed requests are retried.
+// Connection errors and responses with status 429 or 5xx are retried,
+// waiting as long as the Retry-After header of the response asks for.
+type WebhookRetry struct {
+	// Maximum number of retries, at most 10
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// Wait before the first retry if the response has no Retry-After header,
+	// doubled for every further retry. Defaults to 1s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
 }
 
 type WebhookCAProviderType string
@@ -34,6 +34,10 @@ type YandexLockboxProvider struct {
 	// +optional
 	APIEndpoint string `json:"apiEndpoint,omitempty"`
 
+	// ID of the folder to find secrets in, required to use dataFrom.find
+	// +optional
+	FolderID string `json:"folderID,omitempty"`
+
 	// Auth defines the information necessary to authenticate against Yandex Lockbox
 	Auth YandexLockboxAuth `json:"auth"`
 
@@ -12,12 +12,13 @@ limitations under the License.
 */
 package gitlab
 
-// TODO - Gitlab only accepts variable names with alphanumeric and '_'
-// whereas ESO only accepts names with alphanumeric and '-'.
-// Current workaround is to remove all hyphens and underscores set in e2e/framework/util/util.go
-// and in e2e/suite/common/common.go, but this breaks Azure provider.
+// Gitlab only accepts variable names with alphanumeric and '_'
+// whereas the common test cases use names with '-'.
+// The provider does not rewrite keys, so we replace the hyphens
+// in the remote keys of the test cases instead.
 
 import (
+	"strings"
 
 	// nolint
 	. "github.com/onsi/ginkgo/v2"
@@ -31,13 +32,43 @@ var _ = Describe("[gitlab]", Label("gitlab"), func() {
 	prov := newFromEnv(f)
 
 	DescribeTable("sync secrets", framework.TableFunc(f, prov),
-		Entry(common.SimpleDataSync(f)),
-		Entry(common.JSONDataWithProperty(f)),
-		Entry(common.JSONDataFromSync(f)),
-		Entry(common.NestedJSONWithGJSON(f)),
-		Entry(common.JSONDataWithTemplate(f)),
-		Entry(common.SyncWithoutTargetName(f)),
-		Entry(common.JSONDataWithoutTargetName(f)),
-		Entry(common.SyncV1Alpha1(f)),
+		Entry(withVariableKeys(common.SimpleDataSync(f))),
+		Entry(withVariableKeys(common.JSONDataWithProperty(f))),
+		Entry(withVariableKeys(common.JSONDataFromSync(f))),
+		Entry(withVariableKeys(common.NestedJSONWithGJSON(f))),
+		Entry(withVariableKeys(common.JSONDataWithTemplate(f))),
+		Entry(withVariableKeys(common.SyncWithoutTargetName(f))),
+		Entry(withVariableKeys(common.JSONDataWithoutTargetName(f))),
+		Entry(withVariableKeys(common.SyncV1Alpha1(f))),
 	)
 })
+
+// withVariableKeys replaces the hyphens in the remote keys with underscores,
+// the same way the provider creates the variables.
+func withVariableKeys(desc string, tweak func(*framework.TestCase)) (string, func(*framework.TestCase)) {
+	return desc, func(tc *framework.TestCase) {
+		tweak(tc)
+		if tc.ExternalSecret != nil {
+			for i := range tc.ExternalSecret.Spec.Data {
+				tc.ExternalSecret.Spec.Data[i].RemoteRef.Key = variableKey(tc.ExternalSecret.Spec.Data[i].RemoteRef.Key)
+			}
+			for i := range tc.ExternalSecret.Spec.DataFrom {
+				if tc.ExternalSecret.Spec.DataFrom[i].Extract != nil {
+					tc.ExternalSecret.Spec.DataFrom[i].Extract.Key = variableKey(tc.ExternalSecret.Spec.DataFrom[i].Extract.Key)
+				}
+			}
+		}
+		if tc.ExternalSecretV1Alpha1 != nil {
+			for i := range tc.ExternalSecretV1Alpha1.Spec.Data {
+				tc.ExternalSecretV1Alpha1.Spec.Data[i].RemoteRef.Key = variableKey(tc.ExternalSecretV1Alpha1.Spec.Data[i].RemoteRef.Key)
+			}
+			for i := range tc.ExternalSecretV1Alpha1.Spec.DataFrom {
+				tc.ExternalSecretV1Alpha1.Spec.DataFrom[i].Key = variableKey(tc.ExternalSecretV1Alpha1.Spec.DataFrom[i].Key)
+			}
+		}
+	}
+}
+
+func variableKey(key string) string {
+	return strings.ReplaceAll(key, "-", "_")
+}
@@ -16,7 +16,6 @@ package gitlab
 import (
 	"context"
 	"os"
-	"strings"
 
 	// nolint
 	. "github.com/onsi/ginkgo/v2"
@@ -61,11 +60,11 @@ func (s *gitlabProvider) CreateSecret(key string, val framework.SecretEntry) {
 	// Open the client**
 
 	// Set variable options
-	variableKey := strings.ReplaceAll(key, "-", "_")
+	key = variableKey(key)
 	variableValue := val
 
 	opt := gitlab.CreateProjectVariableOptions{
-		Key:              &variableKey,
+		Key:              &key,
 		Value:            &variableValue.Value,
 		VariableType:     nil,
 		Protected:        nil,
@@ -88,7 +87,7 @@ func (s *gitlabProvider) DeleteSecret(key string) {
 	// Open a client**
 
 	// Delete the secret
-	_, err = client.ProjectVariables.RemoveVariable(s.projectID, strings.ReplaceAll(key, "-", "_"), &gitlab.RemoveProjectVariableOptions{})
+	_, err = client.ProjectVariables.RemoveVariable(s.projectID, variableKey(key), &gitlab.RemoveProjectVariableOptions{})
 	Expect(err).ToNot(HaveOccurred())
 }
 
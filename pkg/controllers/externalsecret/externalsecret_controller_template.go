@@ -16,6 +16,7 @@ package externalsecret
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
@@ -27,6 +28,7 @@ import (
 	// Loading registered providers.
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
 	"github.com/external-secrets/external-secrets/pkg/template"
+	v3 "github.com/external-secrets/external-secrets/pkg/template/v3"
 	utils "github.com/external-secrets/external-secrets/pkg/utils"
 )
 
@@ -56,7 +58,7 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 	}
 	r.Log.V(1).Info("found template data", "tpl_data", tplMap)
 
-	execute, err := template.EngineForVersion(es.Spec.Target.Template.EngineVersion)
+	execute, err := template.EngineForVersion(es.Spec.Target.Template.EngineVersion, templateMetadata(es, dataMap))
 	if err != nil {
 		return err
 	}
@@ -75,6 +77,32 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 	return nil
 }
 
+// templateMetadata returns the metadata of the ExternalSecret and the tags of
+// the remote secrets fetched with metadataPolicy Fetch. Their values are the
+// tags as JSON object, values of other shapes, e.g. a single tag, are skipped.
+func templateMetadata(es *esv1beta1.ExternalSecret, dataMap map[string][]byte) template.Metadata {
+	tags := make(map[string]map[string]string)
+	for _, data := range es.Spec.Data {
+		if data.RemoteRef.MetadataPolicy != esv1beta1.ExternalSecretMetadataPolicyFetch {
+			continue
+		}
+		var secretTags map[string]string
+		if err := json.Unmarshal(dataMap[data.SecretKey], &secretTags); err != nil {
+			continue
+		}
+		tags[data.SecretKey] = secretTags
+	}
+	return template.Metadata{
+		ExternalSecret: v3.Metadata{
+			Name:        es.Name,
+			Namespace:   es.Namespace,
+			Labels:      es.Labels,
+			Annotations: es.Annotations,
+		},
+		Tags: tags,
+	}
+}
+
 // we do not want to force-override the label/annotations
 // and only copy the necessary key/value pairs.
 func mergeMetadata(secret *v1.Secret, externalSecret *esv1beta1.ExternalSecret) {
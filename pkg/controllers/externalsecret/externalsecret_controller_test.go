@@ -16,6 +16,7 @@ package externalsecret
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"strconv"
@@ -475,6 +476,46 @@ var _ = Describe("ExternalSecret controller", func() {
 		}
 	}
 
+	// when using a v3 template it should pass the metadata of the ExternalSecret and the tags
+	syncWithTemplateV3 := func(tc *testCase) {
+		const tplKey = "owner"
+		tc.externalSecret.ObjectMeta.Labels = map[string]string{
+			"team": "payments",
+		}
+		tc.externalSecret.Spec.Data[0].RemoteRef.Property = ""
+		tc.externalSecret.Spec.Data[0].RemoteRef.MetadataPolicy = esv1beta1.ExternalSecretMetadataPolicyFetch
+		tc.externalSecret.Spec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+			Type:          v1.SecretTypeOpaque,
+			EngineVersion: esv1beta1.TemplateEngineV3,
+			Data: map[string]string{
+				tplKey: `{{ .Metadata.Namespace }}/{{ .Metadata.Labels.team }}: {{ index .Tags "targetProperty" "env" }} {{ .Strings.targetProperty }}`,
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(`{"env":"prod"}`), nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[tplKey])).To(Equal(es.Namespace + `/payments: prod {"env":"prod"}`))
+		}
+	}
+
+	// the GCP provider returns labels and annotations as one flat map,
+	// annotations are prefixed with "annotations."
+	syncWithTemplateV3GCPTags := func(tc *testCase) {
+		const tplKey = "owner"
+		tc.externalSecret.Spec.Data[0].RemoteRef.Property = ""
+		tc.externalSecret.Spec.Data[0].RemoteRef.MetadataPolicy = esv1beta1.ExternalSecretMetadataPolicyFetch
+		tc.externalSecret.Spec.Target.Template = &esv1beta1.ExternalSecretTemplate{
+			Type:          v1.SecretTypeOpaque,
+			EngineVersion: esv1beta1.TemplateEngineV3,
+			Data: map[string]string{
+				tplKey: `{{ index .Tags "targetProperty" "owner" }}/{{ index .Tags "targetProperty" "annotations.rotation" }}`,
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(`{"annotations.rotation":"monthly","owner":"team-a"}`), nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[tplKey])).To(Equal("team-a/monthly"))
+		}
+	}
+
 	// secret should be synced with correct value precedence:
 	// * template
 	// * templateFrom
@@ -1021,6 +1062,45 @@ var _ = Describe("ExternalSecret controller", func() {
 		}
 	}
 
+	// with a decodingStrategy the values of data and dataFrom
+	// should be decoded before they are put into the secret
+	syncWithDecodingStrategy := func(tc *testCase) {
+		const secretVal = "someValue"
+		tc.externalSecret.Spec.Data[0].RemoteRef.DecodingStrategy = esv1beta1.ExternalSecretDecodeBase64
+		tc.externalSecret.Spec.DataFrom = []esv1beta1.ExternalSecretDataFromRemoteRef{
+			{
+				Extract: &esv1beta1.ExternalSecretDataRemoteRef{
+					Key:              remoteKey,
+					DecodingStrategy: esv1beta1.ExternalSecretDecodeAuto,
+				},
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(base64.StdEncoding.EncodeToString([]byte(secretVal))), nil)
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"foo": []byte(base64.URLEncoding.EncodeToString([]byte(FooValue))),
+			"bar": []byte("?" + BarValue),
+		}, nil)
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+			Expect(string(secret.Data["foo"])).To(Equal(FooValue))
+			Expect(string(secret.Data["bar"])).To(Equal("?" + BarValue))
+		}
+	}
+
+	// when a value can not be decoded
+	// a error condition must be set.
+	decodingErrCondition := func(tc *testCase) {
+		tc.externalSecret.Spec.Data[0].RemoteRef.DecodingStrategy = esv1beta1.ExternalSecretDecodeBase64
+		fakeProvider.WithGetSecret([]byte("not base64!"), nil)
+		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1beta1.ConditionReasonSecretSyncedError {
+				return false
+			}
+			return cond.Message == errGetSecretData
+		}
+	}
+
 	// when a provider errors in a GetSecret call
 	// a error condition must be set.
 	providerErrCondition := func(tc *testCase) {
@@ -1276,6 +1356,8 @@ var _ = Describe("ExternalSecret controller", func() {
 		Entry("should not delete pre-existing secret with creationPolicy=Orphan", createSecretPolicyOrphan),
 		Entry("should sync with template", syncWithTemplate),
 		Entry("should sync with template engine v2", syncWithTemplateV2),
+		Entry("should sync with template engine v3", syncWithTemplateV3),
+		Entry("should pass GCP labels and annotations as tags to template engine v3", syncWithTemplateV3GCPTags),
 		Entry("should sync template with correct value precedence", syncWithTemplatePrecedence),
 		Entry("should refresh secret from template", refreshWithTemplate),
 		Entry("should be able to use only metadata from template", onlyMetadataFromTemplate),
@@ -1286,6 +1368,8 @@ var _ = Describe("ExternalSecret controller", func() {
 		Entry("should fetch secret using dataFrom", syncWithDataFrom),
 		Entry("should fetch secret using dataFrom.find", syncDataFromFind),
 		Entry("should fetch secret using dataFrom and a template", syncWithDataFromTemplate),
+		Entry("should decode secret values with decodingStrategy", syncWithDecodingStrategy),
+		Entry("should set an error condition when a secret value can not be decoded", decodingErrCondition),
 		Entry("should set error condition when provider errors", providerErrCondition),
 		Entry("should set an error condition when store does not exist", storeMissingErrCondition),
 		Entry("should set an error condition when store provider constructor fails", storeConstructErrCondition),
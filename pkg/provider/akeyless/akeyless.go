@@ -23,14 +23,20 @@ import (
 	"time"
 
 	"github.com/akeylesslabs/akeyless-go/v2"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const (
 	defaultAPIUrl = "https://api.akeyless.io"
+	defaultPath   = "/"
+	tagSeparator  = ":"
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
@@ -45,6 +51,7 @@ type akeylessBase struct {
 	kube      client.Client
 	store     esv1beta1.GenericStore
 	namespace string
+	corev1    typedcorev1.CoreV1Interface
 
 	akeylessGwAPIURL string
 	RestAPI          *akeyless.V2ApiService
@@ -58,6 +65,7 @@ type Akeyless struct {
 type akeylessVaultInterface interface {
 	GetSecretByType(secretName, token string, version int32) (string, error)
 	TokenFromSecretRef(ctx context.Context) (string, error)
+	ListItems(path, token string) ([]akeyless.Item, error)
 }
 
 func init() {
@@ -68,7 +76,28 @@ func init() {
 
 // NewClient constructs a new secrets client based on the provided store.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
-	return newClient(ctx, store, kube, namespace)
+	prov, err := GetAKeylessProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	// only the Kubernetes auth needs the TokenRequest API, other auth methods
+	// must not depend on the operator's in-cluster config.
+	var corev1 typedcorev1.CoreV1Interface
+	if prov.Auth != nil && prov.Auth.KubernetesAuth != nil {
+		// controller-runtime/client does not support TokenRequest or other subresource APIs
+		// so we need to construct our own client and use it to fetch tokens
+		// (for Kubernetes service account token auth)
+		restCfg, err := ctrlcfg.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := kubernetes.NewForConfig(restCfg)
+		if err != nil {
+			return nil, err
+		}
+		corev1 = clientset.CoreV1()
+	}
+	return newClient(ctx, store, kube, corev1, namespace)
 }
 
 func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
@@ -88,6 +117,30 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
 		}
 	}
 
+	if akeylessSpec.Auth == nil {
+		return fmt.Errorf(errMissingAuth)
+	}
+
+	if kubernetesAuth := akeylessSpec.Auth.KubernetesAuth; kubernetesAuth != nil {
+		if kubernetesAuth.AccessID == "" {
+			return fmt.Errorf(errInvalidKubernetesAccessID)
+		}
+		if kubernetesAuth.K8sConfName == "" {
+			return fmt.Errorf(errInvalidKubernetesConfName)
+		}
+		// only a ClusterSecretStore may fall back to the token of the controller
+		if kubernetesAuth.ServiceAccountRef == nil {
+			if store.GetObjectKind().GroupVersionKind().Kind != esv1beta1.ClusterSecretStoreKind {
+				return fmt.Errorf(errMissingKubeSA)
+			}
+			return nil
+		}
+		if err := utils.ValidateReferentServiceAccountSelector(store, *kubernetesAuth.ServiceAccountRef); err != nil {
+			return fmt.Errorf(errInvalidKubeSA, err)
+		}
+		return nil
+	}
+
 	accessID := akeylessSpec.Auth.SecretRef.AccessID
 	err := utils.ValidateSecretSelector(store, accessID)
 	if err != nil {
@@ -117,11 +170,12 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
 	return nil
 }
 
-func newClient(_ context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+func newClient(_ context.Context, store esv1beta1.GenericStore, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (esv1beta1.SecretsClient, error) {
 	akl := &akeylessBase{
 		kube:      kube,
 		store:     store,
 		namespace: namespace,
+		corev1:    corev1,
 	}
 
 	spec, err := GetAKeylessProvider(store)
@@ -134,7 +188,7 @@ func newClient(_ context.Context, store esv1beta1.GenericStore, kube client.Clie
 	}
 
 	if spec.Auth == nil {
-		return nil, fmt.Errorf("missing Auth in store config")
+		return nil, fmt.Errorf(errMissingAuth)
 	}
 
 	RestAPIClient := akeyless.NewAPIClient(&akeyless.Configuration{
@@ -190,10 +244,78 @@ func (a *Akeyless) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDa
 	return []byte(value), nil
 }
 
-// Empty GetAllSecrets.
+// Implements store.Client.GetAllSecrets Interface.
+// Lists the static secrets in the folder find.path (default "/") and its subfolders
+// and retrieves those matching find.name.regexp and find.tags, keyed by their full name.
 func (a *Akeyless) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not implemented")
+	if utils.IsNil(a.Client) {
+		return nil, fmt.Errorf(errUninitalizedAkeylessProvider)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+
+	token, err := a.Client.TokenFromSecretRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := defaultPath
+	if ref.Path != nil && *ref.Path != "" {
+		path = *ref.Path
+	}
+	items, err := a.Client.ListItems(path, token)
+	if err != nil {
+		return nil, err
+	}
+
+	secretData := make(map[string][]byte)
+	for _, item := range items {
+		if item.GetItemType() != itemTypeStaticSecret {
+			continue
+		}
+		name := item.GetItemName()
+		if matcher != nil && !matcher.MatchName(name) {
+			continue
+		}
+		if !hasTags(item.GetItemTags(), ref.Tags) {
+			continue
+		}
+		value, err := a.Client.GetSecretByType(name, token, 0)
+		if err != nil {
+			return nil, err
+		}
+		secretData[name] = []byte(value)
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretData)
+}
+
+// hasTags checks that the item has all tags. Akeyless tags are plain strings,
+// a tag matches "key:value" or "key" if its value is empty.
+func hasTags(itemTags []string, tags map[string]string) bool {
+	for k, v := range tags {
+		tag := k
+		if v != "" {
+			tag = k + tagSeparator + v
+		}
+		if !containsTag(itemTags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsTag(itemTags []string, tag string) bool {
+	for _, t := range itemTags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
 }
 
 // Implements store.Client.GetSecretMap Interface.
@@ -32,7 +32,11 @@ import (
 
 var apiErr akeyless.GenericOpenAPIError
 
-const DefServiceAccountFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+const (
+	DefServiceAccountFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	itemTypeStaticSecret = "STATIC_SECRET"
+)
 
 func (a *akeylessBase) GetToken(accessID, accType, accTypeParam string) (string, error) {
 	ctx := context.Background()
@@ -45,10 +49,7 @@ func (a *akeylessBase) GetToken(accessID, accType, accTypeParam string) (string,
 		if err != nil {
 			return "", fmt.Errorf("failed to read JWT with Kubernetes Auth from %v. error: %w", DefServiceAccountFile, err)
 		}
-		K8SAuthConfigName := accTypeParam
-		authBody.AccessType = akeyless.PtrString(accType)
-		authBody.K8sServiceAccountToken = akeyless.PtrString(jwtString)
-		authBody.K8sAuthConfigName = akeyless.PtrString(K8SAuthConfigName)
+		return a.GetK8sToken(accessID, accTypeParam, jwtString)
 	} else {
 		cloudID, err := a.getCloudID(accType, accTypeParam)
 		if err != nil {
@@ -58,6 +59,20 @@ func (a *akeylessBase) GetToken(accessID, accType, accTypeParam string) (string,
 		authBody.CloudId = akeyless.PtrString(cloudID)
 	}
 
+	return a.auth(ctx, authBody)
+}
+
+// GetK8sToken authenticates with the Kubernetes auth method using the given base64 encoded ServiceAccount JWT.
+func (a *akeylessBase) GetK8sToken(accessID, k8sConfName, jwt string) (string, error) {
+	authBody := akeyless.NewAuthWithDefaults()
+	authBody.AccessId = akeyless.PtrString(accessID)
+	authBody.AccessType = akeyless.PtrString("k8s")
+	authBody.K8sServiceAccountToken = akeyless.PtrString(jwt)
+	authBody.K8sAuthConfigName = akeyless.PtrString(k8sConfName)
+	return a.auth(context.Background(), authBody)
+}
+
+func (a *akeylessBase) auth(ctx context.Context, authBody *akeyless.Auth) (string, error) {
 	authOut, _, err := a.RestAPI.Auth(ctx).Body(*authBody).Execute()
 	if err != nil {
 		if errors.As(err, &apiErr) {
@@ -78,7 +93,7 @@ func (a *akeylessBase) GetSecretByType(secretName, token string, version int32)
 	secretType := item.GetItemType()
 
 	switch secretType {
-	case "STATIC_SECRET":
+	case itemTypeStaticSecret:
 		return a.GetStaticSecret(secretName, token, version)
 	case "DYNAMIC_SECRET":
 		return a.GetDynamicSecrets(secretName, token)
@@ -111,6 +126,48 @@ func (a *akeylessBase) DescribeItem(itemName, token string) (*akeyless.Item, err
 	return &gsvOut, nil
 }
 
+// ListItems returns the items in the folder path and all of its subfolders.
+func (a *akeylessBase) ListItems(path, token string) ([]akeyless.Item, error) {
+	ctx := context.Background()
+
+	var items []akeyless.Item
+	folders := []string{path}
+	visited := make(map[string]bool)
+	for len(folders) > 0 {
+		folder := folders[0]
+		folders = folders[1:]
+		if visited[folder] {
+			continue
+		}
+		visited[folder] = true
+
+		body := akeyless.ListItems{
+			Path: &folder,
+		}
+		if strings.HasPrefix(token, "u-") {
+			body.UidToken = &token
+		} else {
+			body.Token = &token
+		}
+		for {
+			out, _, err := a.RestAPI.ListItems(ctx).Body(body).Execute()
+			if err != nil {
+				if errors.As(err, &apiErr) {
+					return nil, fmt.Errorf("can't list items: %v", string(apiErr.Body()))
+				}
+				return nil, fmt.Errorf("can't list items: %w", err)
+			}
+			items = append(items, out.GetItems()...)
+			folders = append(folders, out.GetFolders()...)
+			if out.GetNextPage() == "" {
+				break
+			}
+			body.PaginationToken = out.NextPage
+		}
+	}
+	return items, nil
+}
+
 func (a *akeylessBase) GetRotatedSecrets(secretName, token string, version int32) (string, error) {
 	ctx := context.Background()
 
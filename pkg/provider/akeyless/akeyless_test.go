@@ -15,11 +15,21 @@ package akeyless
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/akeylesslabs/akeyless-go/v2"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	utilpointer "k8s.io/utils/pointer"
+
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	fakeakeyless "github.com/external-secrets/external-secrets/pkg/provider/akeyless/fake"
@@ -195,6 +205,225 @@ func TestGetSecretMap(t *testing.T) {
 	}
 }
 
+func TestValidateStoreKubernetesAuth(t *testing.T) {
+	provider := Provider{}
+	makeStore := func(kind string, auth *esv1beta1.AkeylessKubernetesAuth) esv1beta1.GenericStore {
+		store := &esv1beta1.SecretStore{
+			TypeMeta: metav1.TypeMeta{Kind: kind},
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider: &esv1beta1.SecretStoreProvider{
+					Akeyless: &esv1beta1.AkeylessProvider{
+						Auth: &esv1beta1.AkeylessAuth{KubernetesAuth: auth},
+					},
+				},
+			},
+		}
+		return store
+	}
+	sa := &esmeta.ServiceAccountSelector{Name: "my-sa", Namespace: utilpointer.StringPtr("other")}
+
+	err := provider.ValidateStore(makeStore(esv1beta1.SecretStoreKind, &esv1beta1.AkeylessKubernetesAuth{AccessID: "p-123", K8sConfName: "conf", ServiceAccountRef: &esmeta.ServiceAccountSelector{Name: "my-sa"}}))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = provider.ValidateStore(makeStore(esv1beta1.SecretStoreKind, &esv1beta1.AkeylessKubernetesAuth{AccessID: "p-123", K8sConfName: "conf"}))
+	if !ErrorContains(err, errMissingKubeSA) {
+		t.Errorf("unexpected error: %v, expected: '%s'", err, errMissingKubeSA)
+	}
+	err = provider.ValidateStore(makeStore(esv1beta1.ClusterSecretStoreKind, &esv1beta1.AkeylessKubernetesAuth{AccessID: "p-123", K8sConfName: "conf"}))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = provider.ValidateStore(makeStore(esv1beta1.SecretStoreKind, &esv1beta1.AkeylessKubernetesAuth{K8sConfName: "conf"}))
+	if !ErrorContains(err, errInvalidKubernetesAccessID) {
+		t.Errorf("unexpected error: %v, expected: '%s'", err, errInvalidKubernetesAccessID)
+	}
+	err = provider.ValidateStore(makeStore(esv1beta1.SecretStoreKind, &esv1beta1.AkeylessKubernetesAuth{AccessID: "p-123"}))
+	if !ErrorContains(err, errInvalidKubernetesConfName) {
+		t.Errorf("unexpected error: %v, expected: '%s'", err, errInvalidKubernetesConfName)
+	}
+	err = provider.ValidateStore(makeStore(esv1beta1.SecretStoreKind, &esv1beta1.AkeylessKubernetesAuth{AccessID: "p-123", K8sConfName: "conf", ServiceAccountRef: sa}))
+	if !ErrorContains(err, "namespace not allowed with namespaced SecretStore") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = provider.ValidateStore(makeStore(esv1beta1.ClusterSecretStoreKind, &esv1beta1.AkeylessKubernetesAuth{AccessID: "p-123", K8sConfName: "conf", ServiceAccountRef: sa}))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func makeItem(name, itemType string, tags ...string) akeyless.Item {
+	return akeyless.Item{
+		ItemName: akeyless.PtrString(name),
+		ItemType: akeyless.PtrString(itemType),
+		ItemTags: &tags,
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	items := []akeyless.Item{
+		makeItem("/prod/db-password", itemTypeStaticSecret, "env:prod", "team:backend"),
+		makeItem("/prod/db-user", itemTypeStaticSecret, "env:prod"),
+		makeItem("/prod/db-dynamic", "DYNAMIC_SECRET", "env:prod"),
+		makeItem("/dev/db-password", itemTypeStaticSecret, "env:dev", "shared"),
+	}
+	values := map[string]string{
+		"/prod/db-password": "prod-pass",
+		"/prod/db-user":     "prod-user",
+		"/dev/db-password":  "dev-pass",
+	}
+	// keys are converted with the default strategy which drops the slashes of the item names
+	tbl := []struct {
+		name        string
+		ref         esv1beta1.ExternalSecretFind
+		expected    map[string][]byte
+		expectError string
+	}{
+		{
+			name: "find by name",
+			ref:  esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "password$"}},
+			expected: map[string][]byte{
+				"proddb-password": []byte("prod-pass"),
+				"devdb-password":  []byte("dev-pass"),
+			},
+		},
+		{
+			name: "find by path",
+			ref:  esv1beta1.ExternalSecretFind{Path: utilpointer.StringPtr("/prod")},
+			expected: map[string][]byte{
+				"proddb-password": []byte("prod-pass"),
+				"proddb-user":     []byte("prod-user"),
+			},
+		},
+		{
+			name: "find by tags",
+			ref:  esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod", "team": "backend"}},
+			expected: map[string][]byte{
+				"proddb-password": []byte("prod-pass"),
+			},
+		},
+		{
+			name: "find by tag without value",
+			ref:  esv1beta1.ExternalSecretFind{Tags: map[string]string{"shared": ""}},
+			expected: map[string][]byte{
+				"devdb-password": []byte("dev-pass"),
+			},
+		},
+		{
+			name:        "invalid regexp",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "["}},
+			expectError: "could not compile find.name.regexp",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			mockClient := &fakeakeyless.AkeylessMockClient{}
+			mockClient.WithItems(items, values)
+			sm := Akeyless{Client: mockClient}
+			out, err := sm.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expected) {
+				t.Errorf("unexpected secret data: expected %#v, got %#v", row.expected, out)
+			}
+		})
+	}
+}
+
+type fakeK8sV1 struct {
+	k8sv1.CoreV1Interface
+	namespace string
+}
+
+func (m *fakeK8sV1) ServiceAccounts(namespace string) k8sv1.ServiceAccountInterface {
+	m.namespace = namespace
+	return &fakeK8sV1SA{}
+}
+
+type fakeK8sV1SA struct {
+	k8sv1.ServiceAccountInterface
+}
+
+func (ma *fakeK8sV1SA) CreateToken(
+	ctx context.Context,
+	serviceAccountName string,
+	tokenRequest *authv1.TokenRequest,
+	opts metav1.CreateOptions,
+) (*authv1.TokenRequest, error) {
+	return &authv1.TokenRequest{
+		Status: authv1.TokenRequestStatus{
+			Token: "jwt-of-" + serviceAccountName,
+		},
+	}, nil
+}
+
+func TestTokenFromKubernetesAuth(t *testing.T) {
+	var authBody akeyless.Auth
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&authBody)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token":"t-123"}`))
+	}))
+	defer server.Close()
+
+	for _, kind := range []string{esv1beta1.SecretStoreKind, esv1beta1.ClusterSecretStoreKind} {
+		corev1 := &fakeK8sV1{}
+		store := &esv1beta1.SecretStore{
+			TypeMeta: metav1.TypeMeta{Kind: kind},
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider: &esv1beta1.SecretStoreProvider{
+					Akeyless: &esv1beta1.AkeylessProvider{
+						Auth: &esv1beta1.AkeylessAuth{
+							KubernetesAuth: &esv1beta1.AkeylessKubernetesAuth{
+								AccessID:          "p-123",
+								K8sConfName:       "k8s-conf",
+								ServiceAccountRef: &esmeta.ServiceAccountSelector{Name: "my-sa"},
+							},
+						},
+					},
+				},
+			},
+		}
+		a := &akeylessBase{
+			store:     store,
+			namespace: "tenant-a",
+			corev1:    corev1,
+			RestAPI: akeyless.NewAPIClient(&akeyless.Configuration{
+				Servers: []akeyless.ServerConfiguration{{URL: server.URL}},
+			}).V2Api,
+		}
+		token, err := a.TokenFromSecretRef(context.Background())
+		if err != nil {
+			t.Fatalf("[%s] unexpected error: %v", kind, err)
+		}
+		if token != "t-123" {
+			t.Errorf("[%s] unexpected token: %s", kind, token)
+		}
+		// the service account is resolved in the namespace of the ExternalSecret
+		if corev1.namespace != "tenant-a" {
+			t.Errorf("[%s] unexpected service account namespace: %s", kind, corev1.namespace)
+		}
+		if authBody.GetAccessId() != "p-123" || authBody.GetAccessType() != "k8s" || authBody.GetK8sAuthConfigName() != "k8s-conf" {
+			t.Errorf("[%s] unexpected auth request: %+v", kind, authBody)
+		}
+		if authBody.GetK8sServiceAccountToken() != base64.StdEncoding.EncodeToString([]byte("jwt-of-my-sa")) {
+			t.Errorf("[%s] unexpected service account token: %s", kind, authBody.GetK8sServiceAccountToken())
+		}
+	}
+
+	// a SecretStore must not authenticate with the token of the controller
+	a := &akeylessBase{store: &esv1beta1.SecretStore{TypeMeta: metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind}}}
+	_, err := a.TokenFromKubernetesAuth(context.Background(), &esv1beta1.AkeylessKubernetesAuth{AccessID: "p-123", K8sConfName: "k8s-conf"})
+	if !ErrorContains(err, errMissingKubeSA) {
+		t.Errorf("unexpected error: %v, expected: '%s'", err, errMissingKubeSA)
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
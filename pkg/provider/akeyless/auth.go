@@ -16,12 +16,16 @@ package akeyless
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 const (
@@ -31,13 +35,20 @@ const (
 	errFetchSAKSecret                          = "could not fetch AccessType secret: %w"
 	errMissingSAK                              = "missing SecretAccessKey"
 	errMissingAKID                             = "missing AccessKeyID"
+	errGetKubeSATokenRequest                   = "cannot request Kubernetes service account token for service account %q: %w"
+
+	serviceAccountTokenExpirationSeconds = 600
 )
 
+// TokenFromSecretRef authenticates with the auth method configured in the store.
 func (a *akeylessBase) TokenFromSecretRef(ctx context.Context) (string, error) {
 	prov, err := GetAKeylessProvider(a.store)
 	if err != nil {
 		return "", err
 	}
+	if prov.Auth.KubernetesAuth != nil {
+		return a.TokenFromKubernetesAuth(ctx, prov.Auth.KubernetesAuth)
+	}
 
 	ke := client.ObjectKey{
 		Name:      prov.Auth.SecretRef.AccessID.Name,
@@ -101,3 +112,40 @@ func (a *akeylessBase) TokenFromSecretRef(ctx context.Context) (string, error) {
 
 	return a.GetToken(accessID, accessType, accessTypeParam)
 }
+
+// TokenFromKubernetesAuth authenticates with the token of the referenced ServiceAccount.
+// A ClusterSecretStore without ServiceAccount uses the token of the controller.
+func (a *akeylessBase) TokenFromKubernetesAuth(ctx context.Context, kubernetesAuth *esv1beta1.AkeylessKubernetesAuth) (string, error) {
+	if kubernetesAuth.ServiceAccountRef == nil {
+		if a.store.GetObjectKind().GroupVersionKind().Kind != esv1beta1.ClusterSecretStoreKind {
+			return "", fmt.Errorf(errMissingKubeSA)
+		}
+		return a.GetToken(kubernetesAuth.AccessID, "k8s", kubernetesAuth.K8sConfName)
+	}
+	jwt, err := a.serviceAccountToken(ctx, *kubernetesAuth.ServiceAccountRef)
+	if err != nil {
+		return "", err
+	}
+	return a.GetK8sToken(kubernetesAuth.AccessID, kubernetesAuth.K8sConfName, base64.StdEncoding.EncodeToString([]byte(jwt)))
+}
+
+func (a *akeylessBase) serviceAccountToken(ctx context.Context, serviceAccountRef esmeta.ServiceAccountSelector) (string, error) {
+	expirationSeconds := int64(serviceAccountTokenExpirationSeconds)
+	tokenRequest := &authenticationv1.TokenRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: a.namespace,
+		},
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	if (a.store.GetObjectKind().GroupVersionKind().Kind == esv1beta1.ClusterSecretStoreKind) &&
+		(serviceAccountRef.Namespace != nil) {
+		tokenRequest.Namespace = *serviceAccountRef.Namespace
+	}
+	tokenResponse, err := a.corev1.ServiceAccounts(tokenRequest.Namespace).CreateToken(ctx, serviceAccountRef.Name, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf(errGetKubeSATokenRequest, serviceAccountRef.Name, err)
+	}
+	return tokenResponse.Status.Token, nil
+}
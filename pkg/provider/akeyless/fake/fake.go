@@ -15,10 +15,43 @@ package fake
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/akeylesslabs/akeyless-go/v2"
 )
 
 type AkeylessMockClient struct {
 	getSecret func(secretName, token string, version int32) (string, error)
+	listItems func(path, token string) ([]akeyless.Item, error)
+}
+
+func (mc *AkeylessMockClient) ListItems(path, token string) ([]akeyless.Item, error) {
+	return mc.listItems(path, token)
+}
+
+// WithItems lists the given items and serves the value of each item by its name.
+// ListItems returns the items whose name starts with the listed path.
+func (mc *AkeylessMockClient) WithItems(items []akeyless.Item, values map[string]string) {
+	if mc == nil {
+		return
+	}
+	mc.listItems = func(path, token string) ([]akeyless.Item, error) {
+		var out []akeyless.Item
+		for _, item := range items {
+			if strings.HasPrefix(item.GetItemName(), path) {
+				out = append(out, item)
+			}
+		}
+		return out, nil
+	}
+	mc.getSecret = func(secretName, token string, version int32) (string, error) {
+		value, ok := values[secretName]
+		if !ok {
+			return "", fmt.Errorf("can't get secret: %v", secretName)
+		}
+		return value, nil
+	}
 }
 
 func (mc *AkeylessMockClient) TokenFromSecretRef(ctx context.Context) (string, error) {
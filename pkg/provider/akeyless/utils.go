@@ -34,6 +34,11 @@ const (
 	errInvalidAkeylessURL           = "invalid akeyless GW API URL"
 	errInvalidAkeylessAccessIDName  = "missing akeyless accessID name"
 	errInvalidAkeylessAccessIDKey   = "missing akeyless accessID key"
+	errInvalidKubernetesAccessID    = "missing akeyless kubernetesAuth accessID"
+	errInvalidKubernetesConfName    = "missing akeyless kubernetesAuth k8sConfName"
+	errInvalidKubeSA                = "invalid Auth.KubernetesAuth.ServiceAccountRef: %w"
+	errMissingKubeSA                = "missing akeyless kubernetesAuth serviceAccountRef, required in a SecretStore"
+	errMissingAuth                  = "missing Auth in store config"
 )
 
 // GetAKeylessProvider does the necessary nil checks and returns the akeyless provider or an error.
@@ -15,15 +15,64 @@ limitations under the License.
 package fake
 
 import (
+	"fmt"
+
 	kmssdk "github.com/aliyun/alibaba-cloud-sdk-go/services/kms"
 )
 
 type AlibabaMockClient struct {
 	getSecretValue func(request *kmssdk.GetSecretValueRequest) (response *kmssdk.GetSecretValueResponse, err error)
+	listSecrets    func(request *kmssdk.ListSecretsRequest) (response *kmssdk.ListSecretsResponse, err error)
+}
+
+func (mc *AlibabaMockClient) GetSecretValue(request *kmssdk.GetSecretValueRequest) (result *kmssdk.GetSecretValueResponse, err error) {
+	return mc.getSecretValue(request)
 }
 
-func (mc *AlibabaMockClient) GetSecretValue(*kmssdk.GetSecretValueRequest) (result *kmssdk.GetSecretValueResponse, err error) {
-	return mc.getSecretValue(&kmssdk.GetSecretValueRequest{})
+func (mc *AlibabaMockClient) ListSecrets(request *kmssdk.ListSecretsRequest) (result *kmssdk.ListSecretsResponse, err error) {
+	return mc.listSecrets(request)
+}
+
+// WithSecrets lists the given secrets in pages of the requested size
+// and serves the value of each secret by its name.
+func (mc *AlibabaMockClient) WithSecrets(secrets []kmssdk.Secret, values map[string]string) {
+	if mc == nil {
+		return
+	}
+	mc.listSecrets = func(request *kmssdk.ListSecretsRequest) (*kmssdk.ListSecretsResponse, error) {
+		page, err := request.PageNumber.GetValue()
+		if err != nil {
+			return nil, err
+		}
+		pageSize, err := request.PageSize.GetValue()
+		if err != nil {
+			return nil, err
+		}
+		start := (page - 1) * pageSize
+		end := start + pageSize
+		if start > len(secrets) {
+			start = len(secrets)
+		}
+		if end > len(secrets) {
+			end = len(secrets)
+		}
+		resp := kmssdk.CreateListSecretsResponse()
+		resp.PageNumber = page
+		resp.PageSize = pageSize
+		resp.TotalCount = len(secrets)
+		resp.SecretList.Secret = secrets[start:end]
+		return resp, nil
+	}
+	mc.getSecretValue = func(request *kmssdk.GetSecretValueRequest) (*kmssdk.GetSecretValueResponse, error) {
+		value, ok := values[request.SecretName]
+		if !ok {
+			return nil, fmt.Errorf("secret %s not found", request.SecretName)
+		}
+		resp := kmssdk.CreateGetSecretValueResponse()
+		resp.SecretName = request.SecretName
+		resp.SecretData = value
+		return resp, nil
+	}
 }
 
 func (mc *AlibabaMockClient) WithValue(in *kmssdk.GetSecretValueRequest, val *kmssdk.GetSecretValueResponse, err error) {
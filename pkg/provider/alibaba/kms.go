@@ -18,15 +18,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	kmssdk "github.com/aliyun/alibaba-cloud-sdk-go/services/kms"
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
@@ -40,6 +45,13 @@ const (
 	errFetchAKIDSecret                         = "could not fetch AccessKeyID secret: %w"
 	errMissingSAK                              = "missing AccessSecretKey"
 	errMissingAKID                             = "missing AccessKeyID"
+	errMissingAuth                             = "missing alibaba auth: either secretRef or rrsa must be set"
+
+	listSecretsPageSize  = 100
+	versionStageCurrent  = "ACSCurrent"
+	versionStagePrevious = "ACSPrevious"
+	// custom version stages are referenced with this prefix, e.g. stage/MyStage.
+	versionStagePrefix = "stage/"
 )
 
 type Client struct {
@@ -63,6 +75,7 @@ type KeyManagementService struct {
 
 type SMInterface interface {
 	GetSecretValue(request *kmssdk.GetSecretValueRequest) (response *kmssdk.GetSecretValueResponse, err error)
+	ListSecrets(request *kmssdk.ListSecretsRequest) (response *kmssdk.ListSecretsResponse, err error)
 }
 
 // setAuth creates a new Alibaba session based on a store.
@@ -101,8 +114,6 @@ func (c *Client) setAuth(ctx context.Context) error {
 		objectKey.Namespace = *c.store.Auth.SecretRef.AccessKeySecret.Namespace
 	}
 	c.keyID = credentialsSecret.Data[c.store.Auth.SecretRef.AccessKeyID.Key]
-	fmt.Println(c.keyID)
-	fmt.Println(c.accessKey)
 	if (c.keyID == nil) || (len(c.keyID) == 0) {
 		return fmt.Errorf(errMissingAKID)
 	}
@@ -114,10 +125,80 @@ func (c *Client) setAuth(ctx context.Context) error {
 	return nil
 }
 
-// Empty GetAllSecrets.
+// GetAllSecrets lists all secrets page by page and returns the current
+// version of those matching find.name.regexp and find.tags.
 func (kms *KeyManagementService) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not implemented")
+	if utils.IsNil(kms.Client) {
+		return nil, fmt.Errorf(errUninitalizedAlibabaProvider)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+
+	request := kmssdk.CreateListSecretsRequest()
+	request.SetScheme("https")
+	request.FetchTags = "true"
+	request.PageSize = requests.NewInteger(listSecretsPageSize)
+	secretData := make(map[string][]byte)
+	for page := 1; ; page++ {
+		request.PageNumber = requests.NewInteger(page)
+		resp, err := kms.Client.ListSecrets(request)
+		if err != nil {
+			return nil, util.SanitizeErr(err)
+		}
+		for _, secret := range resp.SecretList.Secret {
+			if matcher != nil && !matcher.MatchName(secret.SecretName) {
+				continue
+			}
+			if !hasTags(secret.Tags.Tag, ref.Tags) {
+				continue
+			}
+			value, err := kms.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: secret.SecretName})
+			if err != nil {
+				return nil, err
+			}
+			secretData[secret.SecretName] = value
+		}
+		if len(resp.SecretList.Secret) == 0 || page*listSecretsPageSize >= resp.TotalCount {
+			break
+		}
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretData)
+}
+
+func hasTags(secretTags []kmssdk.Tag, tags map[string]string) bool {
+	for k, v := range tags {
+		found := false
+		for _, tag := range secretTags {
+			if tag.TagKey == k && tag.TagValue == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// setSecretVersion selects a version by its stage or by its id.
+// ACSCurrent and ACSPrevious are stages, custom stages are prefixed with stage/.
+// Any other version is a version id.
+func setSecretVersion(request *kmssdk.GetSecretValueRequest, version string) {
+	switch {
+	case version == versionStageCurrent || version == versionStagePrevious:
+		request.VersionStage = version
+	case strings.HasPrefix(version, versionStagePrefix):
+		request.VersionStage = strings.TrimPrefix(version, versionStagePrefix)
+	default:
+		request.VersionId = version
+	}
 }
 
 // GetSecret returns a single secret from the provider.
@@ -126,7 +207,7 @@ func (kms *KeyManagementService) GetSecret(ctx context.Context, ref esv1beta1.Ex
 		return nil, fmt.Errorf(errUninitalizedAlibabaProvider)
 	}
 	kmsRequest := kmssdk.CreateGetSecretValueRequest()
-	kmsRequest.VersionId = ref.Version
+	setSecretVersion(kmsRequest, ref.Version)
 	kmsRequest.SecretName = ref.Key
 	kmsRequest.SetScheme("https")
 	secretOut, err := kms.Client.GetSecretValue(kmsRequest)
@@ -172,21 +253,55 @@ func (kms *KeyManagementService) GetSecretMap(ctx context.Context, ref esv1beta1
 func (kms *KeyManagementService) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	storeSpec := store.GetSpec()
 	alibabaSpec := storeSpec.Provider.Alibaba
-	iStore := &Client{
-		kube:      kube,
-		store:     alibabaSpec,
-		namespace: namespace,
-		storeKind: store.GetObjectKind().GroupVersionKind().Kind,
-	}
-	if err := iStore.setAuth(ctx); err != nil {
-		return nil, err
+	if alibabaSpec.Auth == nil {
+		return nil, fmt.Errorf(errMissingAuth)
 	}
-	alibabaRegion := iStore.regionID
-	alibabaKeyID := iStore.keyID
-	alibabaSecretKey := iStore.accessKey
-	keyManagementService, err := kmssdk.NewClientWithAccessKey(alibabaRegion, string(alibabaKeyID), string(alibabaSecretKey))
-	if err != nil {
-		return nil, fmt.Errorf(errAlibabaClient, err)
+
+	var keyManagementService *kmssdk.Client
+	if rrsa := alibabaSpec.Auth.RRSAAuth; rrsa != nil {
+		// controller-runtime/client does not support TokenRequest or other subresource APIs
+		// so we need to construct our own client and use it to fetch tokens
+		restCfg, err := ctrlcfg.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := kubernetes.NewForConfig(restCfg)
+		if err != nil {
+			return nil, err
+		}
+		token, err := rrsaToken(ctx, clientset.CoreV1(), store.GetObjectKind().GroupVersionKind().Kind, namespace, rrsa.ServiceAccountRef)
+		if err != nil {
+			return nil, err
+		}
+		credentials, err := assumeRoleWithOIDC(ctx, defaultSTSEndpoint, rrsa, token)
+		if err != nil {
+			return nil, err
+		}
+		keyManagementService, err = kmssdk.NewClientWithStsToken(alibabaSpec.RegionID, credentials.AccessKeyID, credentials.AccessKeySecret, credentials.SecurityToken)
+		if err != nil {
+			return nil, fmt.Errorf(errAlibabaClient, err)
+		}
+	} else {
+		if alibabaSpec.Auth.SecretRef == nil {
+			return nil, fmt.Errorf(errMissingAuth)
+		}
+		iStore := &Client{
+			kube:      kube,
+			store:     alibabaSpec,
+			namespace: namespace,
+			storeKind: store.GetObjectKind().GroupVersionKind().Kind,
+		}
+		if err := iStore.setAuth(ctx); err != nil {
+			return nil, err
+		}
+		alibabaRegion := iStore.regionID
+		alibabaKeyID := iStore.keyID
+		alibabaSecretKey := iStore.accessKey
+		var err error
+		keyManagementService, err = kmssdk.NewClientWithAccessKey(alibabaRegion, string(alibabaKeyID), string(alibabaSecretKey))
+		if err != nil {
+			return nil, fmt.Errorf(errAlibabaClient, err)
+		}
 	}
 	kms.Client = keyManagementService
 	kms.url = alibabaSpec.Endpoint
@@ -217,6 +332,27 @@ func (kms *KeyManagementService) ValidateStore(store esv1beta1.GenericStore) err
 		return fmt.Errorf("missing alibaba region")
 	}
 
+	if alibabaSpec.Auth == nil {
+		return fmt.Errorf(errMissingAuth)
+	}
+
+	if rrsa := alibabaSpec.Auth.RRSAAuth; rrsa != nil {
+		if rrsa.OIDCProviderARN == "" {
+			return fmt.Errorf("missing alibaba rrsa oidcProviderArn")
+		}
+		if rrsa.RoleARN == "" {
+			return fmt.Errorf("missing alibaba rrsa roleArn")
+		}
+		if rrsa.ServiceAccountRef.Name == "" {
+			return fmt.Errorf("missing alibaba rrsa serviceAccountRef name")
+		}
+		return utils.ValidateReferentServiceAccountSelector(store, rrsa.ServiceAccountRef)
+	}
+
+	if alibabaSpec.Auth.SecretRef == nil {
+		return fmt.Errorf(errMissingAuth)
+	}
+
 	accessKeyID := alibabaSpec.Auth.SecretRef.AccessKeyID
 	err := utils.ValidateSecretSelector(store, accessKeyID)
 	if err != nil {
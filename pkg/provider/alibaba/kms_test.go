@@ -23,6 +23,7 @@ import (
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
 	kmssdk "github.com/aliyun/alibaba-cloud-sdk-go/services/kms"
+	utilpointer "k8s.io/utils/pointer"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -196,7 +197,7 @@ func TestValidateStore(t *testing.T) {
 				Alibaba: &esv1beta1.AlibabaProvider{
 					RegionID: "region-1",
 					Auth: &esv1beta1.AlibabaAuth{
-						SecretRef: esv1beta1.AlibabaAuthSecretRef{
+						SecretRef: &esv1beta1.AlibabaAuthSecretRef{
 							AccessKeyID: esmeta.SecretKeySelector{
 								Name: "accessKeyID",
 								Key:  "key-1",
@@ -218,6 +219,174 @@ func TestValidateStore(t *testing.T) {
 	}
 }
 
+func TestValidateStoreRRSA(t *testing.T) {
+	kms := KeyManagementService{}
+	makeStore := func(rrsa *esv1beta1.AlibabaRRSAAuth) *esv1beta1.SecretStore {
+		return &esv1beta1.SecretStore{
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider: &esv1beta1.SecretStoreProvider{
+					Alibaba: &esv1beta1.AlibabaProvider{
+						RegionID: "region-1",
+						Auth:     &esv1beta1.AlibabaAuth{RRSAAuth: rrsa},
+					},
+				},
+			},
+		}
+	}
+	sa := esmeta.ServiceAccountSelector{Name: "alibaba-sa"}
+	err := kms.ValidateStore(makeStore(&esv1beta1.AlibabaRRSAAuth{
+		OIDCProviderARN:   "acs:ram::1234:oidc-provider/ack-rrsa",
+		RoleARN:           "acs:ram::1234:role/external-secrets",
+		ServiceAccountRef: sa,
+	}))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = kms.ValidateStore(makeStore(&esv1beta1.AlibabaRRSAAuth{
+		OIDCProviderARN:   "acs:ram::1234:oidc-provider/ack-rrsa",
+		ServiceAccountRef: sa,
+	}))
+	if !ErrorContains(err, "missing alibaba rrsa roleArn") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = kms.ValidateStore(makeStore(&esv1beta1.AlibabaRRSAAuth{
+		OIDCProviderARN: "acs:ram::1234:oidc-provider/ack-rrsa",
+		RoleARN:         "acs:ram::1234:role/external-secrets",
+	}))
+	if !ErrorContains(err, "missing alibaba rrsa serviceAccountRef name") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = kms.ValidateStore(makeStore(&esv1beta1.AlibabaRRSAAuth{
+		OIDCProviderARN:   "acs:ram::1234:oidc-provider/ack-rrsa",
+		RoleARN:           "acs:ram::1234:role/external-secrets",
+		ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "alibaba-sa", Namespace: utilpointer.StringPtr("other")},
+	}))
+	if !ErrorContains(err, "namespace not allowed with namespaced SecretStore") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = kms.ValidateStore(makeStore(nil))
+	if !ErrorContains(err, errMissingAuth) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSetSecretVersion(t *testing.T) {
+	tbl := []struct {
+		version     string
+		expectStage string
+		expectID    string
+	}{
+		{version: ""},
+		{version: "ACSCurrent", expectStage: "ACSCurrent"},
+		{version: "ACSPrevious", expectStage: "ACSPrevious"},
+		{version: "stage/MyStage", expectStage: "MyStage"},
+		{version: "v1", expectID: "v1"},
+	}
+	for _, row := range tbl {
+		request := kmssdk.CreateGetSecretValueRequest()
+		setSecretVersion(request, row.version)
+		if request.VersionStage != row.expectStage || request.VersionId != row.expectID {
+			t.Errorf("[%s] unexpected version: stage %q id %q", row.version, request.VersionStage, request.VersionId)
+		}
+	}
+}
+
+func makeSecret(name string, tags map[string]string) kmssdk.Secret {
+	secret := kmssdk.Secret{SecretName: name}
+	for k, v := range tags {
+		secret.Tags.Tag = append(secret.Tags.Tag, kmssdk.Tag{TagKey: k, TagValue: v})
+	}
+	return secret
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	secrets := []kmssdk.Secret{
+		makeSecret("db-password", map[string]string{"env": "prod", "team": "backend"}),
+		makeSecret("db-user", map[string]string{"env": "prod"}),
+		makeSecret("db-host", map[string]string{"env": "dev"}),
+		makeSecret("api-key", nil),
+	}
+	values := map[string]string{
+		"db-password": "s3cr3t",
+		"db-user":     "user",
+		"db-host":     "localhost",
+		"api-key":     "key",
+	}
+	tbl := []struct {
+		name        string
+		ref         esv1beta1.ExternalSecretFind
+		expected    map[string][]byte
+		expectError string
+	}{
+		{
+			name: "find by name",
+			ref:  esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^db-"}},
+			expected: map[string][]byte{
+				"db-password": []byte("s3cr3t"),
+				"db-user":     []byte("user"),
+				"db-host":     []byte("localhost"),
+			},
+		},
+		{
+			name: "find by tags",
+			ref:  esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expected: map[string][]byte{
+				"db-password": []byte("s3cr3t"),
+				"db-user":     []byte("user"),
+			},
+		},
+		{
+			name: "find by name and tags",
+			ref: esv1beta1.ExternalSecretFind{
+				Name: &esv1beta1.FindName{RegExp: "password"},
+				Tags: map[string]string{"team": "backend"},
+			},
+			expected: map[string][]byte{
+				"db-password": []byte("s3cr3t"),
+			},
+		},
+		{
+			name:        "invalid regexp",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "["}},
+			expectError: "could not compile find.name.regexp",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			mockClient := &fakesm.AlibabaMockClient{}
+			mockClient.WithSecrets(secrets, values)
+			kms := KeyManagementService{Client: mockClient}
+			out, err := kms.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expected) {
+				t.Errorf("unexpected secret data: expected %#v, got %#v", row.expected, out)
+			}
+		})
+	}
+}
+
+func TestGetAllSecretsPagination(t *testing.T) {
+	var secrets []kmssdk.Secret
+	values := make(map[string]string)
+	for i := 0; i < 2*listSecretsPageSize+10; i++ {
+		name := fmt.Sprintf("secret-%d", i)
+		secrets = append(secrets, makeSecret(name, nil))
+		values[name] = name
+	}
+	mockClient := &fakesm.AlibabaMockClient{}
+	mockClient.WithSecrets(secrets, values)
+	kms := KeyManagementService{Client: mockClient}
+	out, err := kms.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(secrets) {
+		t.Errorf("expected %d secrets, got %d", len(secrets), len(out))
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
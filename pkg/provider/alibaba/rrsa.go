@@ -0,0 +1,133 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alibaba
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	defaultSTSEndpoint         = "https://sts.aliyuncs.com"
+	defaultRoleSessionName     = "external-secrets"
+	stsAPIVersion              = "2015-04-01"
+	stsTimeout                 = 30 * time.Second
+	rrsaTokenAudience          = "sts.aliyuncs.com"
+	rrsaTokenExpirationSeconds = 600
+
+	errRRSATokenRequest = "could not request OIDC token of service account %s: %w"
+	errRRSARequest      = "could not assume role %s with OIDC token: %w"
+	errRRSAResponse     = "could not assume role %s with OIDC token: %s: %s"
+	errRRSADecode       = "could not decode AssumeRoleWithOIDC response: %w"
+	errRRSANoCredential = "AssumeRoleWithOIDC response of role %s contains no credentials"
+)
+
+// stsCredentials are the temporary credentials of an assumed role.
+type stsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	AccessKeySecret string `json:"AccessKeySecret"`
+	SecurityToken   string `json:"SecurityToken"`
+	Expiration      string `json:"Expiration"`
+}
+
+// rrsaToken requests an OIDC token of the referenced ServiceAccount with the TokenRequest API.
+// A SecretStore can only reference a ServiceAccount in its own namespace,
+// a ClusterSecretStore may set the namespace of the ServiceAccount.
+func rrsaToken(ctx context.Context, corev1 typedcorev1.CoreV1Interface, storeKind, namespace string, serviceAccountRef esmeta.ServiceAccountSelector) (string, error) {
+	expirationSeconds := int64(rrsaTokenExpirationSeconds)
+	tokenRequest := &authenticationv1.TokenRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+		},
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{rrsaTokenAudience},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	if storeKind == esv1beta1.ClusterSecretStoreKind && serviceAccountRef.Namespace != nil {
+		tokenRequest.Namespace = *serviceAccountRef.Namespace
+	}
+	tokenResponse, err := corev1.ServiceAccounts(tokenRequest.Namespace).CreateToken(ctx, serviceAccountRef.Name, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf(errRRSATokenRequest, serviceAccountRef.Name, err)
+	}
+	return tokenResponse.Status.Token, nil
+}
+
+// assumeRoleWithOIDC exchanges the OIDC token of the service account for temporary
+// credentials of the RAM role. The STS API is called anonymously,
+// the request is authenticated by the OIDC token.
+func assumeRoleWithOIDC(ctx context.Context, stsEndpoint string, auth *esv1beta1.AlibabaRRSAAuth, token string) (*stsCredentials, error) {
+	sessionName := auth.SessionName
+	if sessionName == "" {
+		sessionName = defaultRoleSessionName
+	}
+
+	form := url.Values{}
+	form.Set("Action", "AssumeRoleWithOIDC")
+	form.Set("Format", "JSON")
+	form.Set("Version", stsAPIVersion)
+	form.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05Z"))
+	form.Set("RoleArn", auth.RoleARN)
+	form.Set("OIDCProviderArn", auth.OIDCProviderARN)
+	form.Set("OIDCToken", token)
+	form.Set("RoleSessionName", sessionName)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, stsEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf(errRRSARequest, auth.RoleARN, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := &http.Client{Timeout: stsTimeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRRSARequest, auth.RoleARN, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf(errRRSARequest, auth.RoleARN, err)
+	}
+
+	var out struct {
+		Code        string          `json:"Code"`
+		Message     string          `json:"Message"`
+		Credentials *stsCredentials `json:"Credentials"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf(errRRSADecode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(errRRSAResponse, auth.RoleARN, out.Code, out.Message)
+	}
+	if out.Credentials == nil || out.Credentials.AccessKeyID == "" {
+		return nil, fmt.Errorf(errRRSANoCredential, auth.RoleARN)
+	}
+	return out.Credentials, nil
+}
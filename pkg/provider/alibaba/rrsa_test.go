@@ -0,0 +1,157 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alibaba
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	utilpointer "k8s.io/utils/pointer"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	oidcProviderARN = "acs:ram::1234:oidc-provider/ack-rrsa"
+	roleARN         = "acs:ram::1234:role/external-secrets"
+	oidcToken       = "oidc-token"
+)
+
+func newSTSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("Action") != "AssumeRoleWithOIDC" ||
+			r.PostForm.Get("OIDCProviderArn") != oidcProviderARN ||
+			r.PostForm.Get("OIDCToken") != oidcToken {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"Code":"AuthenticationFail.OIDCToken.Invalid","Message":"invalid token"}`))
+			return
+		}
+		if r.PostForm.Get("RoleArn") != roleARN {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"Code":"EntityNotExist.Role","Message":"role does not exist"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"RequestId":"1","Credentials":{"AccessKeyId":"STS.id","AccessKeySecret":"secret","SecurityToken":"token","Expiration":"2030-01-01T00:00:00Z"}}`))
+	}))
+}
+
+func TestAssumeRoleWithOIDC(t *testing.T) {
+	server := newSTSServer(t)
+	defer server.Close()
+
+	tbl := []struct {
+		name        string
+		auth        esv1beta1.AlibabaRRSAAuth
+		token       string
+		expectError string
+	}{
+		{
+			name:  "valid token",
+			auth:  esv1beta1.AlibabaRRSAAuth{OIDCProviderARN: oidcProviderARN, RoleARN: roleARN},
+			token: oidcToken,
+		},
+		{
+			name:        "unknown role",
+			auth:        esv1beta1.AlibabaRRSAAuth{OIDCProviderARN: oidcProviderARN, RoleARN: "acs:ram::1234:role/other"},
+			token:       oidcToken,
+			expectError: "EntityNotExist.Role: role does not exist",
+		},
+		{
+			name:        "invalid token",
+			auth:        esv1beta1.AlibabaRRSAAuth{OIDCProviderARN: oidcProviderARN, RoleARN: roleARN},
+			token:       "other-token",
+			expectError: "AuthenticationFail.OIDCToken.Invalid: invalid token",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			creds, err := assumeRoleWithOIDC(context.Background(), server.URL, &row.auth, row.token)
+			if !ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if err == nil && (creds.AccessKeyID != "STS.id" || creds.AccessKeySecret != "secret" || creds.SecurityToken != "token") {
+				t.Errorf("unexpected credentials: %+v", creds)
+			}
+		})
+	}
+}
+
+type fakeK8sV1 struct {
+	k8sv1.CoreV1Interface
+	namespace string
+	request   *authv1.TokenRequest
+}
+
+func (m *fakeK8sV1) ServiceAccounts(namespace string) k8sv1.ServiceAccountInterface {
+	m.namespace = namespace
+	return &fakeK8sV1SA{parent: m}
+}
+
+type fakeK8sV1SA struct {
+	k8sv1.ServiceAccountInterface
+	parent *fakeK8sV1
+}
+
+func (ma *fakeK8sV1SA) CreateToken(
+	ctx context.Context,
+	serviceAccountName string,
+	tokenRequest *authv1.TokenRequest,
+	opts metav1.CreateOptions,
+) (*authv1.TokenRequest, error) {
+	ma.parent.request = tokenRequest
+	return &authv1.TokenRequest{
+		Status: authv1.TokenRequestStatus{
+			Token: "jwt-of-" + serviceAccountName,
+		},
+	}, nil
+}
+
+func TestRRSAToken(t *testing.T) {
+	sa := esmeta.ServiceAccountSelector{Name: "alibaba-sa", Namespace: utilpointer.StringPtr("other")}
+	tbl := []struct {
+		kind            string
+		expectNamespace string
+	}{
+		{kind: esv1beta1.SecretStoreKind, expectNamespace: "tenant-a"},
+		{kind: esv1beta1.ClusterSecretStoreKind, expectNamespace: "other"},
+	}
+	for _, row := range tbl {
+		corev1 := &fakeK8sV1{}
+		token, err := rrsaToken(context.Background(), corev1, row.kind, "tenant-a", sa)
+		if err != nil {
+			t.Fatalf("[%s] unexpected error: %v", row.kind, err)
+		}
+		if token != "jwt-of-alibaba-sa" {
+			t.Errorf("[%s] unexpected token: %s", row.kind, token)
+		}
+		if corev1.namespace != row.expectNamespace {
+			t.Errorf("[%s] unexpected service account namespace: %s", row.kind, corev1.namespace)
+		}
+		if audiences := corev1.request.Spec.Audiences; len(audiences) != 1 || audiences[0] != rrsaTokenAudience {
+			t.Errorf("[%s] unexpected audiences: %v", row.kind, audiences)
+		}
+	}
+}
@@ -352,7 +352,7 @@ func TestNewSession(t *testing.T) {
 					},
 				},
 			},
-			jwtProvider: func(name, namespace, roleArn, region string) (credentials.Provider, error) {
+			jwtProvider: func(name, namespace, roleArn, region string, customEndpoints map[string]string) (credentials.Provider, error) {
 				assert.Equal(t, myServiceAccountKey, name)
 				assert.Equal(t, otherNsName, namespace)
 				assert.Equal(t, "my-sa-role", roleArn)
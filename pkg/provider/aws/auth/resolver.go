@@ -23,22 +23,43 @@ const (
 	SecretsManagerEndpointEnv = "AWS_SECRETSMANAGER_ENDPOINT"
 	STSEndpointEnv            = "AWS_STS_ENDPOINT"
 	SSMEndpointEnv            = "AWS_SSM_ENDPOINT"
+
+	SecretsManagerService = "secretsmanager"
+	STSService            = "sts"
+	SSMService            = "ssm"
 )
 
 // ResolveEndpoint returns a ResolverFunc with
 // customizable endpoints.
 func ResolveEndpoint() endpoints.ResolverFunc {
+	return ResolveEndpointWithServiceMap(envEndpoints())
+}
+
+// ResolveEndpointWithOverrides returns a ResolverFunc which uses the
+// given per-store endpoints and falls back to the endpoints
+// configured through the environment.
+func ResolveEndpointWithOverrides(overrides map[string]string) endpoints.ResolverFunc {
+	customEndpoints := envEndpoints()
+	for service, ep := range overrides {
+		if ep != "" {
+			customEndpoints[service] = ep
+		}
+	}
+	return ResolveEndpointWithServiceMap(customEndpoints)
+}
+
+func envEndpoints() map[string]string {
 	customEndpoints := make(map[string]string)
 	if v := os.Getenv(SecretsManagerEndpointEnv); v != "" {
-		customEndpoints["secretsmanager"] = v
+		customEndpoints[SecretsManagerService] = v
 	}
 	if v := os.Getenv(SSMEndpointEnv); v != "" {
-		customEndpoints["ssm"] = v
+		customEndpoints[SSMService] = v
 	}
 	if v := os.Getenv(STSEndpointEnv); v != "" {
-		customEndpoints["sts"] = v
+		customEndpoints[STSService] = v
 	}
-	return ResolveEndpointWithServiceMap(customEndpoints)
+	return customEndpoints
 }
 
 func ResolveEndpointWithServiceMap(customEndpoints map[string]string) endpoints.ResolverFunc {
@@ -56,3 +56,38 @@ func TestResolver(t *testing.T) {
 		assert.Equal(t, item.url, ep.URL)
 	}
 }
+
+func TestResolverWithOverrides(t *testing.T) {
+	os.Setenv(SecretsManagerEndpointEnv, "http://sm.env")
+	defer os.Unsetenv(SecretsManagerEndpointEnv)
+	os.Setenv(SSMEndpointEnv, "http://ssm.env")
+	defer os.Unsetenv(SSMEndpointEnv)
+
+	f := ResolveEndpointWithOverrides(map[string]string{
+		SecretsManagerService: "http://sm.store",
+		STSService:            "http://sts.store",
+	})
+
+	tbl := []struct {
+		service string
+		url     string
+	}{
+		{
+			service: SecretsManagerService,
+			url:     "http://sm.store",
+		},
+		{
+			service: SSMService,
+			url:     "http://ssm.env",
+		},
+		{
+			service: STSService,
+			url:     "http://sts.store",
+		},
+	}
+	for _, item := range tbl {
+		ep, err := f.EndpointFor(item.service, "")
+		assert.Nil(t, err)
+		assert.Equal(t, item.url, ep.URL)
+	}
+}
@@ -15,22 +15,43 @@ package fake
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/google/go-cmp/cmp"
 )
 
 // Client implements the aws parameterstore interface.
 type Client struct {
-	valFn func(*ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+	valFn    func(*ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+	byPathFn func(*ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+	tagsFn   func(*ssm.ListTagsForResourceInput) (*ssm.ListTagsForResourceOutput, error)
+	params   []*ssm.ParameterMetadata
 }
 
 func (sm *Client) GetParameter(in *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
 	return sm.valFn(in)
 }
 
+func (sm *Client) GetParametersByPath(in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+	return sm.byPathFn(in)
+}
+
+func (sm *Client) ListTagsForResource(in *ssm.ListTagsForResourceInput) (*ssm.ListTagsForResourceOutput, error) {
+	return sm.tagsFn(in)
+}
+
 func (sm *Client) DescribeParameters(*ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error) {
-	return nil, nil
+	return &ssm.DescribeParametersOutput{Parameters: sm.params}, nil
+}
+
+// WithParameters returns the given parameters for every DescribeParameters call.
+func (sm *Client) WithParameters(names ...string) {
+	sm.params = make([]*ssm.ParameterMetadata, 0, len(names))
+	for _, name := range names {
+		sm.params = append(sm.params, &ssm.ParameterMetadata{Name: aws.String(name)})
+	}
 }
 
 func (sm *Client) WithValue(in *ssm.GetParameterInput, val *ssm.GetParameterOutput, err error) {
@@ -41,3 +62,39 @@ func (sm *Client) WithValue(in *ssm.GetParameterInput, val *ssm.GetParameterOutp
 		return val, err
 	}
 }
+
+// WithParametersByPath returns the given pages one after another,
+// the NextToken of each page is set accordingly.
+func (sm *Client) WithParametersByPath(path string, pages [][]*ssm.Parameter, err error) {
+	sm.byPathFn = func(in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+		if err != nil {
+			return nil, err
+		}
+		if *in.Path != path || !*in.Recursive || !*in.WithDecryption {
+			return nil, fmt.Errorf("unexpected test argument")
+		}
+		idx := 0
+		if in.NextToken != nil {
+			idx, _ = strconv.Atoi(*in.NextToken)
+		}
+		if idx >= len(pages) {
+			return &ssm.GetParametersByPathOutput{}, nil
+		}
+		out := &ssm.GetParametersByPathOutput{
+			Parameters: pages[idx],
+		}
+		if idx+1 < len(pages) {
+			out.NextToken = aws.String(strconv.Itoa(idx + 1))
+		}
+		return out, nil
+	}
+}
+
+func (sm *Client) WithTags(in *ssm.ListTagsForResourceInput, val *ssm.ListTagsForResourceOutput, err error) {
+	sm.tagsFn = func(tagsIn *ssm.ListTagsForResourceInput) (*ssm.ListTagsForResourceOutput, error) {
+		if !cmp.Equal(tagsIn, in) {
+			return nil, fmt.Errorf("unexpected test argument")
+		}
+		return val, err
+	}
+}
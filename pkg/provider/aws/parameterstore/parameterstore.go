@@ -45,7 +45,9 @@ type ParameterStore struct {
 // see: https://docs.aws.amazon.com/sdk-for-go/api/service/ssm/ssmiface/
 type PMInterface interface {
 	GetParameter(*ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+	GetParametersByPath(*ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
 	DescribeParameters(*ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error)
+	ListTagsForResource(*ssm.ListTagsForResourceInput) (*ssm.ListTagsForResourceOutput, error)
 }
 
 const (
@@ -60,8 +62,13 @@ func New(sess *session.Session) (*ParameterStore, error) {
 	}, nil
 }
 
-// Empty GetAllSecrets.
+// GetAllSecrets fetches all parameters that match the find criteria.
+// If only a path (optionally with a name) is given, the whole
+// hierarchy is fetched with GetParametersByPath.
 func (pm *ParameterStore) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path != nil && ref.Tags == nil {
+		return pm.findByPath(ref)
+	}
 	if ref.Name != nil {
 		return pm.findByName(ref)
 	}
@@ -71,6 +78,52 @@ func (pm *ParameterStore) GetAllSecrets(ctx context.Context, ref esv1beta1.Exter
 	return nil, errors.New(errUnexpectedFindOperator)
 }
 
+func (pm *ParameterStore) findByPath(ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	params, err := pm.getParametersByPath(*ref.Path)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte)
+	for _, param := range params {
+		if matcher != nil && !matcher.MatchName(*param.Name) {
+			continue
+		}
+		data[*param.Name] = []byte(aws.StringValue(param.Value))
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, data)
+}
+
+// getParametersByPath recursively fetches and decrypts all parameters below path.
+func (pm *ParameterStore) getParametersByPath(path string) ([]*ssm.Parameter, error) {
+	params := make([]*ssm.Parameter, 0)
+	var nextToken *string
+	for {
+		it, err := pm.client.GetParametersByPath(&ssm.GetParametersByPathInput{
+			Path:           aws.String(path),
+			Recursive:      aws.Bool(true),
+			WithDecryption: aws.Bool(true),
+			NextToken:      nextToken,
+		})
+		if err != nil {
+			return nil, util.SanitizeErr(err)
+		}
+		params = append(params, it.Parameters...)
+		nextToken = it.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+	return params, nil
+}
+
 func (pm *ParameterStore) findByName(ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
 	matcher, err := find.New(*ref.Name)
 	if err != nil {
@@ -169,9 +222,17 @@ func (pm *ParameterStore) fetchAndSet(data map[string][]byte, name string) error
 }
 
 // GetSecret returns a single secret from the provider.
+// A version number or label can be selected using ref.Version.
 func (pm *ParameterStore) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		tags, err := pm.getParameterTags(ref.Key)
+		if err != nil {
+			return nil, err
+		}
+		return util.TagValue(tags, ref, "parameter")
+	}
 	out, err := pm.client.GetParameter(&ssm.GetParameterInput{
-		Name:           &ref.Key,
+		Name:           aws.String(parameterName(ref)),
 		WithDecryption: aws.Bool(true),
 	})
 
@@ -203,8 +264,52 @@ func (pm *ParameterStore) GetSecret(ctx context.Context, ref esv1beta1.ExternalS
 	return []byte(val.String()), nil
 }
 
+// parameterName returns the parameter name including the
+// version or label selector, e.g. name:3 or name:label.
+func parameterName(ref esv1beta1.ExternalSecretDataRemoteRef) string {
+	if ref.Version == "" {
+		return ref.Key
+	}
+	return ref.Key + ":" + ref.Version
+}
+
+func (pm *ParameterStore) getParameterTags(name string) (map[string]string, error) {
+	out, err := pm.client.ListTagsForResource(&ssm.ListTagsForResourceInput{
+		ResourceId:   aws.String(name),
+		ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+	})
+	var nf *ssm.InvalidResourceId
+	if errors.As(err, &nf) {
+		return nil, esv1beta1.NoSecretErr
+	}
+	if err != nil {
+		return nil, util.SanitizeErr(err)
+	}
+	tags := make(map[string]string, len(out.TagList))
+	for _, tag := range out.TagList {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return tags, nil
+}
+
 // GetSecretMap returns multiple k/v pairs from the provider.
+// If ref.Key ends with a slash it is treated as a path and all parameters
+// below it are returned, keyed by their name relative to the path.
 func (pm *ParameterStore) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		tags, err := pm.getParameterTags(ref.Key)
+		if err != nil {
+			return nil, err
+		}
+		secretData := make(map[string][]byte, len(tags))
+		for k, v := range tags {
+			secretData[k] = []byte(v)
+		}
+		return secretData, nil
+	}
+	if strings.HasSuffix(ref.Key, "/") {
+		return pm.getSecretMapByPath(ref.Key)
+	}
 	data, err := pm.GetSecret(ctx, ref)
 	if err != nil {
 		return nil, err
@@ -221,6 +326,26 @@ func (pm *ParameterStore) GetSecretMap(ctx context.Context, ref esv1beta1.Extern
 	return secretData, nil
 }
 
+func (pm *ParameterStore) getSecretMapByPath(prefix string) (map[string][]byte, error) {
+	path := strings.TrimSuffix(prefix, "/")
+	if path == "" {
+		path = "/"
+	}
+	params, err := pm.getParametersByPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) == 0 {
+		return nil, esv1beta1.NoSecretErr
+	}
+	secretData := make(map[string][]byte, len(params))
+	for _, param := range params {
+		name := strings.TrimPrefix(*param.Name, prefix)
+		secretData[name] = []byte(aws.StringValue(param.Value))
+	}
+	return secretData, nil
+}
+
 func (pm *ParameterStore) Close(ctx context.Context) error {
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	fake "github.com/external-secrets/external-secrets/pkg/provider/aws/parameterstore/fake"
@@ -130,7 +131,23 @@ func TestGetSecret(t *testing.T) {
 		pstc.expectError = "oh no"
 	}
 
+	// good case: version label is appended to the parameter name
+	setVersionLabel := func(pstc *parameterstoreTestCase) {
+		pstc.remoteRef.Version = "prod"
+		pstc.apiInput.Name = aws.String("/baz:prod")
+		pstc.expectedSecret = "RRRRR"
+	}
+
+	// good case: version number is appended to the parameter name
+	setVersionNumber := func(pstc *parameterstoreTestCase) {
+		pstc.remoteRef.Version = "3"
+		pstc.apiInput.Name = aws.String("/baz:3")
+		pstc.expectedSecret = "RRRRR"
+	}
+
 	successCases := []*parameterstoreTestCase{
+		makeValidParameterStoreTestCaseCustom(setVersionLabel),
+		makeValidParameterStoreTestCaseCustom(setVersionNumber),
 		makeValidParameterStoreTestCaseCustom(setSecretString),
 		makeValidParameterStoreTestCaseCustom(setExtractProperty),
 		makeValidParameterStoreTestCaseCustom(setMissingProperty),
@@ -191,6 +208,185 @@ func TestGetSecretMap(t *testing.T) {
 	}
 }
 
+func TestGetSecretTags(t *testing.T) {
+	tagsInput := &ssm.ListTagsForResourceInput{
+		ResourceId:   aws.String("/baz"),
+		ResourceType: aws.String(ssm.ResourceTypeForTaggingParameter),
+	}
+	tagsOutput := &ssm.ListTagsForResourceOutput{
+		TagList: []*ssm.Tag{
+			{Key: aws.String("owner"), Value: aws.String("team-a")},
+			{Key: aws.String("rotation"), Value: aws.String("2022-01-01")},
+		},
+	}
+	fakeClient := &fake.Client{}
+	fakeClient.WithTags(tagsInput, tagsOutput, nil)
+	ps := ParameterStore{client: fakeClient}
+
+	out, err := ps.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "/baz",
+		Property:       "owner",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", string(out))
+
+	out, err = ps.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "/baz",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"owner":"team-a","rotation":"2022-01-01"}`, string(out))
+
+	_, err = ps.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "/baz",
+		Property:       "missing",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	assert.EqualError(t, err, "tag missing does not exist in parameter /baz")
+
+	data, err := ps.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "/baz",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"owner":    []byte("team-a"),
+		"rotation": []byte("2022-01-01"),
+	}, data)
+}
+
+func TestGetSecretMapByPath(t *testing.T) {
+	fakeClient := &fake.Client{}
+	fakeClient.WithParametersByPath("/app", [][]*ssm.Parameter{
+		{
+			{Name: aws.String("/app/db/user"), Value: aws.String("admin")},
+		},
+		{
+			{Name: aws.String("/app/db/pass"), Value: aws.String("hunter2")},
+		},
+	}, nil)
+	ps := ParameterStore{client: fakeClient}
+
+	data, err := ps.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key: "/app/",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"db/user": []byte("admin"),
+		"db/pass": []byte("hunter2"),
+	}, data)
+
+	fakeClient.WithParametersByPath("/empty", nil, nil)
+	_, err = ps.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key: "/empty/",
+	})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+}
+
+func TestGetAllSecretsByPath(t *testing.T) {
+	pages := [][]*ssm.Parameter{
+		{
+			{Name: aws.String("/app/db/user"), Value: aws.String("admin")},
+			{Name: aws.String("/app/db/pass"), Value: aws.String("hunter2")},
+		},
+		{
+			{Name: aws.String("/app/api/token"), Value: aws.String("t0k3n")},
+		},
+	}
+	path := "/app"
+
+	tests := []struct {
+		name    string
+		ref     esv1beta1.ExternalSecretFind
+		apiErr  error
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name: "path only",
+			ref: esv1beta1.ExternalSecretFind{
+				Path: &path,
+			},
+			want: map[string][]byte{
+				"_app_db_user":   []byte("admin"),
+				"_app_db_pass":   []byte("hunter2"),
+				"_app_api_token": []byte("t0k3n"),
+			},
+		},
+		{
+			name: "path and name",
+			ref: esv1beta1.ExternalSecretFind{
+				Path: &path,
+				Name: &esv1beta1.FindName{
+					RegExp: "db/",
+				},
+			},
+			want: map[string][]byte{
+				"_app_db_user": []byte("admin"),
+				"_app_db_pass": []byte("hunter2"),
+			},
+		},
+		{
+			name: "invalid regexp",
+			ref: esv1beta1.ExternalSecretFind{
+				Path: &path,
+				Name: &esv1beta1.FindName{
+					RegExp: "[",
+				},
+			},
+			wantErr: "could not compile find.name.regexp",
+		},
+		{
+			name: "api error",
+			ref: esv1beta1.ExternalSecretFind{
+				Path: &path,
+			},
+			apiErr:  fmt.Errorf("oh no"),
+			wantErr: "oh no",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fake.Client{}
+			fakeClient.WithParametersByPath(path, pages, tt.apiErr)
+			ps := ParameterStore{client: fakeClient}
+			tt.ref.ConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+			got, err := ps.GetAllSecrets(context.Background(), tt.ref)
+			if !ErrorContains(err, tt.wantErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, tt.wantErr)
+			}
+			if tt.wantErr == "" {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetAllSecretsByNameAndTags(t *testing.T) {
+	fakeClient := &fake.Client{}
+	fakeClient.WithParameters("/app/db/user", "/app/api/token")
+	// only the parameter matching the name is fetched
+	fakeClient.WithValue(&ssm.GetParameterInput{
+		Name:           aws.String("/app/db/user"),
+		WithDecryption: aws.Bool(true),
+	}, &ssm.GetParameterOutput{
+		Parameter: &ssm.Parameter{Value: aws.String("admin")},
+	}, nil)
+	ps := ParameterStore{client: fakeClient}
+	got, err := ps.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Name: &esv1beta1.FindName{
+			RegExp: "db/",
+		},
+		Tags: map[string]string{
+			"team": "app",
+		},
+		ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]byte{"_app_db_user": []byte("admin")}, got)
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
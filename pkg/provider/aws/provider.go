@@ -17,6 +17,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,6 +40,8 @@ const (
 	errUnableCreateSession    = "unable to create session: %w"
 	errUnknownProviderService = "unknown AWS Provider Service: %s"
 	errRegionNotFound         = "region not found: %s"
+	errUnknownEndpointService = "unknown endpoint service %q, must be one of secretsmanager, ssm or sts"
+	errInvalidEndpoint        = "invalid endpoint for service %q: %s"
 )
 
 // NewClient constructs a new secrets client based on the provided store.
@@ -55,6 +58,10 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
 	if err != nil {
 		return err
 	}
+	err = validateEndpoints(prov)
+	if err != nil {
+		return err
+	}
 
 	// case: static credentials
 	if prov.Auth.SecretRef != nil {
@@ -93,6 +100,24 @@ func validateRegion(prov *esv1beta1.AWSProvider) error {
 	return nil
 }
 
+func validateEndpoints(prov *esv1beta1.AWSProvider) error {
+	for service, ep := range prov.Endpoints {
+		switch service {
+		case awsauth.SecretsManagerService, awsauth.SSMService, awsauth.STSService:
+		default:
+			return fmt.Errorf(errUnknownEndpointService, service)
+		}
+		u, err := url.Parse(ep)
+		if err != nil {
+			return fmt.Errorf(errInvalidEndpoint, service, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf(errInvalidEndpoint, service, "scheme and host are required")
+		}
+	}
+	return nil
+}
+
 func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string, assumeRoler awsauth.STSProvider) (esv1beta1.SecretsClient, error) {
 	prov, err := util.GetAWSProvider(store)
 	if err != nil {
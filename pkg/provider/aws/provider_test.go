@@ -189,6 +189,60 @@ func TestValidateStore(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid endpoints",
+			args: args{
+				store: &esv1beta1.SecretStore{
+					Spec: esv1beta1.SecretStoreSpec{
+						Provider: &esv1beta1.SecretStoreProvider{
+							AWS: &esv1beta1.AWSProvider{
+								Region: validRegion,
+								Endpoints: map[string]string{
+									"secretsmanager": "http://localstack:4566",
+									"sts":            "https://sts.eu-central-1.amazonaws.com",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid endpoints: unknown service",
+			wantErr: true,
+			args: args{
+				store: &esv1beta1.SecretStore{
+					Spec: esv1beta1.SecretStoreSpec{
+						Provider: &esv1beta1.SecretStoreProvider{
+							AWS: &esv1beta1.AWSProvider{
+								Region: validRegion,
+								Endpoints: map[string]string{
+									"kms": "http://localstack:4566",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid endpoints: missing scheme",
+			wantErr: true,
+			args: args{
+				store: &esv1beta1.SecretStore{
+					Spec: esv1beta1.SecretStoreSpec{
+						Provider: &esv1beta1.SecretStoreProvider{
+							AWS: &esv1beta1.AWSProvider{
+								Region: validRegion,
+								Endpoints: map[string]string{
+									"ssm": "localstack:4566",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:    "invalid static creds auth / AccessKeyID",
 			wantErr: true,
@@ -16,6 +16,7 @@ package fake
 
 import (
 	"fmt"
+	"sync"
 
 	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/google/go-cmp/cmp"
@@ -24,7 +25,10 @@ import (
 // Client implements the aws secretsmanager interface.
 type Client struct {
 	ExecutionCounter int
+	mu               sync.Mutex
 	valFn            map[string]func(*awssm.GetSecretValueInput) (*awssm.GetSecretValueOutput, error)
+	listFn           func(*awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error)
+	describeFn       func(*awssm.DescribeSecretInput) (*awssm.DescribeSecretOutput, error)
 }
 
 // NewClient init a new fake client.
@@ -35,15 +39,28 @@ func NewClient() *Client {
 }
 
 func (sm *Client) GetSecretValue(in *awssm.GetSecretValueInput) (*awssm.GetSecretValueOutput, error) {
+	sm.mu.Lock()
 	sm.ExecutionCounter++
-	if entry, found := sm.valFn[sm.cacheKeyForInput(in)]; found {
+	entry, found := sm.valFn[sm.cacheKeyForInput(in)]
+	sm.mu.Unlock()
+	if found {
 		return entry(in)
 	}
 	return nil, fmt.Errorf("test case not found")
 }
 
-func (sm *Client) ListSecrets(*awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error) {
-	return nil, nil
+func (sm *Client) ListSecrets(in *awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error) {
+	if sm.listFn == nil {
+		return nil, nil
+	}
+	return sm.listFn(in)
+}
+
+func (sm *Client) DescribeSecret(in *awssm.DescribeSecretInput) (*awssm.DescribeSecretOutput, error) {
+	if sm.describeFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.describeFn(in)
 }
 
 func (sm *Client) cacheKeyForInput(in *awssm.GetSecretValueInput) string {
@@ -65,3 +82,16 @@ func (sm *Client) WithValue(in *awssm.GetSecretValueInput, val *awssm.GetSecretV
 		return val, err
 	}
 }
+
+func (sm *Client) WithList(fn func(*awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error)) {
+	sm.listFn = fn
+}
+
+func (sm *Client) WithDescribe(in *awssm.DescribeSecretInput, val *awssm.DescribeSecretOutput, err error) {
+	sm.describeFn = func(paramIn *awssm.DescribeSecretInput) (*awssm.DescribeSecretOutput, error) {
+		if !cmp.Equal(paramIn, in) {
+			return nil, fmt.Errorf("unexpected test argument")
+		}
+		return val, err
+	}
+}
@@ -20,10 +20,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/tidwall/gjson"
+	"golang.org/x/sync/errgroup"
 	utilpointer "k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -38,9 +41,10 @@ var _ esv1beta1.SecretsClient = &SecretsManager{}
 
 // SecretsManager is a provider for AWS SecretsManager.
 type SecretsManager struct {
-	sess   *session.Session
-	client SMInterface
-	cache  map[string]*awssm.GetSecretValueOutput
+	sess    *session.Session
+	client  SMInterface
+	cacheMu sync.Mutex
+	cache   map[string]*awssm.GetSecretValueOutput
 }
 
 // SMInterface is a subset of the smiface api.
@@ -48,10 +52,15 @@ type SecretsManager struct {
 type SMInterface interface {
 	GetSecretValue(*awssm.GetSecretValueInput) (*awssm.GetSecretValueOutput, error)
 	ListSecrets(*awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error)
+	DescribeSecret(*awssm.DescribeSecretInput) (*awssm.DescribeSecretOutput, error)
 }
 
 const (
 	errUnexpectedFindOperator = "unexpected find operator"
+
+	// maxConcurrentFetches limits the number of parallel
+	// GetSecretValue calls when fetching the results of a find.
+	maxConcurrentFetches = 10
 )
 
 var log = ctrl.Log.WithName("provider").WithName("aws").WithName("secretsmanager")
@@ -73,7 +82,10 @@ func (sm *SecretsManager) fetch(_ context.Context, ref esv1beta1.ExternalSecretD
 	log.Info("fetching secret value", "key", ref.Key, "version", ver)
 
 	cacheKey := fmt.Sprintf("%s#%s", ref.Key, ver)
-	if secretOut, found := sm.cache[cacheKey]; found {
+	sm.cacheMu.Lock()
+	secretOut, found := sm.cache[cacheKey]
+	sm.cacheMu.Unlock()
+	if found {
 		log.Info("found secret in cache", "key", ref.Key, "version", ver)
 		return secretOut, nil
 	}
@@ -99,7 +111,9 @@ func (sm *SecretsManager) fetch(_ context.Context, ref esv1beta1.ExternalSecretD
 	if err != nil {
 		return nil, err
 	}
+	sm.cacheMu.Lock()
 	sm.cache[cacheKey] = secretOut
+	sm.cacheMu.Unlock()
 
 	return secretOut, nil
 }
@@ -143,15 +157,17 @@ func (sm *SecretsManager) findByName(ctx context.Context, ref esv1beta1.External
 			return nil, err
 		}
 		log.V(1).Info("aws sm findByName found", "secrets", len(it.SecretList))
+		names := make([]string, 0, len(it.SecretList))
 		for _, secret := range it.SecretList {
 			if !matcher.MatchName(*secret.Name) {
 				continue
 			}
 			log.V(1).Info("aws sm findByName matches", "name", *secret.Name)
-			err = sm.fetchAndSet(ctx, data, *secret.Name)
-			if err != nil {
-				return nil, err
-			}
+			names = append(names, *secret.Name)
+		}
+		err = sm.fetchAndSet(ctx, data, names)
+		if err != nil {
+			return nil, err
 		}
 		nextToken = it.NextToken
 		if nextToken == nil {
@@ -198,11 +214,13 @@ func (sm *SecretsManager) findByTags(ctx context.Context, ref esv1beta1.External
 			return nil, err
 		}
 		log.V(1).Info("aws sm findByTag found", "secrets", len(it.SecretList))
+		names := make([]string, 0, len(it.SecretList))
 		for _, secret := range it.SecretList {
-			err = sm.fetchAndSet(ctx, data, *secret.Name)
-			if err != nil {
-				return nil, err
-			}
+			names = append(names, *secret.Name)
+		}
+		err = sm.fetchAndSet(ctx, data, names)
+		if err != nil {
+			return nil, err
 		}
 		nextToken = it.NextToken
 		if nextToken == nil {
@@ -212,24 +230,62 @@ func (sm *SecretsManager) findByTags(ctx context.Context, ref esv1beta1.External
 	return utils.ConvertKeys(ref.ConversionStrategy, data)
 }
 
-func (sm *SecretsManager) fetchAndSet(ctx context.Context, data map[string][]byte, name string) error {
-	sec, err := sm.fetch(ctx, esv1beta1.ExternalSecretDataRemoteRef{
-		Key: name,
+// fetchAndSet fetches the given batch of secrets in parallel
+// and stores their values in data.
+func (sm *SecretsManager) fetchAndSet(ctx context.Context, data map[string][]byte, names []string) error {
+	var mu sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentFetches)
+	for _, name := range names {
+		name := name
+		g.Go(func() error {
+			sec, err := sm.fetch(gctx, esv1beta1.ExternalSecretDataRemoteRef{
+				Key: name,
+			})
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if sec.SecretString != nil {
+				data[name] = []byte(*sec.SecretString)
+			}
+			if sec.SecretBinary != nil {
+				data[name] = sec.SecretBinary
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func (sm *SecretsManager) fetchTags(ref esv1beta1.ExternalSecretDataRemoteRef) (map[string]string, error) {
+	out, err := sm.client.DescribeSecret(&awssm.DescribeSecretInput{
+		SecretId: &ref.Key,
 	})
-	if err != nil {
-		return err
+	var nf *awssm.ResourceNotFoundException
+	if errors.As(err, &nf) {
+		return nil, esv1beta1.NoSecretErr
 	}
-	if sec.SecretString != nil {
-		data[name] = []byte(*sec.SecretString)
+	if err != nil {
+		return nil, util.SanitizeErr(err)
 	}
-	if sec.SecretBinary != nil {
-		data[name] = sec.SecretBinary
+	tags := make(map[string]string, len(out.Tags))
+	for _, tag := range out.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
 	}
-	return nil
+	return tags, nil
 }
 
 // GetSecret returns a single secret from the provider.
 func (sm *SecretsManager) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		tags, err := sm.fetchTags(ref)
+		if err != nil {
+			return nil, err
+		}
+		return util.TagValue(tags, ref, "secret")
+	}
 	secretOut, err := sm.fetch(ctx, ref)
 	if errors.Is(err, esv1beta1.NoSecretErr) {
 		return nil, err
@@ -272,6 +328,17 @@ func (sm *SecretsManager) GetSecret(ctx context.Context, ref esv1beta1.ExternalS
 // GetSecretMap returns multiple k/v pairs from the provider.
 func (sm *SecretsManager) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
 	log.Info("fetching secret map", "key", ref.Key)
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		tags, err := sm.fetchTags(ref)
+		if err != nil {
+			return nil, err
+		}
+		secretData := make(map[string][]byte, len(tags))
+		for k, v := range tags {
+			secretData[k] = []byte(v)
+		}
+		return secretData, nil
+	}
 	data, err := sm.GetSecret(ctx, ref)
 	if err != nil {
 		return nil, err
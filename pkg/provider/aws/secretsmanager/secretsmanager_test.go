@@ -307,6 +307,181 @@ func TestGetSecretMap(t *testing.T) {
 	}
 }
 
+func TestGetAllSecrets(t *testing.T) {
+	fakeClient := fakesm.NewClient()
+	pages := [][]*awssm.SecretListEntry{
+		{
+			{Name: aws.String("team-a/db")},
+			{Name: aws.String("team-b/db")},
+		},
+		{
+			{Name: aws.String("team-a/api")},
+		},
+	}
+	fakeClient.WithList(func(in *awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error) {
+		idx := 0
+		if in.NextToken != nil {
+			idx = 1
+		}
+		out := &awssm.ListSecretsOutput{
+			SecretList: pages[idx],
+		}
+		if idx == 0 {
+			out.NextToken = aws.String("next")
+		}
+		return out, nil
+	})
+	for _, page := range pages {
+		for _, secret := range page {
+			fakeClient.WithValue(&awssm.GetSecretValueInput{
+				SecretId:     secret.Name,
+				VersionStage: aws.String("AWSCURRENT"),
+			}, &awssm.GetSecretValueOutput{
+				SecretString: aws.String("value-of-" + *secret.Name),
+			}, nil)
+		}
+	}
+
+	sm := SecretsManager{
+		cache:  make(map[string]*awssm.GetSecretValueOutput),
+		client: fakeClient,
+	}
+	out, err := sm.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Name: &esv1beta1.FindName{
+			RegExp: "team-a",
+		},
+		ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string][]byte{
+		"team-a_db":  []byte("value-of-team-a/db"),
+		"team-a_api": []byte("value-of-team-a/api"),
+	}
+	if !cmp.Equal(out, expected) {
+		t.Errorf("unexpected secret data: expected %#v, got %#v", expected, out)
+	}
+	if fakeClient.ExecutionCounter != 2 {
+		t.Errorf("unexpected counter value: expected 2, got %d", fakeClient.ExecutionCounter)
+	}
+
+	out, err = sm.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Tags: map[string]string{
+			"owner": "team-a",
+		},
+		ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(out) != 3 {
+		t.Errorf("unexpected secret data: expected 3 secrets, got %#v", out)
+	}
+	// team-a secrets are served from the cache
+	if fakeClient.ExecutionCounter != 3 {
+		t.Errorf("unexpected counter value: expected 3, got %d", fakeClient.ExecutionCounter)
+	}
+
+	failingClient := fakesm.NewClient()
+	failingClient.WithList(func(in *awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error) {
+		return &awssm.ListSecretsOutput{
+			SecretList: pages[0],
+		}, nil
+	})
+	sm = SecretsManager{
+		cache:  make(map[string]*awssm.GetSecretValueOutput),
+		client: failingClient,
+	}
+	_, err = sm.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Name: &esv1beta1.FindName{
+			RegExp: ".*",
+		},
+	})
+	if !ErrorContains(err, "test case not found") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetSecretTags(t *testing.T) {
+	fakeClient := fakesm.NewClient()
+	fakeClient.WithDescribe(&awssm.DescribeSecretInput{
+		SecretId: aws.String("/baz"),
+	}, &awssm.DescribeSecretOutput{
+		Tags: []*awssm.Tag{
+			{Key: aws.String("owner"), Value: aws.String("team-a")},
+			{Key: aws.String("rotation"), Value: aws.String("2022-01-01")},
+		},
+	}, nil)
+	sm := SecretsManager{
+		cache:  make(map[string]*awssm.GetSecretValueOutput),
+		client: fakeClient,
+	}
+
+	tests := []struct {
+		name        string
+		ref         esv1beta1.ExternalSecretDataRemoteRef
+		expected    string
+		expectError string
+	}{
+		{
+			name: "single tag",
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key:            "/baz",
+				Property:       "owner",
+				MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+			},
+			expected: "team-a",
+		},
+		{
+			name: "all tags",
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key:            "/baz",
+				MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+			},
+			expected: `{"owner":"team-a","rotation":"2022-01-01"}`,
+		},
+		{
+			name: "missing tag",
+			ref: esv1beta1.ExternalSecretDataRemoteRef{
+				Key:            "/baz",
+				Property:       "foo",
+				MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+			},
+			expectError: "tag foo does not exist in secret /baz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := sm.GetSecret(context.Background(), tt.ref)
+			if !ErrorContains(err, tt.expectError) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, tt.expectError)
+			}
+			if err == nil && string(out) != tt.expected {
+				t.Errorf("unexpected secret: expected %s, got %s", tt.expected, string(out))
+			}
+		})
+	}
+
+	data, err := sm.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "/baz",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string][]byte{
+		"owner":    []byte("team-a"),
+		"rotation": []byte("2022-01-01"),
+	}
+	if !cmp.Equal(data, expected) {
+		t.Errorf("unexpected secret data: expected %#v, got %#v", expected, data)
+	}
+	if fakeClient.ExecutionCounter != 0 {
+		t.Errorf("unexpected counter value: expected 0, got %d", fakeClient.ExecutionCounter)
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
@@ -0,0 +1,37 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errTagNotExist = "tag %s does not exist in %s %s"
+
+// TagValue returns a single tag value if ref.Property is set
+// and all tags as JSON object otherwise.
+// kind names the tagged resource in the error, e.g. secret or parameter.
+func TagValue(tags map[string]string, ref esv1beta1.ExternalSecretDataRemoteRef, kind string) ([]byte, error) {
+	if ref.Property == "" {
+		return json.Marshal(tags)
+	}
+	if val, ok := tags[ref.Property]; ok {
+		return []byte(val), nil
+	}
+	return nil, fmt.Errorf(errTagNotExist, ref.Property, kind, ref.Key)
+}
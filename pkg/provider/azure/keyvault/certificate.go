@@ -0,0 +1,173 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"software.sslmate.com/src/go-pkcs12"
+
+	v2 "github.com/external-secrets/external-secrets/pkg/template/v2"
+)
+
+const (
+	contentTypePKCS12 = "application/x-pkcs12"
+	contentTypePEM    = "application/x-pem-file"
+
+	pemTypeCertificate = "CERTIFICATE"
+	pemTypePrivateKey  = "PRIVATE KEY"
+
+	errCertNoValue         = "certificate %s has no backing secret value"
+	errCertContentType     = "unsupported content type %q of certificate %s"
+	errCertDecodePKCS12    = "unable to decode PKCS#12 of certificate %s: %w"
+	errCertDecodeBase64    = "unable to decode base64 of certificate %s: %w"
+	errCertParse           = "unable to parse certificate %s: %w"
+	errCertParsePrivateKey = "unable to parse private key of certificate %s"
+	errCertNoCertificate   = "no certificate found in certificate %s"
+	errCertNoPrivateKey    = "no private key found in certificate %s, is it exportable?"
+	errCertPropNotExist    = "property %s does not exist in certificate %s, use tls.crt or tls.key"
+)
+
+// getCertificatePEM resolves the backing secret of a certificate and returns
+// the certificate chain as tls.crt and the private key as tls.key, both PEM encoded.
+// The chain is ordered from the leaf to the root certificate.
+func (a *Azure) getCertificatePEM(ctx context.Context, name, version string) (map[string][]byte, error) {
+	secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if secretResp.Value == nil {
+		return nil, fmt.Errorf(errCertNoValue, name)
+	}
+	var contentType string
+	if secretResp.ContentType != nil {
+		contentType = *secretResp.ContentType
+	}
+
+	var certs []*x509.Certificate
+	var key interface{}
+	switch contentType {
+	case contentTypePKCS12:
+		certs, key, err = decodePKCS12(name, *secretResp.Value)
+	case contentTypePEM:
+		certs, key, err = decodePEM(name, *secretResp.Value)
+	default:
+		return nil, fmt.Errorf(errCertContentType, contentType, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf(errCertNoCertificate, name)
+	}
+	if key == nil {
+		return nil, fmt.Errorf(errCertNoPrivateKey, name)
+	}
+
+	var chain []byte
+	for _, cert := range certs {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: cert.Raw})...)
+	}
+	// try to order the certificate chain. If it fails
+	// we return the certificates in their original order.
+	if ordered, err := v2.FetchCertChains(chain); err == nil {
+		chain = ordered
+	}
+
+	// we use pkcs8 because it supports more key types (ecdsa, ed25519), not just RSA
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		corev1.TLSCertKey:       chain,
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: keyDER}),
+	}, nil
+}
+
+// getCertificatePEMProperty returns the PEM encoded chain or the private key
+// of a certificate. If no property is given the chain is returned.
+func (a *Azure) getCertificatePEMProperty(ctx context.Context, name, version, property string) ([]byte, error) {
+	data, err := a.getCertificatePEM(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if property == "" {
+		property = corev1.TLSCertKey
+	}
+	val, ok := data[property]
+	if !ok {
+		return nil, fmt.Errorf(errCertPropNotExist, property, name)
+	}
+	return val, nil
+}
+
+// decodePKCS12 decodes the base64 encoded, password-less PFX
+// that Key Vault stores as backing secret of a certificate.
+func decodePKCS12(name, value string) ([]*x509.Certificate, interface{}, error) {
+	pfx, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errCertDecodeBase64, name, err)
+	}
+	key, cert, caCerts, err := pkcs12.DecodeChain(pfx, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf(errCertDecodePKCS12, name, err)
+	}
+	return append([]*x509.Certificate{cert}, caCerts...), key, nil
+}
+
+func decodePEM(name, value string) ([]*x509.Certificate, interface{}, error) {
+	var certs []*x509.Certificate
+	var key interface{}
+	data := []byte(value)
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		data = rest
+		switch {
+		case block.Type == pemTypeCertificate:
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf(errCertParse, name, err)
+			}
+			certs = append(certs, cert)
+		case strings.HasSuffix(block.Type, pemTypePrivateKey):
+			k, err := parsePrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf(errCertParsePrivateKey, name)
+			}
+			key = k
+		}
+	}
+	return certs, key, nil
+}
+
+func parsePrivateKey(block []byte) (interface{}, error) {
+	if k, err := x509.ParsePKCS8PrivateKey(block); err == nil {
+		return k, nil
+	}
+	if k, err := x509.ParsePKCS1PrivateKey(block); err == nil {
+		return k, nil
+	}
+	return x509.ParseECPrivateKey(block)
+}
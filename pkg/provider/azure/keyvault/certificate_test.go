@@ -0,0 +1,228 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"software.sslmate.com/src/go-pkcs12"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault/fake"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+type testCertChain struct {
+	key      *ecdsa.PrivateKey
+	leaf     *x509.Certificate
+	ca       *x509.Certificate
+	keyPEM   []byte
+	chainPEM []byte
+}
+
+func makeCert(t *testing.T, cn string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		SubjectKeyId:          []byte(cn),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	if parent == nil {
+		// self-signed certificates refer to themselves as authority
+		tpl.AuthorityKeyId = tpl.SubjectKeyId
+		parent, parentKey = tpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func makeTestCertChain(t *testing.T) *testCertChain {
+	t.Helper()
+	ca, caKey := makeCert(t, "ca", true, nil, nil)
+	leaf, key := makeCert(t, "leaf", false, ca, caKey)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertChain{
+		key:    key,
+		leaf:   leaf,
+		ca:     ca,
+		keyPEM: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		chainPEM: append(
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw}),
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})...),
+	}
+}
+
+func TestAzureKeyVaultCertificatePEM(t *testing.T) {
+	chain := makeTestCertChain(t)
+	pfx, err := pkcs12.Encode(rand.Reader, chain.key, chain.leaf, []*x509.Certificate{chain.ca}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Key Vault does not guarantee the order of the certificates
+	// in the PEM backing secret, so we put the root first.
+	unorderedPEM := append(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain.ca.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain.leaf.Raw})...)
+	unorderedPEM = append(unorderedPEM, chain.keyPEM...)
+
+	tbl := []struct {
+		name        string
+		contentType string
+		value       string
+		property    string
+		expectError string
+		expectData  []byte
+	}{
+		{
+			name:        "pkcs12 chain",
+			contentType: contentTypePKCS12,
+			value:       base64.StdEncoding.EncodeToString(pfx),
+			expectData:  chain.chainPEM,
+		},
+		{
+			name:        "pkcs12 key",
+			contentType: contentTypePKCS12,
+			value:       base64.StdEncoding.EncodeToString(pfx),
+			property:    corev1.TLSPrivateKeyKey,
+			expectData:  chain.keyPEM,
+		},
+		{
+			name:        "pem chain is ordered",
+			contentType: contentTypePEM,
+			value:       string(unorderedPEM),
+			property:    corev1.TLSCertKey,
+			expectData:  chain.chainPEM,
+		},
+		{
+			name:        "pem key",
+			contentType: contentTypePEM,
+			value:       string(unorderedPEM),
+			property:    corev1.TLSPrivateKeyKey,
+			expectData:  chain.keyPEM,
+		},
+		{
+			name:        "pem without key",
+			contentType: contentTypePEM,
+			value:       string(chain.chainPEM),
+			expectError: "no private key found in certificate",
+		},
+		{
+			name:        "unknown property",
+			contentType: contentTypePEM,
+			value:       string(unorderedPEM),
+			property:    "ca.crt",
+			expectError: "property ca.crt does not exist in certificate",
+		},
+		{
+			name:        "unknown content type",
+			contentType: "text/plain",
+			value:       "foo",
+			expectError: "unsupported content type",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			mockClient := &fake.AzureMockClient{}
+			mockClient.WithValue("", "", "", keyvault.SecretBundle{
+				Value:       pointer.StringPtr(row.value),
+				ContentType: pointer.StringPtr(row.contentType),
+			}, nil)
+			sm := Azure{
+				baseClient: mockClient,
+				provider: &esv1beta1.AzureKVProvider{
+					VaultURL:   pointer.StringPtr(fakeURL),
+					CertFormat: esv1beta1.AzureCertFormatPEM,
+				},
+			}
+			ref := esv1beta1.ExternalSecretDataRemoteRef{Key: certName, Property: row.property}
+			out, err := sm.GetSecret(context.Background(), ref)
+			if !utils.ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if err == nil && !bytes.Equal(out, row.expectData) {
+				t.Errorf("unexpected secret data: expected %s, got %s", row.expectData, out)
+			}
+		})
+	}
+}
+
+func TestAzureKeyVaultCertificatePEMSecretMap(t *testing.T) {
+	chain := makeTestCertChain(t)
+	pfx, err := pkcs12.Encode(rand.Reader, chain.key, chain.leaf, []*x509.Certificate{chain.ca}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockClient := &fake.AzureMockClient{}
+	mockClient.WithValue("", "", "", keyvault.SecretBundle{
+		Value:       pointer.StringPtr(base64.StdEncoding.EncodeToString(pfx)),
+		ContentType: pointer.StringPtr(contentTypePKCS12),
+	}, nil)
+	sm := Azure{
+		baseClient: mockClient,
+		provider: &esv1beta1.AzureKVProvider{
+			VaultURL:   pointer.StringPtr(fakeURL),
+			CertFormat: esv1beta1.AzureCertFormatPEM,
+		},
+	}
+	out, err := sm.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: certName})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(out[corev1.TLSCertKey], chain.chainPEM) {
+		t.Errorf("unexpected %s: %s", corev1.TLSCertKey, out[corev1.TLSCertKey])
+	}
+	if !bytes.Equal(out[corev1.TLSPrivateKeyKey], chain.keyPEM) {
+		t.Errorf("unexpected %s: %s", corev1.TLSPrivateKeyKey, out[corev1.TLSPrivateKeyKey])
+	}
+
+	// default format keeps rejecting certificates in dataFrom
+	sm.provider.CertFormat = esv1beta1.AzureCertFormatDER
+	_, err = sm.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: certName})
+	if !utils.ErrorContains(err, errDataFromCert) {
+		t.Errorf("unexpected error: %v, expected: '%s'", err, errDataFromCert)
+	}
+}
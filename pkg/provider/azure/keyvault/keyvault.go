@@ -280,6 +280,9 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataR
 		}
 		return getProperty(*secretResp.Value, ref.Property, ref.Key)
 	case objectTypeCert:
+		if a.provider.CertFormat == esv1beta1.AzureCertFormatPEM && ref.MetadataPolicy != esv1beta1.ExternalSecretMetadataPolicyFetch {
+			return a.getCertificatePEMProperty(ctx, secretName, ref.Version, ref.Property)
+		}
 		// returns a CertBundle. We return CER contents of x509 certificate
 		// see: https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#CertificateBundle
 		certResp, err := a.baseClient.GetCertificate(context.Background(), *a.provider.VaultURL, secretName, ref.Version)
@@ -364,6 +367,9 @@ func (a *Azure) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDa
 
 		return secretData, nil
 	case objectTypeCert:
+		if a.provider.CertFormat == esv1beta1.AzureCertFormatPEM {
+			return a.getCertificatePEM(ctx, secretName, ref.Version)
+		}
 		return nil, fmt.Errorf(errDataFromCert)
 	case objectTypeKey:
 		return nil, fmt.Errorf(errDataFromKey)
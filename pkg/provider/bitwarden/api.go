@@ -0,0 +1,260 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitwarden
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/bitwarden/crypto"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errLogin             = "could not log in to bitwarden: %w"
+	errLoginStatus       = "could not log in to bitwarden, status %d: %s"
+	errOrganizationKey   = "could not decrypt the organization key: %w"
+	errRequest           = "bitwarden request %s failed: %w"
+	errRequestStatus     = "bitwarden request %s failed with status %d: %s"
+	errDecodeResponse    = "could not decode bitwarden response of %s: %w"
+	errDecryptSecret     = "could not decrypt secret %s: %w"
+	errWrongOrganization = "secret %s does not belong to organization %s"
+)
+
+// SecretIdentifier identifies a secret in a list of secrets, the key is decrypted.
+type SecretIdentifier struct {
+	ID         string
+	Key        string
+	ProjectIDs []string
+}
+
+// Secret is a decrypted Bitwarden Secrets Manager secret.
+type Secret struct {
+	ID         string
+	Key        string
+	Value      string
+	Note       string
+	ProjectIDs []string
+}
+
+// SecretsAPI reads secrets of an organization.
+type SecretsAPI interface {
+	ListSecrets(ctx context.Context) ([]SecretIdentifier, error)
+	GetSecret(ctx context.Context, id string) (*Secret, error)
+	GetSecretsByIDs(ctx context.Context, ids []string) ([]*Secret, error)
+}
+
+// apiClient implements SecretsAPI with the Bitwarden HTTP API.
+type apiClient struct {
+	client          *http.Client
+	apiURL          string
+	organizationID  string
+	bearerToken     string
+	organizationKey *crypto.Key
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	EncryptedPayload string `json:"encrypted_payload"`
+}
+
+type tokenPayload struct {
+	EncryptionKey string `json:"encryptionKey"`
+}
+
+type projectModel struct {
+	ID string `json:"id"`
+}
+
+type secretModel struct {
+	ID             string         `json:"id"`
+	OrganizationID string         `json:"organizationId"`
+	Key            string         `json:"key"`
+	Value          string         `json:"value"`
+	Note           string         `json:"note"`
+	Projects       []projectModel `json:"projects"`
+}
+
+type secretListModel struct {
+	Secrets []secretModel `json:"secrets"`
+}
+
+type secretsByIDsModel struct {
+	Data []secretModel `json:"data"`
+}
+
+// login authenticates the machine account and decrypts the organization key
+// returned in the encrypted payload of the token response.
+func login(ctx context.Context, client *http.Client, identityURL, apiURL, organizationID string, token *crypto.AccessToken) (*apiClient, error) {
+	form := url.Values{
+		"scope":         {"api.secrets"},
+		"grant_type":    {"client_credentials"},
+		"client_id":     {token.ClientID},
+		"client_secret": {token.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(identityURL, "/")+"/connect/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf(errLogin, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errLogin, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(errLoginStatus, resp.StatusCode, utils.ReadErrorBody(resp.Body))
+	}
+	var tokenResp tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, fmt.Errorf(errLogin, err)
+	}
+
+	payload, err := crypto.Decrypt(tokenResp.EncryptedPayload, token.Key)
+	if err != nil {
+		return nil, fmt.Errorf(errOrganizationKey, err)
+	}
+	var p tokenPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf(errOrganizationKey, err)
+	}
+	rawKey, err := base64.StdEncoding.DecodeString(p.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf(errOrganizationKey, err)
+	}
+	organizationKey, err := crypto.NewKey(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf(errOrganizationKey, err)
+	}
+	return &apiClient{
+		client:          client,
+		apiURL:          strings.TrimSuffix(apiURL, "/"),
+		organizationID:  organizationID,
+		bearerToken:     tokenResp.AccessToken,
+		organizationKey: organizationKey,
+	}, nil
+}
+
+func (c *apiClient) ListSecrets(ctx context.Context) ([]SecretIdentifier, error) {
+	var list secretListModel
+	if err := c.do(ctx, http.MethodGet, "/organizations/"+url.PathEscape(c.organizationID)+"/secrets", nil, &list); err != nil {
+		return nil, err
+	}
+	identifiers := make([]SecretIdentifier, 0, len(list.Secrets))
+	for i := range list.Secrets {
+		key, err := crypto.DecryptString(list.Secrets[i].Key, c.organizationKey)
+		if err != nil {
+			return nil, fmt.Errorf(errDecryptSecret, list.Secrets[i].ID, err)
+		}
+		identifiers = append(identifiers, SecretIdentifier{
+			ID:         list.Secrets[i].ID,
+			Key:        key,
+			ProjectIDs: projectIDs(list.Secrets[i].Projects),
+		})
+	}
+	return identifiers, nil
+}
+
+func (c *apiClient) GetSecret(ctx context.Context, id string) (*Secret, error) {
+	var secret secretModel
+	if err := c.do(ctx, http.MethodGet, "/secrets/"+url.PathEscape(id), nil, &secret); err != nil {
+		return nil, err
+	}
+	return c.decryptSecret(&secret)
+}
+
+func (c *apiClient) GetSecretsByIDs(ctx context.Context, ids []string) ([]*Secret, error) {
+	body, err := json.Marshal(map[string][]string{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+	var resp secretsByIDsModel
+	if err := c.do(ctx, http.MethodPost, "/secrets/get-by-ids", body, &resp); err != nil {
+		return nil, err
+	}
+	secrets := make([]*Secret, 0, len(resp.Data))
+	for i := range resp.Data {
+		secret, err := c.decryptSecret(&resp.Data[i])
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+func (c *apiClient) decryptSecret(secret *secretModel) (*Secret, error) {
+	// secrets of other organizations are encrypted with another key
+	if !strings.EqualFold(secret.OrganizationID, c.organizationID) {
+		return nil, fmt.Errorf(errWrongOrganization, secret.ID, c.organizationID)
+	}
+	out := &Secret{ID: secret.ID, ProjectIDs: projectIDs(secret.Projects)}
+	var err error
+	for _, field := range []struct {
+		enc string
+		out *string
+	}{{secret.Key, &out.Key}, {secret.Value, &out.Value}, {secret.Note, &out.Note}} {
+		if *field.out, err = crypto.DecryptString(field.enc, c.organizationKey); err != nil {
+			return nil, fmt.Errorf(errDecryptSecret, secret.ID, err)
+		}
+	}
+	return out, nil
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf(errRequest, path, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(errRequest, path, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return esv1beta1.NoSecretErr
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf(errRequestStatus, path, resp.StatusCode, utils.ReadErrorBody(resp.Body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf(errDecodeResponse, path, err)
+	}
+	return nil
+}
+
+func projectIDs(projects []projectModel) []string {
+	ids := make([]string, 0, len(projects))
+	for _, p := range projects {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
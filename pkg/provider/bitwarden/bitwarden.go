@@ -0,0 +1,316 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitwarden
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/provider/bitwarden/crypto"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	defaultAPIURL      = "https://api.bitwarden.com"
+	defaultIdentityURL = "https://identity.bitwarden.com"
+
+	errMissingStore          = "missing store provider bitwardensecretsmanager"
+	errMissingOrganizationID = "organizationID must be set"
+	errMissingAccessToken    = "auth.secretRef.accessToken must be set"
+	errInvalidURL            = "invalid url %q: %w"
+	errInvalidCABundle       = "could not append the caBundle"
+	errMissingNamespace      = "missing namespace for %s on kind ClusterSecretStore"
+	errFetchAccessToken      = "could not fetch access token secret %s: %w"
+	errMissingAccessTokenKey = "missing key %s in access token secret %s"
+	errSecretNotInProject    = "no secret named %s in project %s"
+	errSecretIDNotInProject  = "secret %s is not in project %s"
+	errAmbiguousSecretName   = "found %d secrets named %s, use the secret id instead"
+	errPropertyNotFound      = "property %s not found in secret %s"
+	errNotAnObject           = "value of secret %s is not a json object"
+	errFindNameRequired      = "find.name is required: bitwarden secrets manager only supports finding secrets by name"
+	errFindPathOrTags        = "find.path and find.tags are not supported by bitwarden secrets manager"
+)
+
+var secretIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.SecretsClient = &Client{}
+
+// Provider satisfies the provider interface.
+type Provider struct{}
+
+// Client reads secrets of a Bitwarden Secrets Manager organization.
+type Client struct {
+	api       SecretsAPI
+	projectID string
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		BitwardenSecretsManager: &esv1beta1.BitwardenSecretsManagerProvider{},
+	})
+}
+
+// NewClient logs in with the access token of the machine account.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	rawToken, err := getAccessToken(ctx, kube, store.GetObjectKind().GroupVersionKind().Kind, namespace, provider)
+	if err != nil {
+		return nil, err
+	}
+	token, err := crypto.ParseAccessToken(string(rawToken))
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := getHTTPClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	api, err := login(ctx, httpClient, urlOrDefault(provider.IdentityURL, defaultIdentityURL), urlOrDefault(provider.APIURL, defaultAPIURL), provider.OrganizationID, token)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{api: api, projectID: provider.ProjectID}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
+	provider, err := getProvider(store)
+	if err != nil {
+		return err
+	}
+	if provider.OrganizationID == "" {
+		return errors.New(errMissingOrganizationID)
+	}
+	for _, u := range []string{provider.APIURL, provider.IdentityURL} {
+		if u == "" {
+			continue
+		}
+		if _, err := url.ParseRequestURI(u); err != nil {
+			return fmt.Errorf(errInvalidURL, u, err)
+		}
+	}
+	accessToken := provider.Auth.SecretRef.AccessToken
+	if accessToken.Name == "" || accessToken.Key == "" {
+		return errors.New(errMissingAccessToken)
+	}
+	return utils.ValidateSecretSelector(store, accessToken)
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.BitwardenSecretsManagerProvider, error) {
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.BitwardenSecretsManager == nil {
+		return nil, errors.New(errMissingStore)
+	}
+	return spc.Provider.BitwardenSecretsManager, nil
+}
+
+func getAccessToken(ctx context.Context, kube client.Client, storeKind, namespace string, provider *esv1beta1.BitwardenSecretsManagerProvider) ([]byte, error) {
+	ref := provider.Auth.SecretRef.AccessToken
+	key := client.ObjectKey{Name: ref.Name, Namespace: namespace}
+	// only ClusterSecretStore is allowed to set namespace (and then it's required)
+	if storeKind == esv1beta1.ClusterSecretStoreKind {
+		if ref.Namespace == nil {
+			return nil, fmt.Errorf(errMissingNamespace, ref.Name)
+		}
+		key.Namespace = *ref.Namespace
+	}
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf(errFetchAccessToken, ref.Name, err)
+	}
+	token, ok := secret.Data[ref.Key]
+	if !ok || len(token) == 0 {
+		return nil, fmt.Errorf(errMissingAccessTokenKey, ref.Key, ref.Name)
+	}
+	return token, nil
+}
+
+func getHTTPClient(provider *esv1beta1.BitwardenSecretsManagerProvider) (*http.Client, error) {
+	if len(provider.CABundle) == 0 {
+		return &http.Client{}, nil
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(provider.CABundle) {
+		return nil, errors.New(errInvalidCABundle)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				RootCAs:    caCertPool,
+			},
+		},
+	}, nil
+}
+
+func urlOrDefault(u, def string) string {
+	if u == "" {
+		return def
+	}
+	return u
+}
+
+// GetSecret returns the value of the secret with the id or the name given by key.
+// Names are looked up in the project of the store. If property is set the value
+// is parsed as JSON and the property is resolved as gjson path.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	secret, err := c.getSecret(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return []byte(secret.Value), nil
+	}
+	val := gjson.Get(secret.Value, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the properties of the JSON object stored in the secret.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	obj := gjson.ParseBytes(value)
+	if !obj.IsObject() {
+		return nil, fmt.Errorf(errNotAnObject, ref.Key)
+	}
+	secretMap := make(map[string][]byte)
+	obj.ForEach(func(key, value gjson.Result) bool {
+		secretMap[key.String()] = []byte(value.String())
+		return true
+	})
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the secrets of the project of the store with names matching find.name.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Name == nil {
+		return nil, errors.New(errFindNameRequired)
+	}
+	if ref.Path != nil || len(ref.Tags) > 0 {
+		return nil, errors.New(errFindPathOrTags)
+	}
+	matcher, err := find.New(*ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	identifiers, err := c.api.ListSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	names := make(map[string]int)
+	for _, identifier := range identifiers {
+		if !c.inProject(identifier.ProjectIDs) || !matcher.MatchName(identifier.Key) {
+			continue
+		}
+		ids = append(ids, identifier.ID)
+		names[identifier.Key]++
+	}
+	for name, count := range names {
+		if count > 1 {
+			return nil, fmt.Errorf(errAmbiguousSecretName, count, name)
+		}
+	}
+	secretMap := make(map[string][]byte, len(ids))
+	if len(ids) == 0 {
+		return secretMap, nil
+	}
+	secrets, err := c.api.GetSecretsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		secretMap[secret.Key] = []byte(secret.Value)
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
+
+// getSecret fetches the secret by id, or looks up the id by name, in the project of the store.
+func (c *Client) getSecret(ctx context.Context, key string) (*Secret, error) {
+	if secretIDRegexp.MatchString(key) {
+		secret, err := c.api.GetSecret(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !c.inProject(secret.ProjectIDs) {
+			return nil, fmt.Errorf("%w: %s", esv1beta1.NoSecretErr, fmt.Sprintf(errSecretIDNotInProject, key, c.projectID))
+		}
+		return secret, nil
+	}
+	identifiers, err := c.api.ListSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, identifier := range identifiers {
+		if identifier.Key == key && c.inProject(identifier.ProjectIDs) {
+			ids = append(ids, identifier.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		if c.projectID != "" {
+			return nil, fmt.Errorf("%w: %s", esv1beta1.NoSecretErr, fmt.Sprintf(errSecretNotInProject, key, c.projectID))
+		}
+		return nil, esv1beta1.NoSecretErr
+	case 1:
+		return c.api.GetSecret(ctx, ids[0])
+	default:
+		return nil, fmt.Errorf(errAmbiguousSecretName, len(ids), key)
+	}
+}
+
+// inProject reports whether a secret belongs to the project of the store,
+// every secret does if the store has no project.
+func (c *Client) inProject(projectIDs []string) bool {
+	if c.projectID == "" {
+		return true
+	}
+	for _, id := range projectIDs {
+		if id == c.projectID {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	return nil
+}
+
+// Validate returns Ready since NewClient already logged in with the access token.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultReady, nil
+}
@@ -0,0 +1,378 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitwarden
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/bitwarden/crypto"
+	"github.com/external-secrets/external-secrets/pkg/provider/bitwarden/fake"
+)
+
+const (
+	testNamespace    = "default"
+	testOrganization = "0b5a1c6e-6a43-4c3e-9a3e-3f7c2d1b0a01"
+	projectA         = "a1a1a1a1-0000-4000-8000-000000000001"
+	projectB         = "b2b2b2b2-0000-4000-8000-000000000002"
+	dbPasswordID     = "5d0b7c64-1d57-4a0e-b9f6-0c4a1e3f8a11"
+	dbConfigID       = "5d0b7c64-1d57-4a0e-b9f6-0c4a1e3f8a12"
+	apiTokenID       = "5d0b7c64-1d57-4a0e-b9f6-0c4a1e3f8a13"
+	otherPasswordID  = "5d0b7c64-1d57-4a0e-b9f6-0c4a1e3f8a14"
+)
+
+func newFakeServer() *fake.Server {
+	server := fake.NewServer(testOrganization)
+	server.AddSecret(dbPasswordID, projectA, "DB_PASSWORD", "s3cr3t", "database password")
+	server.AddSecret(dbConfigID, projectA, "DB_CONFIG", `{"user":"admin","port":5432}`, "")
+	server.AddSecret(apiTokenID, projectA, "API_TOKEN", "t0ken", "")
+	server.AddSecret(otherPasswordID, projectB, "DB_PASSWORD", "other", "")
+	return server
+}
+
+func TestGetSecret(t *testing.T) {
+	api := newAPI(t)
+	c := &Client{api: api, projectID: projectA}
+	unscoped := &Client{api: api}
+
+	tbl := []struct {
+		name     string
+		client   *Client
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "by id",
+			client:   c,
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: dbPasswordID},
+			expValue: "s3cr3t",
+		},
+		{
+			name:   "id not in project",
+			client: c,
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: otherPasswordID},
+			expErr: fmt.Sprintf(errSecretIDNotInProject, otherPasswordID, projectA),
+		},
+		{
+			name:     "id without project",
+			client:   unscoped,
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: otherPasswordID},
+			expValue: "other",
+		},
+		{
+			name:     "by name in project",
+			client:   c,
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_PASSWORD"},
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "property",
+			client:   c,
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_CONFIG", Property: "user"},
+			expValue: "admin",
+		},
+		{
+			name:   "missing property",
+			client: c,
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_CONFIG", Property: "password"},
+			expErr: fmt.Sprintf(errPropertyNotFound, "password", "DB_CONFIG"),
+		},
+		{
+			name:   "name not in project",
+			client: c,
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "MISSING"},
+			expErr: fmt.Sprintf(errSecretNotInProject, "MISSING", projectA),
+		},
+		{
+			name:   "missing id",
+			client: c,
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "5d0b7c64-1d57-4a0e-b9f6-0c4a1e3f8aff"},
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+		{
+			name:   "ambiguous name without project",
+			client: unscoped,
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_PASSWORD"},
+			expErr: fmt.Sprintf(errAmbiguousSecretName, 2, "DB_PASSWORD"),
+		},
+		{
+			name:     "unique name without project",
+			client:   unscoped,
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "API_TOKEN"},
+			expValue: "t0ken",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := row.client.GetSecret(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := &Client{api: newAPI(t), projectID: projectA}
+
+	tbl := []struct {
+		name     string
+		key      string
+		expValue map[string][]byte
+		expErr   string
+	}{
+		{
+			name:     "json object",
+			key:      "DB_CONFIG",
+			expValue: map[string][]byte{"user": []byte("admin"), "port": []byte("5432")},
+		},
+		{
+			name:   "not an object",
+			key:    "DB_PASSWORD",
+			expErr: fmt.Sprintf(errNotAnObject, "DB_PASSWORD"),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: row.key})
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expValue) {
+				t.Errorf("unexpected secret map: %v, expected: %v", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	api := newAPI(t)
+	c := &Client{api: api, projectID: projectA}
+	unscoped := &Client{api: api}
+	path := "DB"
+
+	tbl := []struct {
+		name     string
+		client   *Client
+		ref      esv1beta1.ExternalSecretFind
+		expValue map[string][]byte
+		expErr   string
+	}{
+		{
+			name:     "find by name in project",
+			client:   c,
+			ref:      esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^DB_"}},
+			expValue: map[string][]byte{"DB_PASSWORD": []byte("s3cr3t"), "DB_CONFIG": []byte(`{"user":"admin","port":5432}`)},
+		},
+		{
+			name:     "no match",
+			client:   c,
+			ref:      esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^MISSING"}},
+			expValue: map[string][]byte{},
+		},
+		{
+			name:   "ambiguous names without project",
+			client: unscoped,
+			ref:    esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "PASSWORD$"}},
+			expErr: fmt.Sprintf(errAmbiguousSecretName, 2, "DB_PASSWORD"),
+		},
+		{
+			name:   "missing name",
+			client: c,
+			ref:    esv1beta1.ExternalSecretFind{},
+			expErr: errFindNameRequired,
+		},
+		{
+			name:   "path",
+			client: c,
+			ref:    esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}, Path: &path},
+			expErr: errFindPathOrTags,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := row.client.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expValue) {
+				t.Errorf("unexpected secrets: %v, expected: %v", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	server := newFakeServer()
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	token := server.NewAccessToken()
+	parts := strings.SplitN(token, ":", 2)
+	tbl := []struct {
+		name   string
+		token  string
+		expErr string
+	}{
+		{
+			name:  "valid token",
+			token: token,
+		},
+		{
+			name:   "unknown machine account",
+			token:  "0.5d0b7c64-1d57-4a0e-b9f6-0c4a1e3f8aff.secret:" + parts[1],
+			expErr: "could not log in to bitwarden, status 400",
+		},
+		{
+			name:   "wrong encryption key",
+			token:  parts[0] + ":AAAAAAAAAAAAAAAAAAAAAA==",
+			expErr: "could not decrypt the organization key",
+		},
+		{
+			name:   "malformed token",
+			token:  "not-a-token",
+			expErr: "invalid access token",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bitwarden", Namespace: testNamespace},
+				Data:       map[string][]byte{"token": []byte(row.token)},
+			}).Build()
+			store := &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						BitwardenSecretsManager: &esv1beta1.BitwardenSecretsManagerProvider{
+							APIURL:         httpServer.URL + "/api",
+							IdentityURL:    httpServer.URL + "/identity",
+							OrganizationID: testOrganization,
+							Auth:           authWith(esmeta.SecretKeySelector{Name: "bitwarden", Key: "token"}),
+						},
+					},
+				},
+			}
+			_, err := (&Provider{}).NewClient(context.Background(), store, kube, testNamespace)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	namespace := testNamespace
+	accessToken := esmeta.SecretKeySelector{Name: "bitwarden", Key: "token"}
+	tbl := []struct {
+		name     string
+		provider esv1beta1.BitwardenSecretsManagerProvider
+		expErr   string
+	}{
+		{
+			name:     "missing organization",
+			provider: esv1beta1.BitwardenSecretsManagerProvider{Auth: authWith(accessToken)},
+			expErr:   errMissingOrganizationID,
+		},
+		{
+			name:     "missing access token",
+			provider: esv1beta1.BitwardenSecretsManagerProvider{OrganizationID: testOrganization},
+			expErr:   errMissingAccessToken,
+		},
+		{
+			name: "invalid api url",
+			provider: esv1beta1.BitwardenSecretsManagerProvider{
+				OrganizationID: testOrganization,
+				APIURL:         "vaultwarden/api",
+				Auth:           authWith(accessToken),
+			},
+			expErr: "invalid url",
+		},
+		{
+			name: "namespace on SecretStore",
+			provider: esv1beta1.BitwardenSecretsManagerProvider{
+				OrganizationID: testOrganization,
+				Auth:           authWith(esmeta.SecretKeySelector{Name: "bitwarden", Key: "token", Namespace: &namespace}),
+			},
+			expErr: "namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name: "valid",
+			provider: esv1beta1.BitwardenSecretsManagerProvider{
+				OrganizationID: testOrganization,
+				APIURL:         "https://vaultwarden.example.com/api",
+				IdentityURL:    "https://vaultwarden.example.com/identity",
+				Auth:           authWith(accessToken),
+			},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			store := &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{BitwardenSecretsManager: &row.provider},
+				},
+			}
+			err := (&Provider{}).ValidateStore(store)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func authWith(ref esmeta.SecretKeySelector) esv1beta1.BitwardenSecretsManagerAuth {
+	return esv1beta1.BitwardenSecretsManagerAuth{SecretRef: esv1beta1.BitwardenSecretsManagerSecretRef{AccessToken: ref}}
+}
+
+// newAPI logs in to a fake server holding the test secrets.
+func newAPI(t *testing.T) *apiClient {
+	t.Helper()
+	server := newFakeServer()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	token, err := crypto.ParseAccessToken(server.NewAccessToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, err := login(context.Background(), httpServer.Client(), httpServer.URL+"/identity", httpServer.URL+"/api", testOrganization, token)
+	if err != nil {
+		t.Fatalf("unexpected error logging in: %v", err)
+	}
+	return api
+}
+
+func ErrorContains(out error, want string) bool {
+	if out == nil {
+		return want == ""
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(out.Error(), want)
+}
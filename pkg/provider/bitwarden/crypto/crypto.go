@@ -0,0 +1,197 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crypto implements the parts of the Bitwarden encryption scheme
+// needed to read Secrets Manager secrets with a machine account access token.
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// encTypeAesCbc256HmacSha256 is the only EncString type used for Secrets Manager data.
+	encTypeAesCbc256HmacSha256 = "2"
+	accessTokenVersion         = "0"
+
+	errInvalidAccessToken = "invalid access token: %s"
+	errInvalidEncString   = "invalid encrypted string: %s"
+	errUnsupportedType    = "unsupported encryption type %s"
+	errInvalidKey         = "invalid key length %d"
+	errMACMismatch        = "encrypted string MAC does not match"
+	errInvalidPadding     = "invalid padding"
+)
+
+// Key is a symmetric key made of an AES-256 encryption key and a HMAC-SHA256 key.
+type Key struct {
+	EncKey []byte
+	MACKey []byte
+}
+
+// NewKey splits the 64 bytes of a key into the encryption and the MAC key.
+func NewKey(raw []byte) (*Key, error) {
+	if len(raw) != 64 {
+		return nil, fmt.Errorf(errInvalidKey, len(raw))
+	}
+	return &Key{EncKey: raw[:32], MACKey: raw[32:]}, nil
+}
+
+// Bytes returns the encryption key followed by the MAC key.
+func (k *Key) Bytes() []byte {
+	return append(append([]byte{}, k.EncKey...), k.MACKey...)
+}
+
+// AccessToken is a parsed machine account access token of the form
+// 0.<client id>.<client secret>:<base64 encryption key>.
+type AccessToken struct {
+	ClientID     string
+	ClientSecret string
+	// Key decrypts the encrypted payload of the login response.
+	Key *Key
+}
+
+// ParseAccessToken parses an access token and derives its encryption key.
+func ParseAccessToken(token string) (*AccessToken, error) {
+	tokenParts := strings.SplitN(strings.TrimSpace(token), ":", 2)
+	if len(tokenParts) != 2 {
+		return nil, fmt.Errorf(errInvalidAccessToken, "missing encryption key")
+	}
+	encodedKey := tokenParts[1]
+	parts := strings.Split(tokenParts[0], ".")
+	if len(parts) != 3 || parts[0] != accessTokenVersion || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf(errInvalidAccessToken, "expected version 0 with client id and secret")
+	}
+	secret, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(secret) != 16 {
+		return nil, fmt.Errorf(errInvalidAccessToken, "encryption key must be 16 bytes base64 encoded")
+	}
+	key, err := deriveShareableKey(secret, "accesstoken", "sm-access-token")
+	if err != nil {
+		return nil, err
+	}
+	return &AccessToken{ClientID: parts[1], ClientSecret: parts[2], Key: key}, nil
+}
+
+// deriveShareableKey derives a key from a random secret like the Bitwarden clients do.
+func deriveShareableKey(secret []byte, name, info string) (*Key, error) {
+	mac := hmac.New(sha256.New, []byte("bitwarden-"+name))
+	mac.Write(secret)
+	raw := make([]byte, 64)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, mac.Sum(nil), []byte(info)), raw); err != nil {
+		return nil, err
+	}
+	return NewKey(raw)
+}
+
+// Decrypt decrypts an EncString of the form 2.<iv>|<data>|<mac>.
+func Decrypt(encString string, key *Key) ([]byte, error) {
+	typeAndPayload := strings.SplitN(encString, ".", 2)
+	if len(typeAndPayload) != 2 {
+		return nil, fmt.Errorf(errInvalidEncString, "missing type")
+	}
+	if typeAndPayload[0] != encTypeAesCbc256HmacSha256 {
+		return nil, fmt.Errorf(errUnsupportedType, typeAndPayload[0])
+	}
+	parts := strings.Split(typeAndPayload[1], "|")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf(errInvalidEncString, "expected iv, data and mac")
+	}
+	var decoded [3][]byte
+	for i, part := range parts {
+		b, err := base64.StdEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidEncString, err)
+		}
+		decoded[i] = b
+	}
+	iv, data, tag := decoded[0], decoded[1], decoded[2]
+	if len(iv) != aes.BlockSize || len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf(errInvalidEncString, "invalid block size")
+	}
+	if !hmac.Equal(tag, computeMAC(key, iv, data)) {
+		return nil, errors.New(errMACMismatch)
+	}
+	block, err := aes.NewCipher(key.EncKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, data)
+	return unpad(plaintext)
+}
+
+// DecryptString decrypts an EncString into a string, an empty EncString is an empty value.
+func DecryptString(encString string, key *Key) (string, error) {
+	if encString == "" {
+		return "", nil
+	}
+	plaintext, err := Decrypt(encString, key)
+	return string(plaintext), err
+}
+
+// Encrypt encrypts the plaintext into an EncString of type 2.
+func Encrypt(plaintext []byte, key *Key) (string, error) {
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+	block, err := aes.NewCipher(key.EncKey)
+	if err != nil {
+		return "", err
+	}
+	padded := pad(plaintext)
+	data := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, padded)
+	return fmt.Sprintf("%s.%s|%s|%s", encTypeAesCbc256HmacSha256,
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(computeMAC(key, iv, data))), nil
+}
+
+func computeMAC(key *Key, iv, data []byte) []byte {
+	mac := hmac.New(sha256.New, key.MACKey)
+	mac.Write(iv)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func pad(data []byte) []byte {
+	n := aes.BlockSize - len(data)%aes.BlockSize
+	return append(append([]byte{}, data...), bytes.Repeat([]byte{byte(n)}, n)...)
+}
+
+func unpad(data []byte) ([]byte, error) {
+	n := int(data[len(data)-1])
+	if n == 0 || n > aes.BlockSize || n > len(data) {
+		return nil, errors.New(errInvalidPadding)
+	}
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, errors.New(errInvalidPadding)
+		}
+	}
+	return data[:len(data)-n], nil
+}
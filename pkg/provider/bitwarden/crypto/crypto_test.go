@@ -0,0 +1,131 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, err := NewKey(bytes.Repeat([]byte{1}, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, plaintext := range []string{"s3cr3t", "exactly 16 bytes", strings.Repeat("x", 100)} {
+		enc, err := Encrypt([]byte(plaintext), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(enc, "2.") {
+			t.Errorf("unexpected encryption type: %s", enc)
+		}
+		out, err := DecryptString(enc, key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != plaintext {
+			t.Errorf("unexpected plaintext: '%s', expected: '%s'", out, plaintext)
+		}
+	}
+}
+
+func TestDecryptClientEncString(t *testing.T) {
+	// encrypted by the Bitwarden SDK (sdk-go v1.0.2) creating a secret with the organization key 0x00..0x3f
+	raw := make([]byte, 64)
+	for i := range raw {
+		raw[i] = byte(i)
+	}
+	key, err := NewKey(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbl := []struct {
+		encString string
+		expValue  string
+	}{
+		{
+			encString: "2.G0g3xB7jAFrQ88inubrbBg==|4WEnWuK596GtWhrRyhq0Wg==|NyhzMoaCTH+9hU4C29Dyap1OKQi0Y7JyAjS/wMZ//18=",
+			expValue:  "DB_PASSWORD",
+		},
+		{
+			encString: "2.hthw854ItxubZ6+504b1qQ==|e/o+cL8nlLwzQkJOgABvSw==|jZV7U3JCx7qQ4X31cRNXr1dm/SzvM2a2wcLmX9uRB9Y=",
+			expValue:  "s3cr3t",
+		},
+		{
+			encString: "2.zWGXQtNAheI/zpyyI275Jg==|leP7E3UlkVo13RsHWqbM6uTh3FFRvTNfOosyC43uOSg=|aqKP2Etbw+VLlLzX1OItHkz9PaesO0AuLQEn4JiNQ7E=",
+			expValue:  "database password",
+		},
+	}
+	for _, row := range tbl {
+		out, err := DecryptString(row.encString, key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out != row.expValue {
+			t.Errorf("unexpected plaintext: '%s', expected: '%s'", out, row.expValue)
+		}
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	key, _ := NewKey(bytes.Repeat([]byte{1}, 64))
+	otherKey, _ := NewKey(bytes.Repeat([]byte{2}, 64))
+	enc, err := Encrypt([]byte("s3cr3t"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbl := []struct {
+		name      string
+		encString string
+		key       *Key
+		expErr    string
+	}{
+		{name: "wrong key", encString: enc, key: otherKey, expErr: errMACMismatch},
+		{name: "unsupported type", encString: "0." + strings.TrimPrefix(enc, "2."), key: key, expErr: "unsupported encryption type 0"},
+		{name: "missing mac", encString: enc[:strings.LastIndex(enc, "|")], key: key, expErr: "expected iv, data and mac"},
+		{name: "missing type", encString: "invalid", key: key, expErr: "missing type"},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			_, err := Decrypt(row.encString, row.key)
+			if err == nil || !strings.Contains(err.Error(), row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func TestParseAccessToken(t *testing.T) {
+	token, err := ParseAccessToken("0.ec2c1d46-6a4b-4751-a310-af9601317f2d.C2IgxjjLF7qSshsbwe8JGcbM075YXw:X8vbvA0bduihIDe/qrzIQQ==\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.ClientID != "ec2c1d46-6a4b-4751-a310-af9601317f2d" || token.ClientSecret != "C2IgxjjLF7qSshsbwe8JGcbM075YXw" {
+		t.Errorf("unexpected credentials: %s %s", token.ClientID, token.ClientSecret)
+	}
+	// the sample access token of the Bitwarden SDK and the key it derives from it
+	expKey := "H9/oIRLtL9nGCQOVDjSMoEbJsjWXSOCb3qeyDt6ckzS3FhyboEDWyTP/CQfbIszNmAVg2ExFganG1FVFGXO/Jg=="
+	if key := base64.StdEncoding.EncodeToString(token.Key.Bytes()); key != expKey {
+		t.Errorf("unexpected derived key: %s, expected: %s", key, expKey)
+	}
+	for _, invalid := range []string{"", "1.id.secret:X8vbvA0bduihIDe/qrzIQQ==", "0.id.secret", "0.id.secret:c2hvcnQ="} {
+		if _, err := ParseAccessToken(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/external-secrets/external-secrets/pkg/provider/bitwarden/crypto"
+)
+
+// Server is an http.Handler standing in for the identity service and the Secrets Manager API
+// of a Bitwarden organization. The identity service is served below /identity, the API below /api,
+// like on a self-hosted server.
+type Server struct {
+	organizationID  string
+	organizationKey *crypto.Key
+
+	mu       sync.Mutex
+	clients  map[string]*machineAccount
+	sessions map[string]bool
+	secrets  map[string]*secret
+}
+
+type machineAccount struct {
+	clientSecret string
+	key          *crypto.Key
+}
+
+type secret struct {
+	id        string
+	projectID string
+	key       string
+	value     string
+	note      string
+}
+
+type project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type secretResponse struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organizationId"`
+	Key            string    `json:"key"`
+	Value          string    `json:"value,omitempty"`
+	Note           string    `json:"note,omitempty"`
+	Projects       []project `json:"projects"`
+}
+
+// NewServer returns a server for the organization with a random organization key.
+func NewServer(organizationID string) *Server {
+	key, err := crypto.NewKey(randomBytes(64))
+	if err != nil {
+		panic(err)
+	}
+	return &Server{
+		organizationID:  organizationID,
+		organizationKey: key,
+		clients:         make(map[string]*machineAccount),
+		sessions:        make(map[string]bool),
+		secrets:         make(map[string]*secret),
+	}
+}
+
+// NewAccessToken creates a machine account and returns its access token.
+func (s *Server) NewAccessToken() string {
+	clientID := uuid.NewString()
+	clientSecret := base64.RawURLEncoding.EncodeToString(randomBytes(24))
+	encryptionKey := base64.StdEncoding.EncodeToString(randomBytes(16))
+	token := "0." + clientID + "." + clientSecret + ":" + encryptionKey
+	parsed, err := crypto.ParseAccessToken(token)
+	if err != nil {
+		panic(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[clientID] = &machineAccount{clientSecret: clientSecret, key: parsed.Key}
+	return token
+}
+
+// AddSecret adds a secret to the project of the organization.
+func (s *Server) AddSecret(id, projectID, key, value, note string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets[id] = &secret{id: id, projectID: projectID, key: key, value: value, note: note}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/identity/connect/token":
+		s.token(w, r)
+		return
+	case !strings.HasPrefix(r.URL.Path, "/api/"):
+		http.NotFound(w, r)
+		return
+	case !s.sessions[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]:
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	switch {
+	case r.Method == http.MethodGet && path == "/organizations/"+s.organizationID+"/secrets":
+		s.listSecrets(w)
+	case r.Method == http.MethodPost && path == "/secrets/get-by-ids":
+		s.getSecretsByIDs(w, r)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/secrets/"):
+		sec, ok := s.secrets[strings.TrimPrefix(path, "/secrets/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		s.writeJSON(w, s.response(sec, true))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	account, ok := s.clients[r.PostForm.Get("client_id")]
+	if !ok || account.clientSecret != r.PostForm.Get("client_secret") ||
+		r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "api.secrets" {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusBadRequest)
+		return
+	}
+	payload, err := json.Marshal(map[string]string{"encryptionKey": base64.StdEncoding.EncodeToString(s.organizationKey.Bytes())})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	encryptedPayload, err := crypto.Encrypt(payload, account.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	accessToken := uuid.NewString()
+	s.sessions[accessToken] = true
+	s.writeJSON(w, map[string]interface{}{
+		"access_token":      accessToken,
+		"expires_in":        3600,
+		"token_type":        "Bearer",
+		"scope":             "api.secrets",
+		"encrypted_payload": encryptedPayload,
+	})
+}
+
+func (s *Server) listSecrets(w http.ResponseWriter) {
+	ids := make([]string, 0, len(s.secrets))
+	for id := range s.secrets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	secrets := make([]secretResponse, 0, len(ids))
+	for _, id := range ids {
+		secrets = append(secrets, s.response(s.secrets[id], false))
+	}
+	s.writeJSON(w, map[string]interface{}{"secrets": secrets})
+}
+
+func (s *Server) getSecretsByIDs(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IDs []string `json:"ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data := make([]secretResponse, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		sec, ok := s.secrets[id]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data = append(data, s.response(sec, true))
+	}
+	s.writeJSON(w, map[string]interface{}{"data": data})
+}
+
+// response encrypts the secret, the list of secrets only contains the keys.
+func (s *Server) response(sec *secret, withValue bool) secretResponse {
+	resp := secretResponse{
+		ID:             sec.id,
+		OrganizationID: s.organizationID,
+		Key:            s.encrypt(sec.key),
+		Projects:       []project{},
+	}
+	if sec.projectID != "" {
+		resp.Projects = append(resp.Projects, project{ID: sec.projectID, Name: sec.projectID})
+	}
+	if withValue {
+		resp.Value = s.encrypt(sec.value)
+		resp.Note = s.encrypt(sec.note)
+	}
+	return resp
+}
+
+func (s *Server) encrypt(value string) string {
+	if value == "" {
+		return ""
+	}
+	enc, err := crypto.Encrypt([]byte(value), s.organizationKey)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,167 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conjur
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errAuthenticate       = "could not authenticate with conjur: %w"
+	errAuthenticateStatus = "could not authenticate with conjur, status %d: %s"
+	errRequest            = "conjur request for %s failed: %w"
+	errRequestStatus      = "conjur request for %s failed with status %d: %s"
+	errDecodeBatch        = "could not decode conjur batch response: %w"
+)
+
+// authenticator returns the request to exchange credentials for an access token.
+type authenticator func(ctx context.Context) (*http.Request, error)
+
+// conjurAPI reads variables with the Conjur REST API.
+type conjurAPI struct {
+	client       *http.Client
+	url          string
+	account      string
+	authenticate authenticator
+	// token is the base64 encoded access token.
+	token string
+}
+
+// apiKeyAuthenticator authenticates a host or user with its API key.
+func apiKeyAuthenticator(baseURL, account, login, apiKey string) authenticator {
+	return func(ctx context.Context) (*http.Request, error) {
+		u := fmt.Sprintf("%s/authn/%s/%s/authenticate", baseURL, url.PathEscape(account), url.PathEscape(login))
+		return http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(apiKey))
+	}
+}
+
+// jwtAuthenticator authenticates with the authn-jwt authenticator of the service.
+func jwtAuthenticator(baseURL, account, serviceID, hostID, jwt string) authenticator {
+	return func(ctx context.Context) (*http.Request, error) {
+		u := fmt.Sprintf("%s/authn-jwt/%s/%s", baseURL, url.PathEscape(serviceID), url.PathEscape(account))
+		if hostID != "" {
+			u += "/" + url.PathEscape(hostID)
+		}
+		u += "/authenticate"
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(url.Values{"jwt": {jwt}}.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	}
+}
+
+// login exchanges the credentials for a short lived access token.
+func (c *conjurAPI) login(ctx context.Context) error {
+	req, err := c.authenticate(ctx)
+	if err != nil {
+		return fmt.Errorf(errAuthenticate, err)
+	}
+	req.Header.Set("Accept-Encoding", "base64")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(errAuthenticate, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf(errAuthenticateStatus, resp.StatusCode, utils.ReadErrorBody(resp.Body))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf(errAuthenticate, err)
+	}
+	token := strings.TrimSpace(string(body))
+	// older servers ignore Accept-Encoding and return the JSON token
+	if strings.HasPrefix(token, "{") {
+		token = base64.StdEncoding.EncodeToString([]byte(token))
+	}
+	c.token = token
+	return nil
+}
+
+// retrieveSecret returns the value of the variable, the latest version if version is empty.
+func (c *conjurAPI) retrieveSecret(ctx context.Context, id, version string) ([]byte, error) {
+	// the id is escaped as one path segment, slashes included
+	u := fmt.Sprintf("%s/secrets/%s/variable/%s", c.url, url.PathEscape(c.account), url.PathEscape(id))
+	if version != "" {
+		u += "?" + url.Values{"version": {version}}.Encode()
+	}
+	return c.get(ctx, id, u)
+}
+
+// retrieveBatch returns the values of the variables by their ids.
+func (c *conjurAPI) retrieveBatch(ctx context.Context, ids []string) (map[string][]byte, error) {
+	fullIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		fullIDs = append(fullIDs, url.QueryEscape(c.fullID(id)))
+	}
+	body, err := c.get(ctx, strings.Join(ids, ","), c.url+"/secrets?variable_ids="+strings.Join(fullIDs, ","))
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]string
+	if err := json.Unmarshal(body, &values); err != nil {
+		return nil, fmt.Errorf(errDecodeBatch, err)
+	}
+	out := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		value, ok := values[c.fullID(id)]
+		if !ok {
+			return nil, esv1beta1.NoSecretErr
+		}
+		out[id] = []byte(value)
+	}
+	return out, nil
+}
+
+func (c *conjurAPI) get(ctx context.Context, name, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, name, err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Token token=%q", c.token))
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, name, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, esv1beta1.NoSecretErr
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf(errRequestStatus, name, resp.StatusCode, utils.ReadErrorBody(resp.Body))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, name, err)
+	}
+	return body, nil
+}
+
+// fullID qualifies a variable id with the account and kind, e.g. myorg:variable:db/password.
+func (c *conjurAPI) fullID(id string) string {
+	return c.account + ":variable:" + id
+}
@@ -0,0 +1,311 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conjur
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingStore          = "missing store provider conjur"
+	errMissingURL            = "conjur url must be set"
+	errInvalidURL            = "invalid conjur url %q: %w"
+	errMissingAccount        = "conjur account must be set"
+	errMissingAuth           = "exactly one of auth.apikey and auth.jwt must be set"
+	errMissingServiceID      = "auth.jwt.serviceID must be set"
+	errJwtNoTokenSource      = "auth.jwt requires exactly one of secretRef and kubernetesServiceAccountToken"
+	errGetKubeSATokenRequest = "cannot request Kubernetes service account token for service account %q: %w"
+	errPropertyNotFound      = "property %s not found in variable %s"
+	errDuplicateVariableName = "variables %s and %s have the same name %s"
+	errNotImplemented        = "find is not implemented for conjur"
+)
+
+var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.SecretsClient = &Client{}
+
+// Provider satisfies the provider interface.
+type Provider struct{}
+
+// Client reads variables from Conjur.
+type Client struct {
+	api *conjurAPI
+}
+
+// storeReader reads the credentials and certificates referenced by the store.
+type storeReader struct {
+	kube      client.Client
+	corev1    typedcorev1.CoreV1Interface
+	namespace string
+	storeKind string
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Conjur: &esv1beta1.ConjurProvider{},
+	})
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	var coreV1 typedcorev1.CoreV1Interface
+	if provider.Auth.Jwt != nil && provider.Auth.Jwt.KubernetesServiceAccountToken != nil {
+		// controller-runtime/client does not support TokenRequest or other subresource APIs
+		// so we need to construct our own client and use it to fetch tokens
+		restCfg, err := ctrlcfg.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientset, err := kubernetes.NewForConfig(restCfg)
+		if err != nil {
+			return nil, err
+		}
+		coreV1 = clientset.CoreV1()
+	}
+	return newClient(ctx, store, kube, coreV1, namespace)
+}
+
+func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, coreV1 typedcorev1.CoreV1Interface, namespace string) (esv1beta1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	r := &storeReader{
+		kube:      kube,
+		corev1:    coreV1,
+		namespace: namespace,
+		storeKind: store.GetObjectKind().GroupVersionKind().Kind,
+	}
+	httpClient, err := utils.NewCAHTTPClient(ctx, kube, r.storeKind, namespace, provider.CABundle, provider.CAProvider)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := strings.TrimSuffix(provider.URL, "/")
+	auth, err := r.getAuthenticator(ctx, baseURL, provider)
+	if err != nil {
+		return nil, err
+	}
+	api := &conjurAPI{
+		client:       httpClient,
+		url:          baseURL,
+		account:      provider.Account,
+		authenticate: auth,
+	}
+	if err := api.login(ctx); err != nil {
+		return nil, err
+	}
+	return &Client{api: api}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
+	provider, err := getProvider(store)
+	if err != nil {
+		return err
+	}
+	if provider.URL == "" {
+		return errors.New(errMissingURL)
+	}
+	if _, err := url.ParseRequestURI(provider.URL); err != nil {
+		return fmt.Errorf(errInvalidURL, provider.URL, err)
+	}
+	if provider.Account == "" {
+		return errors.New(errMissingAccount)
+	}
+	auth := provider.Auth
+	if (auth.APIKey == nil) == (auth.Jwt == nil) {
+		return errors.New(errMissingAuth)
+	}
+	var refs []esmeta.SecretKeySelector
+	if auth.APIKey != nil {
+		refs = append(refs, auth.APIKey.UserRef, auth.APIKey.APIKeyRef)
+	}
+	if auth.Jwt != nil {
+		if auth.Jwt.ServiceID == "" {
+			return errors.New(errMissingServiceID)
+		}
+		if (auth.Jwt.SecretRef == nil) == (auth.Jwt.KubernetesServiceAccountToken == nil) {
+			return errors.New(errJwtNoTokenSource)
+		}
+		if auth.Jwt.SecretRef != nil {
+			refs = append(refs, *auth.Jwt.SecretRef)
+		}
+		if sat := auth.Jwt.KubernetesServiceAccountToken; sat != nil {
+			if err := utils.ValidateServiceAccountSelector(store, sat.ServiceAccountRef); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ref := range refs {
+		if err := utils.ValidateSecretSelector(store, ref); err != nil {
+			return err
+		}
+	}
+	return utils.ValidateCAProvider(store, provider.CAProvider)
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.ConjurProvider, error) {
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Conjur == nil {
+		return nil, errors.New(errMissingStore)
+	}
+	return spc.Provider.Conjur, nil
+}
+
+// GetSecret returns the value of the variable with the id given by key. If property is set
+// the value is parsed as JSON and the property is resolved as gjson path.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.api.retrieveSecret(ctx, ref.Key, ref.Version)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return value, nil
+	}
+	val := gjson.GetBytes(value, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap retrieves the comma separated list of variable ids given by key in one batch.
+// The keys of the map are the last segments of the ids, e.g. password for db/password.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	var ids []string
+	for _, id := range strings.Split(ref.Key, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	values, err := c.api.retrieveBatch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte, len(values))
+	names := make(map[string]string, len(values))
+	for _, id := range ids {
+		name := path.Base(id)
+		if other, ok := names[name]; ok && other != id {
+			return nil, fmt.Errorf(errDuplicateVariableName, other, id, name)
+		}
+		names[name] = id
+		secretMap[name] = values[id]
+	}
+	return secretMap, nil
+}
+
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errors.New(errNotImplemented)
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	return nil
+}
+
+// Validate authenticates again to check the credentials are still accepted by the authn endpoint.
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	if err := c.api.login(context.Background()); err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (r *storeReader) getAuthenticator(ctx context.Context, baseURL string, provider *esv1beta1.ConjurProvider) (authenticator, error) {
+	if apiKey := provider.Auth.APIKey; apiKey != nil {
+		login, err := r.secretKeyRef(ctx, &apiKey.UserRef)
+		if err != nil {
+			return nil, err
+		}
+		key, err := r.secretKeyRef(ctx, &apiKey.APIKeyRef)
+		if err != nil {
+			return nil, err
+		}
+		return apiKeyAuthenticator(baseURL, provider.Account, login, key), nil
+	}
+	jwtAuth := provider.Auth.Jwt
+	if jwtAuth == nil {
+		return nil, errors.New(errMissingAuth)
+	}
+	var jwt string
+	var err error
+	if jwtAuth.SecretRef != nil {
+		jwt, err = r.secretKeyRef(ctx, jwtAuth.SecretRef)
+	} else if k8sServiceAccountToken := jwtAuth.KubernetesServiceAccountToken; k8sServiceAccountToken != nil {
+		audiences := k8sServiceAccountToken.Audiences
+		if audiences == nil {
+			audiences = &[]string{"conjur"}
+		}
+		expirationSeconds := k8sServiceAccountToken.ExpirationSeconds
+		if expirationSeconds == nil {
+			tmp := int64(600)
+			expirationSeconds = &tmp
+		}
+		jwt, err = r.serviceAccountToken(ctx, k8sServiceAccountToken.ServiceAccountRef, *audiences, *expirationSeconds)
+	} else {
+		err = errors.New(errJwtNoTokenSource)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jwtAuthenticator(baseURL, provider.Account, jwtAuth.ServiceID, jwtAuth.HostID, jwt), nil
+}
+
+func (r *storeReader) serviceAccountToken(ctx context.Context, serviceAccountRef esmeta.ServiceAccountSelector, audiences []string, expirationSeconds int64) (string, error) {
+	tokenRequest := &authenticationv1.TokenRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.namespace,
+		},
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	if (r.storeKind == esv1beta1.ClusterSecretStoreKind) &&
+		(serviceAccountRef.Namespace != nil) {
+		tokenRequest.Namespace = *serviceAccountRef.Namespace
+	}
+	tokenResponse, err := r.corev1.ServiceAccounts(tokenRequest.Namespace).CreateToken(ctx, serviceAccountRef.Name, tokenRequest, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf(errGetKubeSATokenRequest, serviceAccountRef.Name, err)
+	}
+	return tokenResponse.Status.Token, nil
+}
+
+func (r *storeReader) secretKeyRef(ctx context.Context, ref *esmeta.SecretKeySelector) (string, error) {
+	val, err := utils.FetchSecretKey(ctx, r.kube, r.storeKind, r.namespace, ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(val)), nil
+}
@@ -0,0 +1,424 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conjur
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/conjur/fake"
+)
+
+const (
+	testNamespace = "default"
+	testAccount   = "myorg"
+	testLogin     = "host/external-secrets"
+	testAPIKey    = "api-key"
+	testJWT       = "service-account-jwt"
+)
+
+func newFakeServer() *fake.Server {
+	server := fake.NewServer(testAccount)
+	server.AddAPIKey(testLogin, testAPIKey)
+	server.AddVariable("db/user", "admin")
+	server.AddVariable("db/password", "old", "s3cr3t")
+	server.AddVariable("app/config", `{"db":{"port":5432}}`)
+	server.AddVariable("other/password", "other")
+	return server
+}
+
+func apiKeyAuth() esv1beta1.ConjurAuth {
+	return esv1beta1.ConjurAuth{
+		APIKey: &esv1beta1.ConjurAPIKey{
+			UserRef:   esmeta.SecretKeySelector{Name: "conjur", Key: "login"},
+			APIKeyRef: esmeta.SecretKeySelector{Name: "conjur", Key: "apikey"},
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t, newFakeServer(), apiKeyAuth(), nil)
+
+	tbl := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "latest version",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db/password"},
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "version",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db/password", Version: "1"},
+			expValue: "old",
+		},
+		{
+			name:     "property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/config", Property: "db.port"},
+			expValue: "5432",
+		},
+		{
+			name:   "missing property",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "app/config", Property: "db.user"},
+			expErr: fmt.Sprintf(errPropertyNotFound, "db.user", "app/config"),
+		},
+		{
+			name:   "missing variable",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "db/missing"},
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecret(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t, newFakeServer(), apiKeyAuth(), nil)
+
+	tbl := []struct {
+		name     string
+		key      string
+		expValue map[string]string
+		expErr   string
+	}{
+		{
+			name:     "batch",
+			key:      "db/user, db/password",
+			expValue: map[string]string{"user": "admin", "password": "s3cr3t"},
+		},
+		{
+			name:     "single variable",
+			key:      "db/user",
+			expValue: map[string]string{"user": "admin"},
+		},
+		{
+			name:   "duplicate names",
+			key:    "db/password,other/password",
+			expErr: fmt.Sprintf(errDuplicateVariableName, "db/password", "other/password", "password"),
+		},
+		{
+			name:   "missing variable",
+			key:    "db/user,db/missing",
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: row.key})
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if len(out) != len(row.expValue) {
+				t.Errorf("unexpected keys: %v", out)
+			}
+			for k, v := range row.expValue {
+				if string(out[k]) != v {
+					t.Errorf("unexpected value of %s: '%s', expected: '%s'", k, out[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestJWTAuth(t *testing.T) {
+	server := newFakeServer()
+	server.AddJWT("kubernetes", "", testJWT)
+	server.AddJWT("kubernetes-host", "host/external-secrets", testJWT)
+	expiration := int64(900)
+
+	tbl := []struct {
+		name         string
+		jwt          esv1beta1.ConjurJWT
+		expAudiences []string
+		expExpiry    int64
+		expErr       string
+	}{
+		{
+			name: "token from secret",
+			jwt: esv1beta1.ConjurJWT{
+				ServiceID: "kubernetes",
+				SecretRef: &esmeta.SecretKeySelector{Name: "conjur", Key: "jwt"},
+			},
+		},
+		{
+			name: "service account token",
+			jwt: esv1beta1.ConjurJWT{
+				ServiceID: "kubernetes",
+				KubernetesServiceAccountToken: &esv1beta1.ConjurKubernetesServiceAccountTokenAuth{
+					ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets"},
+				},
+			},
+			expAudiences: []string{"conjur"},
+			expExpiry:    600,
+		},
+		{
+			name: "service account token with host id",
+			jwt: esv1beta1.ConjurJWT{
+				ServiceID: "kubernetes-host",
+				HostID:    "host/external-secrets",
+				KubernetesServiceAccountToken: &esv1beta1.ConjurKubernetesServiceAccountTokenAuth{
+					ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets"},
+					Audiences:         &[]string{"https://conjur.example.com"},
+					ExpirationSeconds: &expiration,
+				},
+			},
+			expAudiences: []string{"https://conjur.example.com"},
+			expExpiry:    900,
+		},
+		{
+			name: "unknown service",
+			jwt: esv1beta1.ConjurJWT{
+				ServiceID: "other",
+				SecretRef: &esmeta.SecretKeySelector{Name: "conjur", Key: "jwt"},
+			},
+			expErr: "could not authenticate with conjur, status 401",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			tokens := &fakeTokenRequests{token: testJWT}
+			c, err := newConjurClient(t, server, esv1beta1.ConjurAuth{Jwt: &row.jwt}, tokens)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err != nil {
+				return
+			}
+			out, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db/user"})
+			if err != nil || string(out) != "admin" {
+				t.Errorf("unexpected result: %s, %v", out, err)
+			}
+			if row.expAudiences == nil {
+				return
+			}
+			if tokens.request == nil {
+				t.Fatalf("no token requested")
+			}
+			if strings.Join(tokens.request.Spec.Audiences, ",") != strings.Join(row.expAudiences, ",") {
+				t.Errorf("unexpected audiences: %v", tokens.request.Spec.Audiences)
+			}
+			if *tokens.request.Spec.ExpirationSeconds != row.expExpiry {
+				t.Errorf("unexpected expiration: %d", *tokens.request.Spec.ExpirationSeconds)
+			}
+			if tokens.namespace != testNamespace || tokens.name != "external-secrets" {
+				t.Errorf("unexpected service account: %s/%s", tokens.namespace, tokens.name)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	server := newFakeServer()
+	server.AddAPIKey(testLogin, "rotated")
+	_, err := newConjurClient(t, server, apiKeyAuth(), nil)
+	if !ErrorContains(err, "could not authenticate with conjur, status 401") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	server := newFakeServer()
+	c := newTestClient(t, server, apiKeyAuth(), nil)
+	authentications := server.Authentications()
+
+	result, err := c.Validate()
+	if err != nil || result != esv1beta1.ValidationResultReady {
+		t.Errorf("unexpected result: %v, %v", result, err)
+	}
+	if server.Authentications() != authentications+1 {
+		t.Errorf("expected Validate to authenticate")
+	}
+
+	server.AddAPIKey(testLogin, "rotated")
+	result, err = c.Validate()
+	if err == nil || result != esv1beta1.ValidationResultError {
+		t.Errorf("unexpected result: %v, %v", result, err)
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	namespace := testNamespace
+	tbl := []struct {
+		name     string
+		kind     string
+		provider esv1beta1.ConjurProvider
+		expErr   string
+	}{
+		{
+			name:     "missing url",
+			provider: esv1beta1.ConjurProvider{Account: testAccount, Auth: apiKeyAuth()},
+			expErr:   errMissingURL,
+		},
+		{
+			name:     "missing account",
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Auth: apiKeyAuth()},
+			expErr:   errMissingAccount,
+		},
+		{
+			name:     "missing auth",
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Account: testAccount},
+			expErr:   errMissingAuth,
+		},
+		{
+			name: "missing service id",
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Account: testAccount, Auth: esv1beta1.ConjurAuth{
+				Jwt: &esv1beta1.ConjurJWT{SecretRef: &esmeta.SecretKeySelector{Name: "conjur", Key: "jwt"}},
+			}},
+			expErr: errMissingServiceID,
+		},
+		{
+			name: "missing jwt source",
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Account: testAccount, Auth: esv1beta1.ConjurAuth{
+				Jwt: &esv1beta1.ConjurJWT{ServiceID: "kubernetes"},
+			}},
+			expErr: errJwtNoTokenSource,
+		},
+		{
+			name: "namespace on SecretStore service account",
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Account: testAccount, Auth: esv1beta1.ConjurAuth{
+				Jwt: &esv1beta1.ConjurJWT{ServiceID: "kubernetes", KubernetesServiceAccountToken: &esv1beta1.ConjurKubernetesServiceAccountTokenAuth{
+					ServiceAccountRef: esmeta.ServiceAccountSelector{Name: "external-secrets", Namespace: &namespace},
+				}},
+			}},
+			expErr: "namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name: "unknown caProvider type",
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Account: testAccount, Auth: apiKeyAuth(),
+				CAProvider: &esv1beta1.CAProvider{Type: "Vault", Name: "conjur-ca"},
+			},
+			expErr: `unknown caProvider type "Vault"`,
+		},
+		{
+			name: "caProvider without namespace on ClusterSecretStore",
+			kind: esv1beta1.ClusterSecretStoreKind,
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Account: testAccount, Auth: esv1beta1.ConjurAuth{
+				APIKey: &esv1beta1.ConjurAPIKey{
+					UserRef:   esmeta.SecretKeySelector{Name: "conjur", Key: "login", Namespace: &namespace},
+					APIKeyRef: esmeta.SecretKeySelector{Name: "conjur", Key: "apikey", Namespace: &namespace},
+				},
+			}, CAProvider: &esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeConfigMap, Name: "conjur-ca", Key: "ca.crt"}},
+			expErr: "missing namespace for conjur-ca on kind ClusterSecretStore",
+		},
+		{
+			name:     "valid",
+			provider: esv1beta1.ConjurProvider{URL: "https://conjur.example.com", Account: testAccount, Auth: apiKeyAuth()},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			store := &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: row.kind},
+				Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Conjur: &row.provider}},
+			}
+			err := (&Provider{}).ValidateStore(store)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func newTestClient(t *testing.T, server *fake.Server, auth esv1beta1.ConjurAuth, coreV1 typedcorev1.CoreV1Interface) esv1beta1.SecretsClient {
+	t.Helper()
+	c, err := newConjurClient(t, server, auth, coreV1)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return c
+}
+
+func newConjurClient(t *testing.T, server *fake.Server, auth esv1beta1.ConjurAuth, coreV1 typedcorev1.CoreV1Interface) (esv1beta1.SecretsClient, error) {
+	t.Helper()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "conjur", Namespace: testNamespace},
+		Data: map[string][]byte{
+			"login":  []byte(testLogin),
+			"apikey": []byte(testAPIKey + "\n"),
+			"jwt":    []byte(testJWT),
+		},
+	}).Build()
+	store := &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Conjur: &esv1beta1.ConjurProvider{
+					URL:     httpServer.URL,
+					Account: testAccount,
+					Auth:    auth,
+				},
+			},
+		},
+	}
+	return newClient(context.Background(), store, kube, coreV1, testNamespace)
+}
+
+// fakeTokenRequests records the TokenRequest and returns the token.
+type fakeTokenRequests struct {
+	typedcorev1.CoreV1Interface
+	typedcorev1.ServiceAccountInterface
+
+	token     string
+	namespace string
+	name      string
+	request   *authv1.TokenRequest
+}
+
+func (f *fakeTokenRequests) ServiceAccounts(namespace string) typedcorev1.ServiceAccountInterface {
+	f.namespace = namespace
+	return f
+}
+
+func (f *fakeTokenRequests) CreateToken(ctx context.Context, name string, tokenRequest *authv1.TokenRequest, opts metav1.CreateOptions) (*authv1.TokenRequest, error) {
+	f.name = name
+	f.request = tokenRequest
+	return &authv1.TokenRequest{Status: authv1.TokenRequestStatus{Token: f.token}}, nil
+}
+
+func ErrorContains(out error, want string) bool {
+	if out == nil {
+		return want == ""
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(out.Error(), want)
+}
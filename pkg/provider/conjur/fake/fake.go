@@ -0,0 +1,192 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Server is an http.Handler standing in for the Conjur REST API of one account.
+// It supports the authn and authn-jwt authenticators and reading variables.
+type Server struct {
+	account string
+
+	mu              sync.Mutex
+	apiKeys         map[string]string
+	jwts            map[string]string
+	variables       map[string][]string
+	tokens          map[string]bool
+	authentications int
+}
+
+// NewServer returns a Conjur server for the account.
+func NewServer(account string) *Server {
+	return &Server{
+		account:   account,
+		apiKeys:   make(map[string]string),
+		jwts:      make(map[string]string),
+		variables: make(map[string][]string),
+		tokens:    make(map[string]bool),
+	}
+}
+
+// AddAPIKey allows the host or user to authenticate with the API key.
+func (s *Server) AddAPIKey(login, apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKeys[login] = apiKey
+}
+
+// AddJWT allows to authenticate with the JWT at the authn-jwt service, as the host
+// if it is set in the URL, or with the host read from the token otherwise.
+func (s *Server) AddJWT(serviceID, hostID, jwt string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jwts[serviceID+"/"+hostID+"/"+jwt] = hostID
+}
+
+// AddVariable adds the versions of a variable, the last one is the current value.
+func (s *Server) AddVariable(id string, versions ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.variables[id] = versions
+}
+
+// RevokeTokens invalidates all issued access tokens.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]bool)
+}
+
+// Authentications returns how often a client authenticated successfully.
+func (s *Server) Authentications() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.authentications
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/"), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		segments[i] = unescaped
+	}
+
+	switch {
+	case r.Method == http.MethodPost && len(segments) == 4 && segments[0] == "authn" && segments[3] == "authenticate":
+		body, _ := io.ReadAll(r.Body)
+		apiKey, ok := s.apiKeys[segments[2]]
+		s.authenticate(w, r, segments[1] == s.account && ok && apiKey == string(body))
+	case r.Method == http.MethodPost && (len(segments) == 4 || len(segments) == 5) && segments[0] == "authn-jwt" && segments[len(segments)-1] == "authenticate":
+		hostID := ""
+		if len(segments) == 5 {
+			hostID = segments[3]
+		}
+		_, ok := s.jwts[segments[1]+"/"+hostID+"/"+r.PostFormValue("jwt")]
+		s.authenticate(w, r, segments[2] == s.account && ok)
+	case r.Method == http.MethodGet && len(segments) == 1 && segments[0] == "secrets":
+		if s.authorize(w, r) {
+			s.batch(w, r)
+		}
+	case r.Method == http.MethodGet && len(segments) == 4 && segments[0] == "secrets" && segments[1] == s.account && segments[2] == "variable":
+		if s.authorize(w, r) {
+			s.variable(w, r, segments[3])
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request, ok bool) {
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.authentications++
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	token := fmt.Sprintf(`{"protected":"e30=","payload":%q,"signature":"c2ln"}`, hex.EncodeToString(nonce))
+	encoded := base64.StdEncoding.EncodeToString([]byte(token))
+	s.tokens[encoded] = true
+	if r.Header.Get("Accept-Encoding") == "base64" {
+		_, _ = w.Write([]byte(encoded))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(token))
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) bool {
+	var token string
+	if _, err := fmt.Sscanf(r.Header.Get("Authorization"), "Token token=%q", &token); err != nil || !s.tokens[token] {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func (s *Server) variable(w http.ResponseWriter, r *http.Request, id string) {
+	versions, ok := s.variables[id]
+	if !ok || len(versions) == 0 {
+		http.Error(w, "Variable not found", http.StatusNotFound)
+		return
+	}
+	value := versions[len(versions)-1]
+	if v := r.URL.Query().Get("version"); v != "" {
+		version, err := strconv.Atoi(v)
+		if err != nil || version < 1 || version > len(versions) {
+			http.Error(w, "Requested version does not exist", http.StatusNotFound)
+			return
+		}
+		value = versions[version-1]
+	}
+	_, _ = w.Write([]byte(value))
+}
+
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	prefix := s.account + ":variable:"
+	out := make(map[string]string)
+	for _, fullID := range strings.Split(r.URL.Query().Get("variable_ids"), ",") {
+		versions, ok := s.variables[strings.TrimPrefix(fullID, prefix)]
+		if !strings.HasPrefix(fullID, prefix) || !ok || len(versions) == 0 {
+			http.Error(w, "Variable not found: "+fullID, http.StatusNotFound)
+			return
+		}
+		out[fullID] = versions[len(versions)-1]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
@@ -0,0 +1,211 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingStore        = "missing store provider consul"
+	errMissingServer       = "consul server must be set"
+	errInvalidServer       = "invalid consul server %q: %w"
+	errPropertyNotFound    = "property %s not found in key %s"
+	errNotAnObject         = "value of key %s is not a json object and there are no keys below it"
+	errFindTagsUnsupported = "consul kv has no tags, only find.name and find.path are supported"
+)
+
+var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.SecretsClient = &Client{}
+
+// Provider satisfies the provider interface.
+type Provider struct{}
+
+// Client reads secrets from the Consul KV store.
+type Client struct {
+	kv KV
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Consul: &esv1beta1.ConsulProvider{},
+	})
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	storeKind := store.GetObjectKind().GroupVersionKind().Kind
+	httpClient, err := utils.NewCAHTTPClient(ctx, kube, storeKind, namespace, provider.CABundle, provider.CAProvider)
+	if err != nil {
+		return nil, err
+	}
+	kv := &kvClient{
+		client:     httpClient,
+		server:     provider.Server,
+		namespace:  provider.Namespace,
+		partition:  provider.Partition,
+		datacenter: provider.Datacenter,
+	}
+	if provider.Auth.TokenSecretRef != nil {
+		token, err := utils.FetchSecretKey(ctx, kube, storeKind, namespace, provider.Auth.TokenSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		kv.token = strings.TrimSpace(string(token))
+	}
+	return &Client{kv: kv}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
+	provider, err := getProvider(store)
+	if err != nil {
+		return err
+	}
+	if provider.Server == "" {
+		return errors.New(errMissingServer)
+	}
+	if _, err := url.Parse(provider.Server); err != nil {
+		return fmt.Errorf(errInvalidServer, provider.Server, err)
+	}
+	if provider.Auth.TokenSecretRef != nil {
+		if err := utils.ValidateSecretSelector(store, *provider.Auth.TokenSecretRef); err != nil {
+			return err
+		}
+	}
+	return utils.ValidateCAProvider(store, provider.CAProvider)
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.ConsulProvider, error) {
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Consul == nil {
+		return nil, errors.New(errMissingStore)
+	}
+	return spc.Provider.Consul, nil
+}
+
+// GetSecret returns the value of the key. If property is set the value is parsed as JSON
+// and the property is resolved as gjson path.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	pair, err := c.kv.Get(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if pair == nil {
+		return nil, esv1beta1.NoSecretErr
+	}
+	if ref.Property == "" {
+		return pair.Value, nil
+	}
+	val := gjson.GetBytes(pair.Value, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the values of all keys below the key, relative to it.
+// If there are no keys below it, the value of the key is parsed as JSON object.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	prefix := strings.TrimSuffix(ref.Key, "/") + "/"
+	pairs, err := c.kv.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte, len(pairs))
+	for _, pair := range pairs {
+		if isFolder(pair) {
+			continue
+		}
+		secretMap[strings.TrimPrefix(pair.Key, prefix)] = pair.Value
+	}
+	if len(secretMap) > 0 {
+		return secretMap, nil
+	}
+
+	value, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	obj := gjson.ParseBytes(value)
+	if !obj.IsObject() {
+		return nil, fmt.Errorf(errNotAnObject, ref.Key)
+	}
+	obj.ForEach(func(key, value gjson.Result) bool {
+		secretMap[key.String()] = []byte(value.String())
+		return true
+	})
+	return secretMap, nil
+}
+
+// GetAllSecrets returns the values of the keys below find.path matching find.name.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errFindTagsUnsupported)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	prefix := ""
+	if ref.Path != nil {
+		prefix = *ref.Path
+	}
+	pairs, err := c.kv.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	secretMap := make(map[string][]byte, len(pairs))
+	for _, pair := range pairs {
+		if isFolder(pair) {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(pair.Key) {
+			continue
+		}
+		secretMap[pair.Key] = pair.Value
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
+
+// isFolder reports whether the entry only exists to group other keys, e.g. created by the Consul UI.
+func isFolder(pair *KVPair) bool {
+	return strings.HasSuffix(pair.Key, "/") && len(pair.Value) == 0
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	return nil
+}
+
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultUnknown, nil
+}
@@ -0,0 +1,423 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/consul/fake"
+)
+
+const (
+	testNamespace = "default"
+	testToken     = "acl-token"
+)
+
+func TestGetSecret(t *testing.T) {
+	kv := fake.NewKVServer(testToken)
+	kv.Put("app/db/password", []byte("s3cr3t"))
+	kv.Put("app/config", []byte(`{"db":{"user":"admin"}}`))
+	kv.Put("app/with space", []byte("spaced"))
+	c := newTestClient(t, kv, nil)
+
+	tbl := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "value",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db/password"},
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/config", Property: "db.user"},
+			expValue: "admin",
+		},
+		{
+			name:     "escaped key",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/with space"},
+			expValue: "spaced",
+		},
+		{
+			name:   "missing property",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "app/config", Property: "db.password"},
+			expErr: fmt.Sprintf(errPropertyNotFound, "db.password", "app/config"),
+		},
+		{
+			name:   "missing key",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "app/missing"},
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecret(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	kv := fake.NewKVServer(testToken)
+	kv.Put("app/db/", nil)
+	kv.Put("app/db/user", []byte("admin"))
+	kv.Put("app/db/password", []byte("s3cr3t"))
+	kv.Put("app/db/tls/ca", []byte("ca"))
+	kv.Put("app/dbx/user", []byte("other"))
+	kv.Put("app/config", []byte(`{"user":"admin","port":5432}`))
+	kv.Put("app/plain", []byte("plain"))
+	c := newTestClient(t, kv, nil)
+
+	tbl := []struct {
+		name     string
+		key      string
+		expValue map[string]string
+		expErr   string
+	}{
+		{
+			name:     "prefix",
+			key:      "app/db",
+			expValue: map[string]string{"user": "admin", "password": "s3cr3t", "tls/ca": "ca"},
+		},
+		{
+			name:     "prefix with trailing slash",
+			key:      "app/db/",
+			expValue: map[string]string{"user": "admin", "password": "s3cr3t", "tls/ca": "ca"},
+		},
+		{
+			name:     "json value",
+			key:      "app/config",
+			expValue: map[string]string{"user": "admin", "port": "5432"},
+		},
+		{
+			name:   "plain value",
+			key:    "app/plain",
+			expErr: fmt.Sprintf(errNotAnObject, "app/plain"),
+		},
+		{
+			name:   "missing key",
+			key:    "app/missing",
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: row.key})
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			assertSecretMap(t, out, row.expValue)
+		})
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	kv := fake.NewKVServer(testToken)
+	kv.Put("app/", nil)
+	kv.Put("app/db/user", []byte("admin"))
+	kv.Put("app/db/password", []byte("s3cr3t"))
+	kv.Put("app/api/token", []byte("t0ken"))
+	kv.Put("other/password", []byte("other"))
+	c := newTestClient(t, kv, nil)
+
+	path := "app/"
+	tbl := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretFind
+		expValue map[string]string
+		expErr   string
+	}{
+		{
+			name: "find by path",
+			ref:  esv1beta1.ExternalSecretFind{Path: &path},
+			expValue: map[string]string{
+				"app_db_user":     "admin",
+				"app_db_password": "s3cr3t",
+				"app_api_token":   "t0ken",
+			},
+		},
+		{
+			name: "find by name",
+			ref:  esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "password$"}},
+			expValue: map[string]string{
+				"app_db_password": "s3cr3t",
+				"other_password":  "other",
+			},
+		},
+		{
+			name: "find by path and name",
+			ref:  esv1beta1.ExternalSecretFind{Path: &path, Name: &esv1beta1.FindName{RegExp: "password$"}},
+			expValue: map[string]string{
+				"app_db_password": "s3cr3t",
+			},
+		},
+		{
+			name:   "find by tags",
+			ref:    esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expErr: errFindTagsUnsupported,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row.ref.ConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+			out, err := c.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			assertSecretMap(t, out, row.expValue)
+		})
+	}
+}
+
+func TestNamespaceAndPartition(t *testing.T) {
+	kv := fake.NewKVServer(testToken)
+	kv.Put("app/password", []byte("default"))
+	kv.PutScoped("team-a", "part-1", "app/password", []byte("scoped"))
+	c := newTestClient(t, kv, func(p *esv1beta1.ConsulProvider) {
+		p.Namespace = "team-a"
+		p.Partition = "part-1"
+		p.Datacenter = "dc2"
+	})
+
+	out, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "app/password"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != "scoped" {
+		t.Errorf("unexpected value: '%s'", out)
+	}
+	reqs := kv.Requests()
+	if dc := reqs[len(reqs)-1].URL.Query().Get("dc"); dc != "dc2" {
+		t.Errorf("unexpected datacenter: '%s'", dc)
+	}
+}
+
+func TestACLToken(t *testing.T) {
+	kv := fake.NewKVServer("other-token")
+	kv.Put("app/password", []byte("s3cr3t"))
+	c := newTestClient(t, kv, nil)
+
+	_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "app/password"})
+	if !ErrorContains(err, fmt.Sprintf(errKVPermission, "app/password")) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCAProvider(t *testing.T) {
+	kv := fake.NewKVServer(testToken)
+	kv.Put("app/password", []byte("s3cr3t"))
+	server := httptest.NewTLSServer(kv)
+	defer server.Close()
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tbl := []struct {
+		name       string
+		caProvider *esv1beta1.CAProvider
+		expErr     string
+	}{
+		{
+			name:   "without CA",
+			expErr: "certificate",
+		},
+		{
+			name:       "CA from configmap",
+			caProvider: &esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeConfigMap, Name: "consul-ca", Key: "ca.crt"},
+		},
+		{
+			name:       "CA from secret",
+			caProvider: &esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeSecret, Name: "consul-ca", Key: "ca.crt"},
+		},
+		{
+			name:       "missing configmap key",
+			caProvider: &esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeConfigMap, Name: "consul-ca", Key: "missing"},
+			expErr:     "missing key missing in configmap consul-ca",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithObjects(
+				tokenSecret(),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "consul-ca", Namespace: testNamespace},
+					Data:       map[string]string{"ca.crt": string(caCert)},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "consul-ca", Namespace: testNamespace},
+					Data:       map[string][]byte{"ca.crt": caCert},
+				},
+			).Build()
+			store := newStore(server.URL)
+			store.Spec.Provider.Consul.CAProvider = row.caProvider
+			c, err := (&Provider{}).NewClient(context.Background(), store, kube, testNamespace)
+			if err == nil {
+				_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "app/password"})
+			}
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	namespace := testNamespace
+	tbl := []struct {
+		name     string
+		kind     string
+		provider esv1beta1.ConsulProvider
+		expErr   string
+	}{
+		{
+			name:     "missing server",
+			provider: esv1beta1.ConsulProvider{},
+			expErr:   errMissingServer,
+		},
+		{
+			name:     "invalid server",
+			provider: esv1beta1.ConsulProvider{Server: "http://consul:port"},
+			expErr:   "invalid consul server",
+		},
+		{
+			name: "namespace on SecretStore token",
+			provider: esv1beta1.ConsulProvider{
+				Server: "http://consul:8500",
+				Auth:   esv1beta1.ConsulAuth{TokenSecretRef: &esmeta.SecretKeySelector{Name: "consul", Key: "token", Namespace: &namespace}},
+			},
+			expErr: "namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name: "unknown CAProvider type",
+			provider: esv1beta1.ConsulProvider{
+				Server:     "https://consul:8501",
+				CAProvider: &esv1beta1.CAProvider{Type: "Vault", Name: "consul-ca"},
+			},
+			expErr: `unknown caProvider type "Vault"`,
+		},
+		{
+			name: "missing CAProvider namespace on ClusterSecretStore",
+			kind: esv1beta1.ClusterSecretStoreKind,
+			provider: esv1beta1.ConsulProvider{
+				Server:     "https://consul:8501",
+				CAProvider: &esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeConfigMap, Name: "consul-ca"},
+			},
+			expErr: "missing namespace for consul-ca on kind ClusterSecretStore",
+		},
+		{
+			name: "valid",
+			provider: esv1beta1.ConsulProvider{
+				Server: "https://consul:8501",
+				Auth:   esv1beta1.ConsulAuth{TokenSecretRef: &esmeta.SecretKeySelector{Name: "consul", Key: "token"}},
+			},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			var store esv1beta1.GenericStore = &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Consul: &row.provider}},
+			}
+			if row.kind == esv1beta1.ClusterSecretStoreKind {
+				store = &esv1beta1.ClusterSecretStore{
+					TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+					Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Consul: &row.provider}},
+				}
+			}
+			err := (&Provider{}).ValidateStore(store)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func newTestClient(t *testing.T, kv *fake.KVServer, modify func(*esv1beta1.ConsulProvider)) esv1beta1.SecretsClient {
+	t.Helper()
+	server := httptest.NewServer(kv)
+	t.Cleanup(server.Close)
+	store := newStore(server.URL)
+	if modify != nil {
+		modify(store.Spec.Provider.Consul)
+	}
+	kube := clientfake.NewClientBuilder().WithObjects([]client.Object{tokenSecret()}...).Build()
+	c, err := (&Provider{}).NewClient(context.Background(), store, kube, testNamespace)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return c
+}
+
+func newStore(server string) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Consul: &esv1beta1.ConsulProvider{
+					Server: server,
+					Auth: esv1beta1.ConsulAuth{
+						TokenSecretRef: &esmeta.SecretKeySelector{Name: "consul", Key: "token"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func tokenSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "consul", Namespace: testNamespace},
+		Data:       map[string][]byte{"token": []byte(testToken + "\n")},
+	}
+}
+
+func assertSecretMap(t *testing.T, out map[string][]byte, expected map[string]string) {
+	t.Helper()
+	if len(out) != len(expected) {
+		t.Errorf("unexpected keys: %v", out)
+	}
+	for k, v := range expected {
+		if string(out[k]) != v {
+			t.Errorf("unexpected value of %s: '%s', expected: '%s'", k, out[k], v)
+		}
+	}
+}
+
+func ErrorContains(out error, want string) bool {
+	if out == nil {
+		return want == ""
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(out.Error(), want)
+}
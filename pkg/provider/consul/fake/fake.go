@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const tokenHeader = "X-Consul-Token"
+
+// KVServer is an http.Handler standing in for the /v1/kv endpoint of the Consul HTTP API.
+// Entries are scoped by namespace and partition, every request has to present the token.
+type KVServer struct {
+	token string
+
+	mu       sync.Mutex
+	entries  map[string][]byte
+	requests []*http.Request
+}
+
+type kvPair struct {
+	Key         string
+	Value       []byte
+	Flags       uint64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// NewKVServer returns a KV store that only accepts requests with the given ACL token.
+func NewKVServer(token string) *KVServer {
+	return &KVServer{
+		token:   token,
+		entries: make(map[string][]byte),
+	}
+}
+
+// Put stores the value in the default namespace and partition.
+func (s *KVServer) Put(key string, value []byte) {
+	s.PutScoped("", "", key, value)
+}
+
+// PutScoped stores the value in the namespace and partition.
+func (s *KVServer) PutScoped(namespace, partition, key string, value []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[scopedKey(namespace, partition, key)] = value
+}
+
+// Requests returns the requests received by the server.
+func (s *KVServer) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request{}, s.requests...)
+}
+
+func (s *KVServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+
+	if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, "/v1/kv/") {
+		http.Error(w, "unsupported request", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get(tokenHeader) != s.token {
+		http.Error(w, "Permission denied", http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	scope := scopedKey(query.Get("ns"), query.Get("partition"), "")
+	var pairs []kvPair
+	for k, v := range s.entries {
+		if !strings.HasPrefix(k, scope) {
+			continue
+		}
+		k = strings.TrimPrefix(k, scope)
+		if k == key || (query.Has("recurse") && strings.HasPrefix(k, key)) {
+			pairs = append(pairs, kvPair{Key: k, Value: v, CreateIndex: 1, ModifyIndex: 1})
+		}
+	}
+	if len(pairs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(pairs)
+}
+
+func scopedKey(namespace, partition, key string) string {
+	if namespace == "" {
+		namespace = "default"
+	}
+	if partition == "" {
+		partition = "default"
+	}
+	return partition + "\x00" + namespace + "\x00" + key
+}
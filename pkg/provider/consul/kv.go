@@ -0,0 +1,130 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errKVRequest    = "consul kv request for %q failed: %w"
+	errKVStatus     = "consul kv request for %q failed with status %d: %s"
+	errKVPermission = "permission denied reading %q from consul kv, check the ACL token"
+	errKVDecode     = "could not decode consul kv response for %q: %w"
+
+	headerToken = "X-Consul-Token"
+)
+
+// KVPair is an entry of the Consul KV store as returned by the /v1/kv endpoint.
+type KVPair struct {
+	Key         string
+	Value       []byte
+	Flags       uint64
+	ModifyIndex uint64
+}
+
+// KV reads entries of the Consul KV store.
+type KV interface {
+	// Get returns the entry of the key, or nil if the key does not exist.
+	Get(ctx context.Context, key string) (*KVPair, error)
+	// List returns all entries with keys starting with prefix.
+	List(ctx context.Context, prefix string) ([]*KVPair, error)
+}
+
+// kvClient implements KV with the Consul HTTP API.
+type kvClient struct {
+	client     *http.Client
+	server     string
+	token      string
+	namespace  string
+	partition  string
+	datacenter string
+}
+
+func (c *kvClient) Get(ctx context.Context, key string) (*KVPair, error) {
+	pairs, err := c.read(ctx, key, false)
+	if err != nil || len(pairs) == 0 {
+		return nil, err
+	}
+	return pairs[0], nil
+}
+
+func (c *kvClient) List(ctx context.Context, prefix string) ([]*KVPair, error) {
+	return c.read(ctx, prefix, true)
+}
+
+func (c *kvClient) read(ctx context.Context, key string, recurse bool) ([]*KVPair, error) {
+	query := url.Values{}
+	if recurse {
+		query.Set("recurse", "true")
+	}
+	if c.namespace != "" {
+		query.Set("ns", c.namespace)
+	}
+	if c.partition != "" {
+		query.Set("partition", c.partition)
+	}
+	if c.datacenter != "" {
+		query.Set("dc", c.datacenter)
+	}
+	reqURL := strings.TrimSuffix(c.server, "/") + "/v1/kv/" + escapeKey(key)
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf(errKVRequest, key, err)
+	}
+	if c.token != "" {
+		req.Header.Set(headerToken, c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errKVRequest, key, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf(errKVPermission, key)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf(errKVStatus, key, resp.StatusCode, utils.ReadErrorBody(resp.Body))
+	}
+	var pairs []*KVPair
+	if err := json.NewDecoder(resp.Body).Decode(&pairs); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf(errKVDecode, key, err)
+	}
+	return pairs, nil
+}
+
+// escapeKey escapes the segments of a key, keeping the separators.
+func escapeKey(key string) string {
+	segments := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
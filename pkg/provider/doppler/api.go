@@ -0,0 +1,128 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doppler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errAPIRequest = "doppler request for %q failed: %w"
+	errAPIStatus  = "doppler request for %q failed with status %d: %s"
+	errAPIDecode  = "could not decode doppler response for %q: %w"
+
+	defaultAPIURL = "https://api.doppler.com"
+)
+
+// apiClient talks to the v3 Doppler API. Project and config are only sent
+// when set, service tokens are scoped to a single config.
+type apiClient struct {
+	client  *http.Client
+	url     string
+	token   string
+	project string
+	config  string
+}
+
+type secretResponse struct {
+	Name  string `json:"name"`
+	Value struct {
+		Raw      string `json:"raw"`
+		Computed string `json:"computed"`
+	} `json:"value"`
+}
+
+type errorResponse struct {
+	Messages []string `json:"messages"`
+}
+
+// getSecret returns the computed value of the secret, with references to
+// other secrets resolved. ok is false if the secret does not exist.
+func (c *apiClient) getSecret(ctx context.Context, name string) (value string, ok bool, err error) {
+	query := c.query(c.config)
+	query.Set("name", name)
+	var resp secretResponse
+	ok, err = c.get(ctx, "/v3/configs/config/secret", query, &resp)
+	return resp.Value.Computed, ok, err
+}
+
+// downloadSecrets returns the computed values of all secrets of the config.
+// The store's config is used if config is empty. ok is false if the config
+// does not exist.
+func (c *apiClient) downloadSecrets(ctx context.Context, config string) (secrets map[string]string, ok bool, err error) {
+	if config == "" {
+		config = c.config
+	}
+	query := c.query(config)
+	query.Set("format", "json")
+	ok, err = c.get(ctx, "/v3/configs/config/secrets/download", query, &secrets)
+	return secrets, ok, err
+}
+
+func (c *apiClient) query(config string) url.Values {
+	query := url.Values{}
+	if c.project != "" {
+		query.Set("project", c.project)
+	}
+	if config != "" {
+		query.Set("config", config)
+	}
+	return query
+}
+
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, out interface{}) (bool, error) {
+	reqURL := strings.TrimSuffix(c.url, "/") + path + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return false, fmt.Errorf(errAPIRequest, path, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf(errAPIRequest, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf(errAPIStatus, path, resp.StatusCode, errorMessage(resp.Body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf(errAPIDecode, path, err)
+	}
+	return true, nil
+}
+
+// errorMessage returns the messages of a Doppler error response, or the raw body
+// if it is not one.
+func errorMessage(body io.Reader) string {
+	raw := utils.ReadErrorBody(body)
+	var resp errorResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err == nil && len(resp.Messages) > 0 {
+		return strings.Join(resp.Messages, ", ")
+	}
+	return raw
+}
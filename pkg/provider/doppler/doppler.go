@@ -0,0 +1,195 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doppler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingStore        = "missing store provider doppler"
+	errInvalidAPIURL       = "invalid doppler api url %q: %w"
+	errMissingToken        = "missing dopplerToken secret name or key"
+	errPropertyNotFound    = "property %s not found in secret %s"
+	errFindPathUnsupported = "doppler configs have no folders, find.path is not supported"
+	errFindTagsUnsupported = "doppler secrets have no tags, only find.name is supported"
+)
+
+var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.SecretsClient = &Client{}
+
+// Provider satisfies the provider interface.
+type Provider struct{}
+
+// Client reads the secrets of a Doppler config.
+type Client struct {
+	api             *apiClient
+	nameTransformer esv1beta1.SecretNameTransformer
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Doppler: &esv1beta1.DopplerProvider{},
+	})
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	token, err := utils.FetchSecretKey(ctx, kube, store.GetObjectKind().GroupVersionKind().Kind, namespace, &provider.Auth.SecretRef.DopplerToken)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := provider.APIURL
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+	return &Client{
+		api: &apiClient{
+			client:  &http.Client{},
+			url:     apiURL,
+			token:   strings.TrimSpace(string(token)),
+			project: provider.Project,
+			config:  provider.Config,
+		},
+		nameTransformer: provider.NameTransformer,
+	}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
+	provider, err := getProvider(store)
+	if err != nil {
+		return err
+	}
+	if provider.APIURL != "" {
+		if _, err := url.Parse(provider.APIURL); err != nil {
+			return fmt.Errorf(errInvalidAPIURL, provider.APIURL, err)
+		}
+	}
+	tokenRef := provider.Auth.SecretRef.DopplerToken
+	if tokenRef.Name == "" || tokenRef.Key == "" {
+		return errors.New(errMissingToken)
+	}
+	if err := utils.ValidateSecretSelector(store, tokenRef); err != nil {
+		return err
+	}
+	return utils.ValidateNameTransformer(provider.NameTransformer)
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.DopplerProvider, error) {
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Doppler == nil {
+		return nil, errors.New(errMissingStore)
+	}
+	return spc.Provider.Doppler, nil
+}
+
+// GetSecret returns the value of the secret named key. If property is set the
+// value is parsed as JSON and the property is resolved as gjson path.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, ok, err := c.api.getSecret(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	if ref.Property == "" {
+		return []byte(value), nil
+	}
+	val := gjson.Get(value, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns all secrets of the config named key, or of the store's
+// config if key is empty. The names are renamed with the store's name transformer.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	secrets, ok, err := c.api.downloadSecrets(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	return utils.TransformKeys(c.nameTransformer, toSecretMap(secrets, nil))
+}
+
+// GetAllSecrets returns the secrets of the store's config with names matching find.name.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path != nil {
+		return nil, errors.New(errFindPathUnsupported)
+	}
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errFindTagsUnsupported)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	secrets, ok, err := c.api.downloadSecrets(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	secretMap, err := utils.TransformKeys(c.nameTransformer, toSecretMap(secrets, matcher))
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
+
+// toSecretMap returns the secrets with names matching the matcher, or all secrets
+// if the matcher is nil.
+func toSecretMap(secrets map[string]string, matcher *find.Matcher) map[string][]byte {
+	secretMap := make(map[string][]byte, len(secrets))
+	for name, value := range secrets {
+		if matcher != nil && !matcher.MatchName(name) {
+			continue
+		}
+		secretMap[name] = []byte(value)
+	}
+	return secretMap
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	return nil
+}
+
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultUnknown, nil
+}
@@ -0,0 +1,329 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doppler
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/doppler/fake"
+)
+
+const (
+	testNamespace = "default"
+	testToken     = "dp.st.prd.token"
+)
+
+func TestGetSecret(t *testing.T) {
+	api := fake.NewServer(testToken, "backend", "prd")
+	api.Put("backend", "prd", "DB_PASSWORD", "s3cr3t")
+	api.Put("backend", "prd", "DB_CONFIG", `{"user":"admin","port":5432}`)
+	server := httptest.NewServer(api)
+	defer server.Close()
+	c := &Client{api: &apiClient{client: server.Client(), url: server.URL, token: testToken}}
+
+	tbl := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "value",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_PASSWORD"},
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_CONFIG", Property: "port"},
+			expValue: "5432",
+		},
+		{
+			name:   "missing property",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_CONFIG", Property: "password"},
+			expErr: fmt.Sprintf(errPropertyNotFound, "password", "DB_CONFIG"),
+		},
+		{
+			name:   "missing secret",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_USER"},
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecret(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+
+	// service tokens are scoped to a config, project and config are not sent
+	query := api.Requests()[0].URL.Query()
+	if query.Has("project") || query.Has("config") {
+		t.Errorf("unexpected project or config for service token: %v", query)
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	api := fake.NewServer(testToken, "", "")
+	api.Put("backend", "prd", "DB_USER", "admin")
+	api.Put("backend", "prd", "DB_PASSWORD", "s3cr3t")
+	api.Put("backend", "stg", "DB_USER", "staging")
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	tbl := []struct {
+		name        string
+		key         string
+		transformer esv1beta1.SecretNameTransformer
+		expValue    map[string][]byte
+		expErr      string
+	}{
+		{
+			name:     "store config",
+			expValue: map[string][]byte{"DB_USER": []byte("admin"), "DB_PASSWORD": []byte("s3cr3t")},
+		},
+		{
+			name:     "other config",
+			key:      "stg",
+			expValue: map[string][]byte{"DB_USER": []byte("staging")},
+		},
+		{
+			name:        "lower-kebab",
+			transformer: esv1beta1.SecretNameTransformerLowerKebab,
+			expValue:    map[string][]byte{"db-user": []byte("admin"), "db-password": []byte("s3cr3t")},
+		},
+		{
+			name:        "camel",
+			transformer: esv1beta1.SecretNameTransformerCamel,
+			expValue:    map[string][]byte{"dbUser": []byte("admin"), "dbPassword": []byte("s3cr3t")},
+		},
+		{
+			name:   "missing config",
+			key:    "dev",
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			c := &Client{
+				api:             &apiClient{client: server.Client(), url: server.URL, token: testToken, project: "backend", config: "prd"},
+				nameTransformer: row.transformer,
+			}
+			out, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: row.key})
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expValue) {
+				t.Errorf("unexpected secret map: %v, expected: %v", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	api := fake.NewServer(testToken, "backend", "prd")
+	api.Put("backend", "prd", "DB_USER", "admin")
+	api.Put("backend", "prd", "DB_PASSWORD", "s3cr3t")
+	api.Put("backend", "prd", "API_TOKEN", "t0ken")
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	path := "/backend"
+	tbl := []struct {
+		name        string
+		ref         esv1beta1.ExternalSecretFind
+		transformer esv1beta1.SecretNameTransformer
+		expValue    map[string][]byte
+		expErr      string
+	}{
+		{
+			name:     "find by name",
+			ref:      esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^DB_"}},
+			expValue: map[string][]byte{"DB_USER": []byte("admin"), "DB_PASSWORD": []byte("s3cr3t")},
+		},
+		{
+			name:        "find by name with transformer",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^DB_"}},
+			transformer: esv1beta1.SecretNameTransformerTFVar,
+			expValue:    map[string][]byte{"TF_VAR_db_user": []byte("admin"), "TF_VAR_db_password": []byte("s3cr3t")},
+		},
+		{
+			name:     "find all",
+			ref:      esv1beta1.ExternalSecretFind{},
+			expValue: map[string][]byte{"DB_USER": []byte("admin"), "DB_PASSWORD": []byte("s3cr3t"), "API_TOKEN": []byte("t0ken")},
+		},
+		{
+			name:   "find by path",
+			ref:    esv1beta1.ExternalSecretFind{Path: &path},
+			expErr: errFindPathUnsupported,
+		},
+		{
+			name:   "find by tags",
+			ref:    esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expErr: errFindTagsUnsupported,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			c := &Client{
+				api:             &apiClient{client: server.Client(), url: server.URL, token: testToken},
+				nameTransformer: row.transformer,
+			}
+			row.ref.ConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+			out, err := c.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expValue) {
+				t.Errorf("unexpected secrets: %v, expected: %v", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	api := fake.NewServer(testToken, "backend", "prd")
+	api.Put("backend", "prd", "DB_PASSWORD", "s3cr3t")
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	tbl := []struct {
+		name   string
+		token  string
+		expErr string
+	}{
+		{
+			name:  "token with trailing newline",
+			token: testToken + "\n",
+		},
+		{
+			name:   "invalid token",
+			token:  "other-token",
+			expErr: "failed with status 401: Invalid Auth token",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "doppler", Namespace: testNamespace},
+				Data:       map[string][]byte{"token": []byte(row.token)},
+			}).Build()
+			store := &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Doppler: &esv1beta1.DopplerProvider{
+					APIURL: server.URL,
+					Auth:   esv1beta1.DopplerAuth{SecretRef: esv1beta1.DopplerAuthSecretRef{DopplerToken: esmeta.SecretKeySelector{Name: "doppler", Key: "token"}}},
+				}}},
+			}
+			c, err := (&Provider{}).NewClient(context.Background(), store, kube, testNamespace)
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "DB_PASSWORD"})
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	namespace := testNamespace
+	auth := esv1beta1.DopplerAuth{SecretRef: esv1beta1.DopplerAuthSecretRef{
+		DopplerToken: esmeta.SecretKeySelector{Name: "doppler", Key: "token"},
+	}}
+	namespacedAuth := esv1beta1.DopplerAuth{SecretRef: esv1beta1.DopplerAuthSecretRef{
+		DopplerToken: esmeta.SecretKeySelector{Name: "doppler", Key: "token", Namespace: &namespace},
+	}}
+	tbl := []struct {
+		name     string
+		kind     string
+		provider esv1beta1.DopplerProvider
+		expErr   string
+	}{
+		{
+			name:     "missing token",
+			provider: esv1beta1.DopplerProvider{},
+			expErr:   errMissingToken,
+		},
+		{
+			name:     "invalid api url",
+			provider: esv1beta1.DopplerProvider{APIURL: "http://doppler:port", Auth: auth},
+			expErr:   "invalid doppler api url",
+		},
+		{
+			name:     "namespace on SecretStore token",
+			provider: esv1beta1.DopplerProvider{Auth: namespacedAuth},
+			expErr:   "namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name:     "missing namespace on ClusterSecretStore token",
+			kind:     esv1beta1.ClusterSecretStoreKind,
+			provider: esv1beta1.DopplerProvider{Auth: auth},
+			expErr:   "cluster scope requires namespace",
+		},
+		{
+			name:     "unknown name transformer",
+			provider: esv1beta1.DopplerProvider{NameTransformer: "screaming", Auth: auth},
+			expErr:   `unknown name transformer "screaming"`,
+		},
+		{
+			name:     "valid",
+			provider: esv1beta1.DopplerProvider{Project: "backend", Config: "prd", Auth: auth},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			var store esv1beta1.GenericStore = &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Doppler: &row.provider}},
+			}
+			if row.kind == esv1beta1.ClusterSecretStoreKind {
+				store = &esv1beta1.ClusterSecretStore{
+					TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+					Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Doppler: &row.provider}},
+				}
+			}
+			err := (&Provider{}).ValidateStore(store)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func ErrorContains(out error, want string) bool {
+	if out == nil {
+		return want == ""
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(out.Error(), want)
+}
@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Server is an http.Handler standing in for the Doppler API. It accepts a single
+// token, which is scoped to a project and config like a service token: requests
+// without project or config read from that config.
+type Server struct {
+	token   string
+	project string
+	config  string
+
+	mu       sync.Mutex
+	secrets  map[string]map[string]string
+	requests []*http.Request
+}
+
+// NewServer returns a Doppler API accepting the token, scoped to the project and config.
+func NewServer(token, project, config string) *Server {
+	return &Server{
+		token:   token,
+		project: project,
+		config:  config,
+		secrets: make(map[string]map[string]string),
+	}
+}
+
+// Put stores the secret in the config of the project.
+func (s *Server) Put(project, config, name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := project + "/" + config
+	if s.secrets[key] == nil {
+		s.secrets[key] = make(map[string]string)
+	}
+	s.secrets[key][name] = value
+}
+
+// Requests returns the requests received by the server.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request{}, s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, "Invalid Auth token")
+		return
+	}
+	query := r.URL.Query()
+	project, config := query.Get("project"), query.Get("config")
+	if project == "" {
+		project = s.project
+	}
+	if config == "" {
+		config = s.config
+	}
+	secrets, ok := s.secrets[project+"/"+config]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Could not find requested config '"+config+"'")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/configs/config/secret":
+		name := query.Get("name")
+		value, ok := secrets[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Could not find requested secret '"+name+"'")
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"name":  name,
+			"value": map[string]string{"raw": value, "computed": value},
+		})
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/configs/config/secrets/download":
+		if query.Get("format") != "json" {
+			writeError(w, http.StatusBadRequest, "unsupported format")
+			return
+		}
+		writeJSON(w, secrets)
+	default:
+		writeError(w, http.StatusNotFound, "unsupported request "+strings.TrimPrefix(r.URL.Path, "/"))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"messages": []string{message},
+		"success":  false,
+	})
+}
@@ -16,11 +16,18 @@ package fake
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 var (
@@ -28,10 +35,54 @@ var (
 	errMissingFakeProvider = fmt.Errorf("missing store provider fake")
 	errMissingKeyField     = "key must be set in data %v"
 	errMissingValueField   = "at least one of value or valueMap must be set in data %v"
+	errInvalidFailureMode  = "invalid failure mode %q in data %v"
+	errNegativeAfterCalls  = "failure.afterCalls must not be negative in data %v"
+	errNegativeLatency     = "latency must not be negative"
+	errAuth                = "permission denied for key %s"
+	errTimeout             = "timeout requesting key %s: %w"
 )
 
+// callCounter counts the requests per store and key. It is owned by the registered
+// provider, so the counts outlive the clients which are created for every reconciliation.
+// Only the counts of the latest generation of a store are kept.
+type callCounter struct {
+	mu     sync.Mutex
+	stores map[string]*storeCalls
+}
+
+type storeCalls struct {
+	generation int64
+	counts     map[callKey]int
+}
+
+type callKey struct {
+	key     string
+	version string
+}
+
+// inc counts a request and returns the number of requests made before it.
+// A new generation of the store drops the counts of the previous generations.
+func (c *callCounter) inc(store string, generation int64, key callKey) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stores == nil {
+		c.stores = make(map[string]*storeCalls)
+	}
+	calls, ok := c.stores[store]
+	if !ok || generation > calls.generation {
+		calls = &storeCalls{generation: generation, counts: make(map[callKey]int)}
+		c.stores[store] = calls
+	}
+	n := calls.counts[key]
+	calls.counts[key] = n + 1
+	return n
+}
+
 type Provider struct {
-	config *esv1beta1.FakeProvider
+	config     *esv1beta1.FakeProvider
+	store      string
+	generation int64
+	calls      *callCounter
 }
 
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
@@ -39,8 +90,16 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	if err != nil {
 		return nil, err
 	}
+	// the registered provider owns the counter, others like in tests get their own
+	if p.calls == nil {
+		p.calls = &callCounter{}
+	}
+	meta := store.GetObjectMeta()
 	return &Provider{
-		config: cfg,
+		config:     cfg,
+		store:      fmt.Sprintf("%s/%s/%s", store.GetObjectKind().GroupVersionKind().Kind, meta.Namespace, meta.Name),
+		generation: meta.Generation,
+		calls:      p.calls,
 	}, nil
 }
 
@@ -55,16 +114,61 @@ func getProvider(store esv1beta1.GenericStore) (*esv1beta1.FakeProvider, error)
 	return spc.Provider.Fake, nil
 }
 
-// Empty GetAllSecrets.
+// GetAllSecrets returns the unversioned data matching the given name, path prefix and tags.
 func (p *Provider) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not implemented")
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+
+	secretMap := make(map[string][]byte)
+	for i := range p.config.Data {
+		data := &p.config.Data[i]
+		if data.Version != "" {
+			continue
+		}
+		if ref.Path != nil && !strings.HasPrefix(data.Key, *ref.Path) {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(data.Key) {
+			continue
+		}
+		if !hasTags(data.Tags, ref.Tags) {
+			continue
+		}
+		if err := p.fail(data); err != nil {
+			if errors.Is(err, esv1beta1.NoSecretErr) {
+				continue
+			}
+			return nil, err
+		}
+		value, err := dataValue(data)
+		if err != nil {
+			return nil, err
+		}
+		secretMap[data.Key] = value
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
 }
 
 // GetSecret returns a single secret from the provider.
 func (p *Provider) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	for _, data := range p.config.Data {
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+	for i := range p.config.Data {
+		data := &p.config.Data[i]
 		if data.Key == ref.Key && data.Version == ref.Version {
+			if err := p.fail(data); err != nil {
+				return nil, err
+			}
 			return []byte(data.Value), nil
 		}
 	}
@@ -73,15 +177,74 @@ func (p *Provider) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDa
 
 // GetSecretMap returns multiple k/v pairs from the provider.
 func (p *Provider) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
-	for _, data := range p.config.Data {
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+	for i := range p.config.Data {
+		data := &p.config.Data[i]
 		if data.Key != ref.Key || data.Version != ref.Version || data.ValueMap == nil {
 			continue
 		}
+		if err := p.fail(data); err != nil {
+			return nil, err
+		}
 		return convertMap(data.ValueMap), nil
 	}
 	return nil, esv1beta1.NoSecretErr
 }
 
+// wait simulates the configured latency.
+func (p *Provider) wait(ctx context.Context) error {
+	if p.config.Latency == nil || p.config.Latency.Duration <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(p.config.Latency.Duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// fail counts a request for the data and returns the configured failure
+// once the number of successful requests is exhausted.
+func (p *Provider) fail(data *esv1beta1.FakeProviderData) error {
+	if data.Failure == nil {
+		return nil
+	}
+	if p.calls.inc(p.store, p.generation, callKey{data.Key, data.Version}) < data.Failure.AfterCalls {
+		return nil
+	}
+	switch data.Failure.Mode {
+	case esv1beta1.FakeProviderFailureNotFound:
+		return esv1beta1.NoSecretErr
+	case esv1beta1.FakeProviderFailureAuthError:
+		return fmt.Errorf(errAuth, data.Key)
+	case esv1beta1.FakeProviderFailureTimeout:
+		return fmt.Errorf(errTimeout, data.Key, context.DeadlineExceeded)
+	}
+	return nil
+}
+
+// dataValue returns the value of the data, or its valueMap as JSON.
+func dataValue(data *esv1beta1.FakeProviderData) ([]byte, error) {
+	if data.Value != "" || data.ValueMap == nil {
+		return []byte(data.Value), nil
+	}
+	return json.Marshal(data.ValueMap)
+}
+
+func hasTags(dataTags, tags map[string]string) bool {
+	for k, v := range tags {
+		if val, ok := dataTags[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
 func convertMap(in map[string]string) map[string][]byte {
 	m := make(map[string][]byte)
 	for k, v := range in {
@@ -103,6 +266,9 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
 	if prov == nil {
 		return nil
 	}
+	if prov.Latency != nil && prov.Latency.Duration < 0 {
+		return fmt.Errorf(errNegativeLatency)
+	}
 	for pos, data := range prov.Data {
 		if data.Key == "" {
 			return fmt.Errorf(errMissingKeyField, pos)
@@ -110,12 +276,23 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
 		if data.Value == "" && data.ValueMap == nil {
 			return fmt.Errorf(errMissingValueField, pos)
 		}
+		if data.Failure == nil {
+			continue
+		}
+		switch data.Failure.Mode {
+		case esv1beta1.FakeProviderFailureNotFound, esv1beta1.FakeProviderFailureAuthError, esv1beta1.FakeProviderFailureTimeout:
+		default:
+			return fmt.Errorf(errInvalidFailureMode, data.Failure.Mode, pos)
+		}
+		if data.Failure.AfterCalls < 0 {
+			return fmt.Errorf(errNegativeAfterCalls, pos)
+		}
 	}
 	return nil
 }
 
 func init() {
-	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+	esv1beta1.Register(&Provider{calls: &callCounter{}}, &esv1beta1.SecretStoreProvider{
 		Fake: &esv1beta1.FakeProvider{},
 	})
 }
@@ -15,10 +15,13 @@ package fake
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
@@ -68,6 +71,22 @@ func TestValidateStore(t *testing.T) {
 	store.Spec.Provider.Fake.Data = []esv1beta1.FakeProviderData{data}
 	err = p.ValidateStore(store)
 	gomega.Expect(err).To(gomega.BeNil())
+	// invalid failure mode
+	data.Failure = &esv1beta1.FakeProviderFailure{Mode: "Crash"}
+	store.Spec.Provider.Fake.Data = []esv1beta1.FakeProviderData{data}
+	err = p.ValidateStore(store)
+	gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf(errInvalidFailureMode, "Crash", 0)))
+	// negative afterCalls
+	data.Failure = &esv1beta1.FakeProviderFailure{Mode: esv1beta1.FakeProviderFailureTimeout, AfterCalls: -1}
+	store.Spec.Provider.Fake.Data = []esv1beta1.FakeProviderData{data}
+	err = p.ValidateStore(store)
+	gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf(errNegativeAfterCalls, 0)))
+	// negative latency
+	data.Failure = nil
+	store.Spec.Provider.Fake.Data = []esv1beta1.FakeProviderData{data}
+	store.Spec.Provider.Fake.Latency = &metav1.Duration{Duration: -time.Second}
+	err = p.ValidateStore(store)
+	gomega.Expect(err).To(gomega.MatchError(errNegativeLatency))
 }
 func TestClose(t *testing.T) {
 	p := &Provider{}
@@ -226,3 +245,180 @@ func TestGetSecretMap(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllSecrets(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	input := []esv1beta1.FakeProviderData{
+		{
+			Key:   "/app/db/user",
+			Value: "admin",
+			Tags:  map[string]string{"env": "prod"},
+		},
+		{
+			Key:   "/app/db/password",
+			Value: "secret",
+			Tags:  map[string]string{"env": "dev"},
+		},
+		{
+			Key:     "/app/db/password",
+			Value:   "old-secret",
+			Version: "v1",
+			Tags:    map[string]string{"env": "dev"},
+		},
+		{
+			Key:      "/app/config",
+			ValueMap: map[string]string{"debug": "true"},
+			Tags:     map[string]string{"env": "prod"},
+		},
+		{
+			Key:   "/other/token",
+			Value: "token",
+		},
+	}
+	path := "/app/db"
+	tbl := []struct {
+		name     string
+		request  esv1beta1.ExternalSecretFind
+		expValue map[string][]byte
+	}{
+		{
+			name:    "find by name",
+			request: esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "token$"}},
+			expValue: map[string][]byte{
+				"_other_token": []byte("token"),
+			},
+		},
+		{
+			name:    "find by path",
+			request: esv1beta1.ExternalSecretFind{Path: &path},
+			expValue: map[string][]byte{
+				"_app_db_user":     []byte("admin"),
+				"_app_db_password": []byte("secret"),
+			},
+		},
+		{
+			name:    "find by tags",
+			request: esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expValue: map[string][]byte{
+				"_app_db_user": []byte("admin"),
+				"_app_config":  []byte(`{"debug":"true"}`),
+			},
+		},
+		{
+			name:     "find by path and tags",
+			request:  esv1beta1.ExternalSecretFind{Path: &path, Tags: map[string]string{"env": "staging"}},
+			expValue: map[string][]byte{},
+		},
+	}
+
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			cl, err := p.NewClient(context.Background(), newStore(input, nil), nil, "")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			row.request.ConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+			out, err := cl.GetAllSecrets(context.Background(), row.request)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(out).To(gomega.Equal(row.expValue))
+		})
+	}
+}
+
+func TestFailures(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	input := []esv1beta1.FakeProviderData{
+		{
+			Key:     "/not-found",
+			Value:   "value",
+			Failure: &esv1beta1.FakeProviderFailure{Mode: esv1beta1.FakeProviderFailureNotFound},
+		},
+		{
+			Key:      "/auth",
+			ValueMap: map[string]string{"foo": "bar"},
+			Failure:  &esv1beta1.FakeProviderFailure{Mode: esv1beta1.FakeProviderFailureAuthError},
+		},
+		{
+			Key:     "/timeout",
+			Value:   "value",
+			Failure: &esv1beta1.FakeProviderFailure{Mode: esv1beta1.FakeProviderFailureTimeout, AfterCalls: 2},
+		},
+	}
+	store := newStore(input, nil)
+	store.Name = "failures"
+	cl, err := p.NewClient(context.Background(), store, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	_, err = cl.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/not-found"})
+	gomega.Expect(err).To(gomega.MatchError(esv1beta1.NoSecretErr))
+
+	_, err = cl.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/auth"})
+	gomega.Expect(err).To(gomega.MatchError(fmt.Sprintf(errAuth, "/auth")))
+
+	// the first two calls succeed, also across clients of the same store
+	for i := 0; i < 2; i++ {
+		cl, err := p.NewClient(context.Background(), store, nil, "")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		out, err := cl.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/timeout"})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(string(out)).To(gomega.Equal("value"))
+	}
+	_, err = cl.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/timeout"})
+	gomega.Expect(errors.Is(err, context.DeadlineExceeded)).To(gomega.BeTrue())
+
+	// a not found key is left out of find, other failures are returned
+	_, err = cl.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "not-found|timeout"}})
+	gomega.Expect(errors.Is(err, context.DeadlineExceeded)).To(gomega.BeTrue())
+
+	// a new generation of the store resets the count
+	store.Generation++
+	cl, err = p.NewClient(context.Background(), store, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	out, err := cl.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/timeout"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(string(out)).To(gomega.Equal("value"))
+
+	// only the counts of the latest generation are kept
+	gomega.Expect(p.calls.stores).To(gomega.HaveLen(1))
+	for _, calls := range p.calls.stores {
+		gomega.Expect(calls.generation).To(gomega.Equal(store.Generation))
+		gomega.Expect(calls.counts).To(gomega.Equal(map[callKey]int{{key: "/timeout"}: 1}))
+	}
+}
+
+func TestLatency(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	input := []esv1beta1.FakeProviderData{
+		{
+			Key:   "/foo",
+			Value: "bar",
+		},
+	}
+	cl, err := p.NewClient(context.Background(), newStore(input, &metav1.Duration{Duration: 50 * time.Millisecond}), nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	start := time.Now()
+	out, err := cl.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "/foo"})
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(string(out)).To(gomega.Equal("bar"))
+	gomega.Expect(time.Since(start)).To(gomega.BeNumerically(">=", 50*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cl.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "/foo"})
+	gomega.Expect(err).To(gomega.MatchError(context.Canceled))
+}
+
+func newStore(data []esv1beta1.FakeProviderData, latency *metav1.Duration) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Fake: &esv1beta1.FakeProvider{
+					Data:    data,
+					Latency: latency,
+				},
+			},
+		},
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 type MockSMClient struct {
 	accessSecretFn func(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+	getSecretFn    func(ctx context.Context, req *secretmanagerpb.GetSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error)
 	ListSecretsFn  func(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) *secretmanager.SecretIterator
 	closeFn        func() error
 }
@@ -34,6 +35,10 @@ func (mc *MockSMClient) AccessSecretVersion(ctx context.Context, req *secretmana
 	return mc.accessSecretFn(ctx, req)
 }
 
+func (mc *MockSMClient) GetSecret(ctx context.Context, req *secretmanagerpb.GetSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error) {
+	return mc.getSecretFn(ctx, req)
+}
+
 func (mc *MockSMClient) ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) *secretmanager.SecretIterator {
 	return mc.ListSecretsFn(ctx, req)
 }
@@ -59,3 +64,14 @@ func (mc *MockSMClient) WithValue(ctx context.Context, req *secretmanagerpb.Acce
 		}
 	}
 }
+
+func (mc *MockSMClient) WithSecret(req *secretmanagerpb.GetSecretRequest, val *secretmanagerpb.Secret, err error) {
+	if mc != nil {
+		mc.getSecretFn = func(paramCtx context.Context, paramReq *secretmanagerpb.GetSecretRequest, paramOpts ...gax.CallOption) (*secretmanagerpb.Secret, error) {
+			if !cmp.Equal(paramReq, req, cmpopts.IgnoreUnexported(secretmanagerpb.GetSecretRequest{})) {
+				return nil, fmt.Errorf("unexpected test argument")
+			}
+			return val, err
+		}
+	}
+}
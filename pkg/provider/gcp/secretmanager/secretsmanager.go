@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -64,12 +65,19 @@ const (
 	errInvalidAuthSecretRef   = "invalid auth secret ref: %w"
 	errInvalidWISARef         = "invalid workload identity service account reference: %w"
 	errUnexpectedFindOperator = "unexpected find operator"
+	errClientGetSecret        = "unable to get Secret metadata from SecretManager Client: %w"
+	errMetadataNotExist       = "metadata %s does not exist in secret %s"
+
+	regionalEndpoint = "secretmanager.%s.rep.googleapis.com:443"
+
+	metadataAnnotationPrefix = "annotations."
 )
 
 var log = ctrl.Log.WithName("provider").WithName("gcp").WithName("secretsmanager")
 
 type GoogleSecretManagerClient interface {
 	AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+	GetSecret(ctx context.Context, req *secretmanagerpb.GetSecretRequest, opts ...gax.CallOption) (*secretmanagerpb.Secret, error)
 	ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) *secretmanager.SecretIterator
 	Close() error
 }
@@ -90,6 +98,7 @@ var _ esv1beta1.Provider = &ProviderGCP{}
 // ProviderGCP is a provider for GCP Secret Manager.
 type ProviderGCP struct {
 	projectID           string
+	location            string
 	SecretManagerClient GoogleSecretManagerClient
 	gClient             *gClient
 }
@@ -191,6 +200,7 @@ func (sm *ProviderGCP) NewClient(ctx context.Context, store esv1beta1.GenericSto
 	}()
 
 	sm.projectID = cliStore.store.ProjectID
+	sm.location = cliStore.store.Location
 
 	ts, err := cliStore.getTokenSource(ctx, store, kube, namespace)
 	if err != nil {
@@ -205,7 +215,11 @@ func (sm *ProviderGCP) NewClient(ctx context.Context, store esv1beta1.GenericSto
 		return nil, fmt.Errorf(errUnableGetCredentials, err)
 	}
 
-	clientGCPSM, err := secretmanager.NewClient(ctx, option.WithTokenSource(ts))
+	opts := []option.ClientOption{option.WithTokenSource(ts)}
+	if sm.location != "" {
+		opts = append(opts, option.WithEndpoint(fmt.Sprintf(regionalEndpoint, sm.location)))
+	}
+	clientGCPSM, err := secretmanager.NewClient(ctx, opts...)
 	if err != nil {
 		useMu.Unlock()
 		return nil, fmt.Errorf(errUnableCreateGCPSMClient, err)
@@ -232,7 +246,7 @@ func (sm *ProviderGCP) findByName(ctx context.Context, ref esv1beta1.ExternalSec
 		return nil, err
 	}
 	req := &secretmanagerpb.ListSecretsRequest{
-		Parent: fmt.Sprintf("projects/%s", sm.projectID),
+		Parent: sm.parent(),
 	}
 	if ref.Path != nil {
 		req.Filter = fmt.Sprintf("name:%s", *ref.Path)
@@ -280,20 +294,31 @@ func (sm *ProviderGCP) getData(ctx context.Context, key string) ([]byte, error)
 	return data, nil
 }
 
-func (sm *ProviderGCP) findByTags(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	var tagFilter string
-	for k, v := range ref.Tags {
-		tagFilter = fmt.Sprintf("%slabels.%s=%s ", tagFilter, k, v)
+// labelFilter builds a ListSecrets filter that matches all given labels
+// and optionally the path, see https://cloud.google.com/secret-manager/docs/filtering.
+func labelFilter(tags map[string]string, path *string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
 	}
-	tagFilter = strings.TrimSuffix(tagFilter, " ")
-	if ref.Path != nil {
-		tagFilter = fmt.Sprintf("%s name:%s", tagFilter, *ref.Path)
+	sort.Strings(keys)
+	terms := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		terms = append(terms, fmt.Sprintf("labels.%s=%s", k, strconv.Quote(tags[k])))
 	}
+	if path != nil {
+		terms = append(terms, fmt.Sprintf("name:%s", *path))
+	}
+	return strings.Join(terms, " AND ")
+}
+
+func (sm *ProviderGCP) findByTags(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	tagFilter := labelFilter(ref.Tags, ref.Path)
 	req := &secretmanagerpb.ListSecretsRequest{
-		Parent: fmt.Sprintf("projects/%s", sm.projectID),
+		Parent: sm.parent(),
+		Filter: tagFilter,
 	}
 	log.V(1).Info("gcp sm findByTags", "tagFilter", tagFilter)
-	req.Filter = tagFilter
 	// Call the API.
 	it := sm.SecretManagerClient.ListSecrets(ctx, req)
 	secretMap := make(map[string][]byte)
@@ -321,10 +346,46 @@ func (sm *ProviderGCP) findByTags(ctx context.Context, ref esv1beta1.ExternalSec
 
 func (sm *ProviderGCP) trimName(name string) string {
 	projectIDNumuber := sm.extractProjectIDNumber(name)
+	if sm.location != "" {
+		return strings.TrimPrefix(name, fmt.Sprintf("projects/%s/locations/%s/secrets/", projectIDNumuber, sm.location))
+	}
 	key := strings.TrimPrefix(name, fmt.Sprintf("projects/%s/secrets/", projectIDNumuber))
 	return key
 }
 
+// parent returns the parent resource of all secrets, which
+// is either the project or a location within the project.
+func (sm *ProviderGCP) parent() string {
+	if sm.location != "" {
+		return fmt.Sprintf("projects/%s/locations/%s", sm.projectID, sm.location)
+	}
+	return fmt.Sprintf("projects/%s", sm.projectID)
+}
+
+func (sm *ProviderGCP) secretName(key string) string {
+	return fmt.Sprintf("%s/secrets/%s", sm.parent(), key)
+}
+
+// getSecretMetadata returns the labels and annotations of the secret referenced by ref
+// as flat map. Labels keep their key, annotations are prefixed with "annotations.".
+// Label keys cannot contain dots, so the keys of both never collide.
+func (sm *ProviderGCP) getSecretMetadata(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string]string, error) {
+	secret, err := sm.SecretManagerClient.GetSecret(ctx, &secretmanagerpb.GetSecretRequest{
+		Name: sm.secretName(ref.Key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errClientGetSecret, err)
+	}
+	metadata := make(map[string]string, len(secret.GetLabels())+len(secret.GetAnnotations()))
+	for k, v := range secret.GetLabels() {
+		metadata[k] = v
+	}
+	for k, v := range secret.GetAnnotations() {
+		metadata[metadataAnnotationPrefix+k] = v
+	}
+	return metadata, nil
+}
+
 // extractProjectIDNumber grabs the project id from the full name returned by gcp api
 // gcp api seems to always return the number and not the project name
 // (and users would always use the name, while requests accept both).
@@ -340,13 +401,28 @@ func (sm *ProviderGCP) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecr
 		return nil, fmt.Errorf(errUninitalizedGCPProvider)
 	}
 
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		metadata, err := sm.getSecretMetadata(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Property == "" {
+			return json.Marshal(metadata)
+		}
+		if val, ok := metadata[ref.Property]; ok {
+			return []byte(val), nil
+		}
+		return nil, fmt.Errorf(errMetadataNotExist, ref.Property, ref.Key)
+	}
+
+	// version is either a version number, latest or a version alias
 	version := ref.Version
 	if version == "" {
 		version = defaultVersion
 	}
 
 	req := &secretmanagerpb.AccessSecretVersionRequest{
-		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", sm.projectID, ref.Key, version),
+		Name: fmt.Sprintf("%s/versions/%s", sm.secretName(ref.Key), version),
 	}
 	result, err := sm.SecretManagerClient.AccessSecretVersion(ctx, req)
 	if err != nil {
@@ -386,6 +462,18 @@ func (sm *ProviderGCP) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalS
 		return nil, fmt.Errorf(errUninitalizedGCPProvider)
 	}
 
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		metadata, err := sm.getSecretMetadata(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		secretData := make(map[string][]byte, len(metadata))
+		for k, v := range metadata {
+			secretData[k] = []byte(v)
+		}
+		return secretData, nil
+	}
+
 	data, err := sm.GetSecret(ctx, ref)
 	if err != nil {
 		return nil, err
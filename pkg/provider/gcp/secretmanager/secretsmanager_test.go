@@ -34,6 +34,7 @@ type secretManagerTestCase struct {
 	apiOutput      *secretmanagerpb.AccessSecretVersionResponse
 	ref            *esv1beta1.ExternalSecretDataRemoteRef
 	projectID      string
+	location       string
 	apiErr         error
 	expectError    string
 	expectedSecret string
@@ -157,10 +158,20 @@ func TestSecretManagerGetSecret(t *testing.T) {
 		smtc.expectedSecret = "FOOBA!"
 	}
 
+	// good case: version alias of a regional secret
+	setRegionalVersionAlias := func(smtc *secretManagerTestCase) {
+		smtc.location = "europe-west1"
+		smtc.ref.Version = "stable"
+		smtc.apiInput.Name = "projects/default/locations/europe-west1/secrets//baz/versions/stable"
+		smtc.apiOutput.Payload.Data = []byte("FOOBA!")
+		smtc.expectedSecret = "FOOBA!"
+	}
+
 	successCases := []*secretManagerTestCase{
 		makeValidSecretManagerTestCase(),
 		makeValidSecretManagerTestCaseCustom(setSecretString),
 		makeValidSecretManagerTestCaseCustom(setCustomVersion),
+		makeValidSecretManagerTestCaseCustom(setRegionalVersionAlias),
 		makeValidSecretManagerTestCaseCustom(setAPIErr),
 		makeValidSecretManagerTestCaseCustom(setCustomRef),
 		makeValidSecretManagerTestCaseCustom(setDotRef),
@@ -170,6 +181,7 @@ func TestSecretManagerGetSecret(t *testing.T) {
 	sm := ProviderGCP{}
 	for k, v := range successCases {
 		sm.projectID = v.projectID
+		sm.location = v.location
 		sm.SecretManagerClient = v.mockClient
 		out, err := sm.GetSecret(context.Background(), *v.ref)
 		if !ErrorContains(err, v.expectError) {
@@ -223,6 +235,94 @@ func TestGetSecretMap(t *testing.T) {
 	}
 }
 
+func TestGetSecretMetadata(t *testing.T) {
+	mockClient := &fakesm.MockSMClient{}
+	mockClient.WithSecret(&secretmanagerpb.GetSecretRequest{
+		Name: "projects/default/locations/europe-west1/secrets/foo",
+	}, &secretmanagerpb.Secret{
+		Labels: map[string]string{
+			"owner": "team-a",
+		},
+		Annotations: map[string]string{
+			"rotation": "monthly",
+		},
+	}, nil)
+	sm := ProviderGCP{
+		projectID:           "default",
+		location:            "europe-west1",
+		SecretManagerClient: mockClient,
+	}
+
+	tests := []struct {
+		property string
+		want     string
+		wantErr  string
+	}{
+		{property: "", want: `{"annotations.rotation":"monthly","owner":"team-a"}`},
+		{property: "owner", want: "team-a"},
+		{property: "annotations.rotation", want: "monthly"},
+		{property: "rotation", wantErr: "metadata rotation does not exist in secret foo"},
+		{property: "annotations.owner", wantErr: "metadata annotations.owner does not exist in secret foo"},
+	}
+	for _, tt := range tests {
+		out, err := sm.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+			Key:            "foo",
+			Property:       tt.property,
+			MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+		})
+		if !ErrorContains(err, tt.wantErr) {
+			t.Errorf("[%s] unexpected error: %v, expected: %s", tt.property, err, tt.wantErr)
+		}
+		if tt.wantErr == "" && string(out) != tt.want {
+			t.Errorf("[%s] unexpected metadata: %s, expected: %s", tt.property, string(out), tt.want)
+		}
+	}
+
+	data, err := sm.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "foo",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	want := map[string][]byte{"owner": []byte("team-a"), "annotations.rotation": []byte("monthly")}
+	if err != nil || !reflect.DeepEqual(data, want) {
+		t.Errorf("unexpected metadata: %#v, %v", data, err)
+	}
+}
+
+func TestLabelFilter(t *testing.T) {
+	path := "team-a"
+	tests := []struct {
+		tags map[string]string
+		path *string
+		want string
+	}{
+		{
+			tags: map[string]string{"env": "prod"},
+			want: `labels.env="prod"`,
+		},
+		{
+			tags: map[string]string{"team": "a", "env": "prod"},
+			path: &path,
+			want: `labels.env="prod" AND labels.team="a" AND name:team-a`,
+		},
+	}
+	for _, tt := range tests {
+		if got := labelFilter(tt.tags, tt.path); got != tt.want {
+			t.Errorf("unexpected filter: expected %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestTrimName(t *testing.T) {
+	sm := ProviderGCP{projectID: "default"}
+	if got := sm.trimName("projects/1234/secrets/foo"); got != "foo" {
+		t.Errorf("unexpected name: %s", got)
+	}
+	sm.location = "europe-west1"
+	if got := sm.trimName("projects/1234/locations/europe-west1/secrets/foo"); got != "foo" {
+		t.Errorf("unexpected name: %s", got)
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
@@ -14,25 +14,31 @@ limitations under the License.
 package fake
 
 import (
+	"fmt"
+	"net/http"
+
 	gitlab "github.com/xanzy/go-gitlab"
 )
 
 type GitlabMockClient struct {
-	getVariable   func(pid interface{}, key string, options ...gitlab.RequestOptionFunc) (*gitlab.ProjectVariable, *gitlab.Response, error)
-	listVariables func(pid interface{}, options ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectVariable, *gitlab.Response, error)
+	// ListCalls counts the calls of ListVariables.
+	ListCalls     int
+	getVariable   func(pid interface{}, key string, opt *gitlab.GetProjectVariableOptions) (*gitlab.ProjectVariable, *gitlab.Response, error)
+	listVariables func(pid interface{}, opt *gitlab.ListProjectVariablesOptions) ([]*gitlab.ProjectVariable, *gitlab.Response, error)
 }
 
 func (mc *GitlabMockClient) GetVariable(pid interface{}, key string, opt *gitlab.GetProjectVariableOptions, options ...gitlab.RequestOptionFunc) (*gitlab.ProjectVariable, *gitlab.Response, error) {
-	return mc.getVariable(pid, key, nil)
+	return mc.getVariable(pid, key, opt)
 }
 
 func (mc *GitlabMockClient) ListVariables(pid interface{}, opt *gitlab.ListProjectVariablesOptions, options ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
-	return mc.listVariables(pid)
+	mc.ListCalls++
+	return mc.listVariables(pid, opt)
 }
 
 func (mc *GitlabMockClient) WithValue(projectIDinput, keyInput string, output *gitlab.ProjectVariable, response *gitlab.Response, err error) {
 	if mc != nil {
-		mc.getVariable = func(pid interface{}, key string, options ...gitlab.RequestOptionFunc) (*gitlab.ProjectVariable, *gitlab.Response, error) {
+		mc.getVariable = func(pid interface{}, key string, opt *gitlab.GetProjectVariableOptions) (*gitlab.ProjectVariable, *gitlab.Response, error) {
 			// type secretmanagerpb.AccessSecretVersionRequest contains unexported fields
 			// use cmpopts.IgnoreUnexported to ignore all the unexported fields in the cmp.
 			// if !cmp.Equal(paramReq, input, cmpopts.IgnoreUnexported(gitlab.ProjectVariable{})) {
@@ -41,8 +47,101 @@ func (mc *GitlabMockClient) WithValue(projectIDinput, keyInput string, output *g
 			return output, response, err
 		}
 
-		mc.listVariables = func(pid interface{}, options ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
+		mc.listVariables = func(pid interface{}, opt *gitlab.ListProjectVariablesOptions) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
 			return []*gitlab.ProjectVariable{output}, response, err
 		}
 	}
 }
+
+// WithVariables behaves like the gitlab API for the given variables:
+// GetVariable honors the environment scope filter, returns a 404 response
+// for unknown keys and a 409 response if several scopes match the key.
+// ListVariables returns pages of pageSize variables.
+func (mc *GitlabMockClient) WithVariables(vars []*gitlab.ProjectVariable, pageSize int) {
+	if mc == nil {
+		return
+	}
+	mc.getVariable = func(pid interface{}, key string, opt *gitlab.GetProjectVariableOptions) (*gitlab.ProjectVariable, *gitlab.Response, error) {
+		var found []*gitlab.ProjectVariable
+		for _, v := range vars {
+			if v.Key != key {
+				continue
+			}
+			if opt != nil && opt.Filter != nil && opt.Filter.EnvironmentScope != v.EnvironmentScope {
+				continue
+			}
+			found = append(found, v)
+		}
+		switch len(found) {
+		case 0:
+			return nil, newResponse(http.StatusNotFound, 0), fmt.Errorf("404 Variable Not Found")
+		case 1:
+			return found[0], newResponse(http.StatusOK, 0), nil
+		default:
+			return nil, newResponse(http.StatusConflict, 0), fmt.Errorf("409 There are multiple variables with provided parameters")
+		}
+	}
+	mc.listVariables = func(pid interface{}, opt *gitlab.ListProjectVariablesOptions) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
+		start, end, next := page(len(vars), opt.Page, pageSize)
+		return vars[start:end], newResponse(http.StatusOK, next), nil
+	}
+}
+
+type GitlabMockGroupClient struct {
+	// ListCalls counts the calls of ListVariables.
+	ListCalls     int
+	getVariable   func(gid interface{}, key string) (*gitlab.GroupVariable, *gitlab.Response, error)
+	listVariables func(gid interface{}, opt *gitlab.ListGroupVariablesOptions) ([]*gitlab.GroupVariable, *gitlab.Response, error)
+}
+
+func (mc *GitlabMockGroupClient) GetVariable(gid interface{}, key string, options ...gitlab.RequestOptionFunc) (*gitlab.GroupVariable, *gitlab.Response, error) {
+	return mc.getVariable(gid, key)
+}
+
+func (mc *GitlabMockGroupClient) ListVariables(gid interface{}, opt *gitlab.ListGroupVariablesOptions, options ...gitlab.RequestOptionFunc) ([]*gitlab.GroupVariable, *gitlab.Response, error) {
+	mc.ListCalls++
+	return mc.listVariables(gid, opt)
+}
+
+// WithVariables registers the variables per group id. The group API
+// does not filter by environment scope, so GetVariable returns the first match.
+func (mc *GitlabMockGroupClient) WithVariables(vars map[string][]*gitlab.GroupVariable, pageSize int) {
+	if mc == nil {
+		return
+	}
+	mc.getVariable = func(gid interface{}, key string) (*gitlab.GroupVariable, *gitlab.Response, error) {
+		for _, v := range vars[fmt.Sprint(gid)] {
+			if v.Key == key {
+				return v, newResponse(http.StatusOK, 0), nil
+			}
+		}
+		return nil, newResponse(http.StatusNotFound, 0), fmt.Errorf("404 Variable Not Found")
+	}
+	mc.listVariables = func(gid interface{}, opt *gitlab.ListGroupVariablesOptions) ([]*gitlab.GroupVariable, *gitlab.Response, error) {
+		groupVars := vars[fmt.Sprint(gid)]
+		start, end, next := page(len(groupVars), opt.Page, pageSize)
+		return groupVars[start:end], newResponse(http.StatusOK, next), nil
+	}
+}
+
+func page(total, page, pageSize int) (start, end, next int) {
+	if page < 1 {
+		page = 1
+	}
+	start = (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end = start + pageSize
+	if end >= total {
+		return start, total, 0
+	}
+	return start, end, page + 1
+}
+
+func newResponse(statusCode, nextPage int) *gitlab.Response {
+	return &gitlab.Response{
+		Response: &http.Response{StatusCode: statusCode},
+		NextPage: nextPage,
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/tidwall/gjson"
@@ -28,6 +29,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/e2e/framework/log"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
@@ -42,22 +44,47 @@ const (
 	errAuth                                   = "client is not allowed to get secrets"
 	errUninitalizedGitlabProvider             = "provider gitlab is not initialized"
 	errJSONSecretUnmarshal                    = "unable to unmarshal secret: %w"
+	errInvalidVariableKey                     = "invalid variable key %q: only letters, digits and _ are allowed"
+	errMissingProjectOrGroup                  = "projectID or groupIDs must be set"
+	errFindNameRequired                       = "find.name is required: gitlab provider only supports finding variables by name"
+	errListProjectVariables                   = "unable to list variables of project %s: %w"
+	errListGroupVariables                     = "unable to list variables of group %s: %w"
+	errConflictingScopes                      = "variable %s exists with multiple environment scopes, set the environment of the store"
+
+	// gitlab scopes variables to all environments with a wildcard.
+	environmentScopeAll = "*"
+	listPageSize        = 100
 )
 
+// https://docs.gitlab.com/ee/ci/variables/#add-a-cicd-variable-to-a-project
+var variableKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &Gitlab{}
 var _ esv1beta1.Provider = &Gitlab{}
 
-type Client interface {
+type ProjectVariablesClient interface {
 	GetVariable(pid interface{}, key string, opt *gitlab.GetProjectVariableOptions, options ...gitlab.RequestOptionFunc) (*gitlab.ProjectVariable, *gitlab.Response, error)
 	ListVariables(pid interface{}, opt *gitlab.ListProjectVariablesOptions, options ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectVariable, *gitlab.Response, error)
 }
 
-// Gitlab Provider struct with reference to a GitLab client and a projectID.
+type GroupVariablesClient interface {
+	GetVariable(gid interface{}, key string, options ...gitlab.RequestOptionFunc) (*gitlab.GroupVariable, *gitlab.Response, error)
+	ListVariables(gid interface{}, opt *gitlab.ListGroupVariablesOptions, options ...gitlab.RequestOptionFunc) ([]*gitlab.GroupVariable, *gitlab.Response, error)
+}
+
+// Gitlab Provider struct with reference to GitLab clients, a projectID and groupIDs.
 type Gitlab struct {
-	client    Client
-	url       string
-	projectID interface{}
+	projectVariablesClient ProjectVariablesClient
+	groupVariablesClient   GroupVariablesClient
+	url                    string
+	projectID              string
+	groupIDs               []string
+	environment            string
+
+	// the variables are listed at most once per client.
+	projectVariables *scopedVariables
+	groupVariables   map[string]*scopedVariables
 }
 
 // Client for interacting with kubernetes cluster...?
@@ -149,25 +176,77 @@ func (g *Gitlab) NewClient(ctx context.Context, store esv1beta1.GenericStore, ku
 		log.Logf("Failed to create client: %v", err)
 	}
 
-	g.client = gitlabClient.ProjectVariables
-	g.projectID = cliStore.store.ProjectID
-	g.url = cliStore.store.URL
-
-	return g, nil
+	// every client gets its own instance, as it caches the listed variables of its store.
+	return &Gitlab{
+		projectVariablesClient: gitlabClient.ProjectVariables,
+		groupVariablesClient:   gitlabClient.GroupVariables,
+		projectID:              cliStore.store.ProjectID,
+		groupIDs:               cliStore.store.GroupIDs,
+		environment:            cliStore.store.Environment,
+		url:                    cliStore.store.URL,
+	}, nil
 }
 
-// Empty GetAllSecrets.
+// GetAllSecrets lists the variables of the project and the groups
+// and returns the ones whose key matches find.name.regexp.
+// Project variables take precedence over group variables.
 func (g *Gitlab) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not implemented")
+	if utils.IsNil(g.projectVariablesClient) {
+		return nil, fmt.Errorf(errUninitalizedGitlabProvider)
+	}
+	if ref.Name == nil || len(ref.Tags) > 0 || ref.Path != nil {
+		return nil, fmt.Errorf(errFindNameRequired)
+	}
+	matcher, err := find.New(*ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	secretData := make(map[string][]byte)
+	add := func(vars *scopedVariables) error {
+		for k := range vars.conflicts {
+			if _, exists := secretData[k]; !exists && matcher.MatchName(k) {
+				return fmt.Errorf(errConflictingScopes, k)
+			}
+		}
+		for k, v := range vars.values {
+			if _, exists := secretData[k]; exists || !matcher.MatchName(k) {
+				continue
+			}
+			secretData[k] = []byte(v)
+		}
+		return nil
+	}
+	if g.projectID != "" {
+		vars, err := g.listProjectVariables()
+		if err != nil {
+			return nil, err
+		}
+		if err := add(vars); err != nil {
+			return nil, err
+		}
+	}
+	for _, groupID := range g.groupIDs {
+		vars, err := g.listGroupVariables(groupID)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(vars); err != nil {
+			return nil, err
+		}
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretData)
 }
 
 func (g *Gitlab) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	if utils.IsNil(g.client) {
+	if utils.IsNil(g.projectVariablesClient) {
 		return nil, fmt.Errorf(errUninitalizedGitlabProvider)
 	}
-	// Need to replace hyphens with underscores to work with Gitlab API
-	ref.Key = strings.ReplaceAll(ref.Key, "-", "_")
+	// gitlab only allows letters, digits and underscores in variable keys.
+	// We do not rewrite the key, as this would silently fetch a different variable.
+	if !variableKeyRegexp.MatchString(ref.Key) {
+		return nil, fmt.Errorf(errInvalidVariableKey, ref.Key)
+	}
 	// Retrieves a gitlab variable in the form
 	// {
 	// 	"key": "TEST_VARIABLE_1",
@@ -175,30 +254,234 @@ func (g *Gitlab) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretData
 	// 	"value": "TEST_1",
 	// 	"protected": false,
 	// 	"masked": true
-	data, _, err := g.client.GetVariable(g.projectID, ref.Key, nil) // Optional 'filter' parameter could be added later
+	// 	"environment_scope": "*"
+	value, err := g.getVariable(ref.Key)
 	if err != nil {
 		return nil, err
 	}
 
 	if ref.Property == "" {
-		if data.Value != "" {
-			return []byte(data.Value), nil
+		if value != "" {
+			return []byte(value), nil
 		}
 		return nil, fmt.Errorf("invalid secret received. no secret string for key: %s", ref.Key)
 	}
 
-	var payload string
-	if data.Value != "" {
-		payload = data.Value
-	}
-
-	val := gjson.Get(payload, ref.Property)
+	val := gjson.Get(value, ref.Property)
 	if !val.Exists() {
 		return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
 	}
 	return []byte(val.String()), nil
 }
 
+// getVariable looks up the variable in the project first
+// and then in the groups in the order they are configured.
+func (g *Gitlab) getVariable(key string) (string, error) {
+	if g.projectID != "" {
+		value, found, err := g.getProjectVariable(key)
+		if err != nil || found {
+			return value, err
+		}
+	}
+	for _, groupID := range g.groupIDs {
+		value, found, err := g.getGroupVariable(groupID, key)
+		if err != nil || found {
+			return value, err
+		}
+	}
+	return "", esv1beta1.NoSecretErr
+}
+
+func (g *Gitlab) getProjectVariable(key string) (string, bool, error) {
+	// the variables API only filters by the exact scope, so wildcard
+	// scopes of an environment can only be matched by listing the variables.
+	if g.environment != "" {
+		vars, err := g.listProjectVariables()
+		if err != nil {
+			return "", false, err
+		}
+		return vars.get(key)
+	}
+	// a variable scoped to all environments wins, otherwise the variable must be unique.
+	for _, opt := range []*gitlab.GetProjectVariableOptions{
+		{Filter: &gitlab.VariableFilter{EnvironmentScope: environmentScopeAll}},
+		nil,
+	} {
+		data, resp, err := g.projectVariablesClient.GetVariable(g.projectID, key, opt)
+		if isNotFound(resp) {
+			continue
+		}
+		// gitlab refuses to pick one of several scoped variables with the same key
+		if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusConflict {
+			return "", false, fmt.Errorf(errConflictingScopes, key)
+		}
+		if err != nil {
+			return "", false, err
+		}
+		return data.Value, true, nil
+	}
+	return "", false, nil
+}
+
+func (g *Gitlab) getGroupVariable(groupID, key string) (string, bool, error) {
+	// the group variables API does not support filtering by environment scope,
+	// so we have to list the variables to pick the one with the right scope.
+	vars, err := g.listGroupVariables(groupID)
+	if err != nil {
+		return "", false, err
+	}
+	return vars.get(key)
+}
+
+// listProjectVariables returns all project variables matching the environment.
+func (g *Gitlab) listProjectVariables() (*scopedVariables, error) {
+	if g.projectVariables != nil {
+		return g.projectVariables, nil
+	}
+	var vars []variable
+	opt := &gitlab.ListProjectVariablesOptions{PerPage: listPageSize, Page: 1}
+	for {
+		data, resp, err := g.projectVariablesClient.ListVariables(g.projectID, opt)
+		if err != nil {
+			return nil, fmt.Errorf(errListProjectVariables, g.projectID, err)
+		}
+		for _, v := range data {
+			vars = append(vars, variable{key: v.Key, value: v.Value, scope: v.EnvironmentScope})
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	g.projectVariables = g.filterEnvironment(vars)
+	return g.projectVariables, nil
+}
+
+// listGroupVariables returns all variables of a group matching the environment.
+func (g *Gitlab) listGroupVariables(groupID string) (*scopedVariables, error) {
+	if cached, ok := g.groupVariables[groupID]; ok {
+		return cached, nil
+	}
+	var vars []variable
+	opt := &gitlab.ListGroupVariablesOptions{PerPage: listPageSize, Page: 1}
+	for {
+		data, resp, err := g.groupVariablesClient.ListVariables(groupID, opt)
+		if err != nil {
+			return nil, fmt.Errorf(errListGroupVariables, groupID, err)
+		}
+		for _, v := range data {
+			vars = append(vars, variable{key: v.Key, value: v.Value, scope: v.EnvironmentScope})
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	if g.groupVariables == nil {
+		g.groupVariables = make(map[string]*scopedVariables)
+	}
+	g.groupVariables[groupID] = g.filterEnvironment(vars)
+	return g.groupVariables[groupID], nil
+}
+
+type variable struct {
+	key   string
+	value string
+	scope string
+}
+
+// scopedVariables are the variables of a project or group matching the environment.
+// Keys with several equally specific scopes are conflicts and can not be resolved.
+type scopedVariables struct {
+	values    map[string]string
+	conflicts map[string]bool
+}
+
+func (s *scopedVariables) get(key string) (string, bool, error) {
+	if s.conflicts[key] {
+		return "", false, fmt.Errorf(errConflictingScopes, key)
+	}
+	value, found := s.values[key]
+	return value, found, nil
+}
+
+// filterEnvironment picks the variable with the most specific scope for the
+// environment like GitLab does: the exact scope wins over a wildcard scope,
+// e.g. review/*, which wins over all environments. Without an environment the
+// variable scoped to all environments wins and other scopes must be unique.
+func (g *Gitlab) filterEnvironment(vars []variable) *scopedVariables {
+	best := make(map[string]variable)
+	conflicts := make(map[string]bool)
+	for _, v := range vars {
+		if g.environment != "" && !matchesScope(v.scope, g.environment) {
+			continue
+		}
+		current, exists := best[v.key]
+		if !exists {
+			best[v.key] = v
+			continue
+		}
+		switch prio, currentPrio := g.scopePriority(v.scope), g.scopePriority(current.scope); {
+		case prio > currentPrio:
+			best[v.key] = v
+			delete(conflicts, v.key)
+		case prio == currentPrio:
+			conflicts[v.key] = true
+		}
+	}
+	out := &scopedVariables{values: make(map[string]string), conflicts: conflicts}
+	for key, v := range best {
+		if !conflicts[key] {
+			out.values[key] = v.value
+		}
+	}
+	return out
+}
+
+// scopePriority ranks a matching scope, the scope with the highest priority wins.
+func (g *Gitlab) scopePriority(scope string) int {
+	switch {
+	case g.environment == "" && scope == environmentScopeAll:
+		return 2
+	case g.environment == "":
+		return 1
+	case scope == g.environment:
+		return 1 << 30
+	case scope == environmentScopeAll:
+		return 0
+	default:
+		// longer wildcard scopes are more specific
+		return 1 + len(scope)
+	}
+}
+
+// matchesScope reports whether the environment matches the scope,
+// * in a scope matches any characters like in GitLab.
+func matchesScope(scope, environment string) bool {
+	parts := strings.Split(scope, "*")
+	if len(parts) == 1 {
+		return scope == environment
+	}
+	first, last := parts[0], parts[len(parts)-1]
+	if !strings.HasPrefix(environment, first) {
+		return false
+	}
+	rest := environment[len(first):]
+	// the parts between two wildcards match at their first occurrence
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return strings.HasSuffix(rest, last)
+}
+
+func isNotFound(resp *gitlab.Response) bool {
+	return resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound
+}
+
 func (g *Gitlab) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
 	// Gets a secret as normal, expecting secret value to be a json object
 	data, err := g.GetSecret(ctx, ref)
@@ -227,7 +510,13 @@ func (g *Gitlab) Close(ctx context.Context) error {
 
 // Validate will use the gitlab client to validate the gitlab provider using the ListVariable call to ensure get permissions without needing a specific key.
 func (g *Gitlab) Validate() (esv1beta1.ValidationResult, error) {
-	_, resp, err := g.client.ListVariables(g.projectID, nil)
+	var resp *gitlab.Response
+	var err error
+	if g.projectID != "" || len(g.groupIDs) == 0 {
+		_, resp, err = g.projectVariablesClient.ListVariables(g.projectID, nil)
+	} else {
+		_, resp, err = g.groupVariablesClient.ListVariables(g.groupIDs[0], nil)
+	}
 	if err != nil {
 		return esv1beta1.ValidationResultError, fmt.Errorf(errList, err)
 	} else if resp == nil || resp.StatusCode != http.StatusOK {
@@ -245,8 +534,8 @@ func (g *Gitlab) ValidateStore(store esv1beta1.GenericStore) error {
 		return err
 	}
 
-	if gitlabSpec.ProjectID == "" {
-		return fmt.Errorf("projectID cannot be empty")
+	if gitlabSpec.ProjectID == "" && len(gitlabSpec.GroupIDs) == 0 {
+		return fmt.Errorf(errMissingProjectOrGroup)
 	}
 
 	if accessToken.Key == "" {
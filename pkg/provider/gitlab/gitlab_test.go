@@ -71,7 +71,7 @@ func makeValidSecretManagerTestCase() *secretManagerTestCase {
 
 func makeValidRef() *esv1beta1.ExternalSecretDataRemoteRef {
 	return &esv1beta1.ExternalSecretDataRemoteRef{
-		Key:     "test-secret",
+		Key:     "test_secret",
 		Version: "default",
 	}
 }
@@ -155,15 +155,22 @@ func TestGitlabSecretManagerGetSecret(t *testing.T) {
 		smtc.expectedSecret = secretValue
 	}
 
+	// bad case: keys with hyphens are not rewritten
+	setInvalidKey := func(smtc *secretManagerTestCase) {
+		smtc.ref.Key = "test-secret"
+		smtc.expectError = `invalid variable key "test-secret"`
+	}
+
 	successCases := []*secretManagerTestCase{
 		makeValidSecretManagerTestCaseCustom(setSecretString),
 		makeValidSecretManagerTestCaseCustom(setAPIErr),
+		makeValidSecretManagerTestCaseCustom(setInvalidKey),
 		makeValidSecretManagerTestCaseCustom(setNilMockClient),
 	}
 
-	sm := Gitlab{}
+	sm := Gitlab{projectID: makeValidAPIInputProjectID()}
 	for k, v := range successCases {
-		sm.client = v.mockClient
+		sm.projectVariablesClient = v.mockClient
 		out, err := sm.GetSecret(context.Background(), *v.ref)
 		if !ErrorContains(err, v.expectError) {
 			t.Errorf("[%d] unexpected error: %s, expected: '%s'", k, err.Error(), v.expectError)
@@ -181,9 +188,9 @@ func TestValidate(t *testing.T) {
 		makeValidSecretManagerTestCaseCustom(setListAPIRespNil),
 		makeValidSecretManagerTestCaseCustom(setListAPIRespBadCode),
 	}
-	sm := Gitlab{}
+	sm := Gitlab{projectID: makeValidAPIInputProjectID()}
 	for k, v := range successCases {
-		sm.client = v.mockClient
+		sm.projectVariablesClient = v.mockClient
 		t.Logf("%+v", v)
 		validationResult, err := sm.Validate()
 		if !ErrorContains(err, v.expectError) {
@@ -215,9 +222,9 @@ func TestGetSecretMap(t *testing.T) {
 		makeValidSecretManagerTestCaseCustom(setAPIErr),
 	}
 
-	sm := Gitlab{}
+	sm := Gitlab{projectID: makeValidAPIInputProjectID()}
 	for k, v := range successCases {
-		sm.client = v.mockClient
+		sm.projectVariablesClient = v.mockClient
 		out, err := sm.GetSecretMap(context.Background(), *v.ref)
 		if !ErrorContains(err, v.expectError) {
 			t.Errorf("[%d] unexpected error: %s, expected: '%s'", k, err.Error(), v.expectError)
@@ -268,6 +275,13 @@ func withAccessToken(name, key string, namespace *string) storeModifier {
 	}
 }
 
+func withGroupIDs(groupIDs ...string) storeModifier {
+	return func(store *esv1beta1.SecretStore) *esv1beta1.SecretStore {
+		store.Spec.Provider.Gitlab.GroupIDs = groupIDs
+		return store
+	}
+}
+
 type ValidateStoreTestCase struct {
 	store *esv1beta1.SecretStore
 	err   error
@@ -278,7 +292,7 @@ func TestValidateStore(t *testing.T) {
 	testCases := []ValidateStoreTestCase{
 		{
 			store: makeSecretStore(""),
-			err:   fmt.Errorf("projectID cannot be empty"),
+			err:   fmt.Errorf(errMissingProjectOrGroup),
 		},
 		{
 			store: makeSecretStore(project, withAccessToken("", userkey, nil)),
@@ -296,6 +310,10 @@ func TestValidateStore(t *testing.T) {
 			store: makeSecretStore(project, withAccessToken("userName", "userKey", nil)),
 			err:   nil,
 		},
+		{
+			store: makeSecretStore("", withGroupIDs("1"), withAccessToken("userName", "userKey", nil)),
+			err:   nil,
+		},
 	}
 	p := Gitlab{}
 	for _, tc := range testCases {
@@ -309,3 +327,198 @@ func TestValidateStore(t *testing.T) {
 		}
 	}
 }
+
+func makeVariablesProvider(environment string) *Gitlab {
+	projectClient := &fakegitlab.GitlabMockClient{}
+	projectClient.WithVariables([]*gitlab.ProjectVariable{
+		{Key: "PROJECT_ONLY", Value: "project", EnvironmentScope: "*"},
+		{Key: "SHARED", Value: "project", EnvironmentScope: "*"},
+		{Key: "SCOPED", Value: "project-all", EnvironmentScope: "*"},
+		{Key: "SCOPED", Value: "project-production", EnvironmentScope: "production"},
+		{Key: "REVIEW_ONLY", Value: "review", EnvironmentScope: "review/*"},
+		{Key: "REVIEW", Value: "review-all", EnvironmentScope: "*"},
+		{Key: "REVIEW", Value: "review", EnvironmentScope: "review/*"},
+		{Key: "REVIEW", Value: "review-feature", EnvironmentScope: "review/feature-*"},
+		{Key: "REVIEW", Value: "review-feature-1", EnvironmentScope: "review/feature-1"},
+		{Key: "UNSCOPED_CONFLICT", Value: "project-staging", EnvironmentScope: "staging"},
+		{Key: "UNSCOPED_CONFLICT", Value: "project-production", EnvironmentScope: "production"},
+	}, 2)
+	groupClient := &fakegitlab.GitlabMockGroupClient{}
+	groupClient.WithVariables(map[string][]*gitlab.GroupVariable{
+		"1": {
+			{Key: "SHARED", Value: "group1", EnvironmentScope: "*"},
+			{Key: "GROUP_ONLY", Value: "group1", EnvironmentScope: "*"},
+			{Key: "GROUP_PRODUCTION", Value: "group1-staging", EnvironmentScope: "staging"},
+			{Key: "GROUP_PRODUCTION", Value: "group1-production", EnvironmentScope: "production"},
+		},
+		"2": {
+			{Key: "GROUP_ONLY", Value: "group2", EnvironmentScope: "*"},
+			{Key: "OTHER_GROUP", Value: "group2", EnvironmentScope: "*"},
+		},
+	}, 1)
+	return &Gitlab{
+		projectVariablesClient: projectClient,
+		groupVariablesClient:   groupClient,
+		projectID:              "project",
+		groupIDs:               []string{"1", "2"},
+		environment:            environment,
+	}
+}
+
+func TestGetSecretPrecedence(t *testing.T) {
+	tbl := []struct {
+		environment string
+		key         string
+		expected    string
+		expectError string
+	}{
+		{key: "PROJECT_ONLY", expected: "project"},
+		{key: "SHARED", expected: "project"},
+		{key: "GROUP_ONLY", expected: "group1"},
+		{key: "OTHER_GROUP", expected: "group2"},
+		{key: "MISSING", expectError: esv1beta1.NoSecretErr.Error()},
+		{environment: "production", key: "SCOPED", expected: "project-production"},
+		{environment: "staging", key: "SCOPED", expected: "project-all"},
+		{environment: "production", key: "GROUP_PRODUCTION", expected: "group1-production"},
+		{environment: "staging", key: "GROUP_PRODUCTION", expected: "group1-staging"},
+		{environment: "development", key: "GROUP_PRODUCTION", expectError: esv1beta1.NoSecretErr.Error()},
+		{environment: "review/feature-2", key: "REVIEW_ONLY", expected: "review"},
+		{environment: "production", key: "REVIEW_ONLY", expectError: esv1beta1.NoSecretErr.Error()},
+		{key: "REVIEW", expected: "review-all"},
+		{environment: "review/other", key: "REVIEW", expected: "review"},
+		{environment: "review/feature-2", key: "REVIEW", expected: "review-feature"},
+		{environment: "review/feature-1", key: "REVIEW", expected: "review-feature-1"},
+		{environment: "production", key: "REVIEW", expected: "review-all"},
+		{key: "UNSCOPED_CONFLICT", expectError: "exists with multiple environment scopes"},
+		{key: "GROUP_PRODUCTION", expectError: "exists with multiple environment scopes"},
+		{environment: "production", key: "UNSCOPED_CONFLICT", expected: "project-production"},
+	}
+	for _, row := range tbl {
+		t.Run(row.environment+"/"+row.key, func(t *testing.T) {
+			g := makeVariablesProvider(row.environment)
+			out, err := g.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: row.key})
+			if !ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if string(out) != row.expected {
+				t.Errorf("unexpected secret: expected %s, got %s", row.expected, string(out))
+			}
+		})
+	}
+}
+
+func TestVariablesAreListedOnce(t *testing.T) {
+	g := makeVariablesProvider("production")
+	for _, key := range []string{"SCOPED", "GROUP_PRODUCTION", "OTHER_GROUP", "MISSING"} {
+		_, _ = g.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+	}
+	_, err := g.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 11 project variables in pages of 2, 4 and 2 group variables in pages of 1
+	if calls := g.projectVariablesClient.(*fakegitlab.GitlabMockClient).ListCalls; calls != 6 {
+		t.Errorf("unexpected project list calls: %d", calls)
+	}
+	if calls := g.groupVariablesClient.(*fakegitlab.GitlabMockGroupClient).ListCalls; calls != 6 {
+		t.Errorf("unexpected group list calls: %d", calls)
+	}
+}
+
+func TestMatchesScope(t *testing.T) {
+	tbl := []struct {
+		scope       string
+		environment string
+		expected    bool
+	}{
+		{scope: "*", environment: "production", expected: true},
+		{scope: "production", environment: "production", expected: true},
+		{scope: "production", environment: "production-eu", expected: false},
+		{scope: "review/*", environment: "review/feature-1", expected: true},
+		{scope: "review/*", environment: "review/", expected: true},
+		{scope: "review/*", environment: "staging", expected: false},
+		{scope: "*-eu", environment: "production-eu", expected: true},
+		{scope: "*-eu", environment: "production-us", expected: false},
+		{scope: "review/*/eu-*", environment: "review/feature/eu-west", expected: true},
+		{scope: "review/*/eu-*", environment: "review/feature/us-west", expected: false},
+		{scope: "a*a", environment: "a", expected: false},
+		{scope: "a*a", environment: "aa", expected: true},
+		{scope: "prod.*", environment: "prodX", expected: false},
+	}
+	for _, row := range tbl {
+		if got := matchesScope(row.scope, row.environment); got != row.expected {
+			t.Errorf("matchesScope(%q, %q) = %v, expected %v", row.scope, row.environment, got, row.expected)
+		}
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	tbl := []struct {
+		name        string
+		environment string
+		find        esv1beta1.ExternalSecretFind
+		expected    map[string][]byte
+		expectError string
+	}{
+		{
+			name: "all variables without environment",
+			find: esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "_ONLY$"}},
+			expected: map[string][]byte{
+				"PROJECT_ONLY": []byte("project"),
+				"REVIEW_ONLY":  []byte("review"),
+				"GROUP_ONLY":   []byte("group1"),
+			},
+		},
+		{
+			name:        "conflicting scopes without environment",
+			find:        esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^GROUP_"}},
+			expectError: "variable GROUP_PRODUCTION exists with multiple environment scopes",
+		},
+		{
+			name:        "wildcard scope matches environment",
+			environment: "review/feature-1",
+			find:        esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^REVIEW"}},
+			expected: map[string][]byte{
+				"REVIEW_ONLY": []byte("review"),
+				"REVIEW":      []byte("review-feature-1"),
+			},
+		},
+		{
+			name:        "project takes precedence and environment filters scopes",
+			environment: "production",
+			find:        esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}},
+			expected: map[string][]byte{
+				"PROJECT_ONLY":      []byte("project"),
+				"SHARED":            []byte("project"),
+				"SCOPED":            []byte("project-production"),
+				"REVIEW":            []byte("review-all"),
+				"UNSCOPED_CONFLICT": []byte("project-production"),
+				"GROUP_ONLY":        []byte("group1"),
+				"GROUP_PRODUCTION":  []byte("group1-production"),
+				"OTHER_GROUP":       []byte("group2"),
+			},
+		},
+		{
+			name:        "find by tags is not supported",
+			find:        esv1beta1.ExternalSecretFind{Tags: map[string]string{"foo": "bar"}},
+			expectError: errFindNameRequired,
+		},
+		{
+			name:        "invalid regexp",
+			find:        esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "["}},
+			expectError: "could not compile find.name.regexp",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			g := makeVariablesProvider(row.environment)
+			out, err := g.GetAllSecrets(context.Background(), row.find)
+			if !ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expected) {
+				t.Errorf("unexpected secret data: expected %#v, got %#v", row.expected, out)
+			}
+		})
+	}
+}
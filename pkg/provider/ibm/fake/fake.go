@@ -23,13 +23,67 @@ import (
 )
 
 type IBMMockClient struct {
-	getSecret func(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error)
+	// GetSecretCalls counts the calls of GetSecret.
+	GetSecretCalls int
+	getSecret      func(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error)
+	listAllSecrets func(listAllSecretsOptions *sm.ListAllSecretsOptions) (result *sm.ListSecrets, response *core.DetailedResponse, err error)
 }
 
 func (mc *IBMMockClient) GetSecret(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error) {
+	mc.GetSecretCalls++
 	return mc.getSecret(getSecretOptions)
 }
 
+func (mc *IBMMockClient) ListAllSecrets(listAllSecretsOptions *sm.ListAllSecretsOptions) (result *sm.ListSecrets, response *core.DetailedResponse, err error) {
+	return mc.listAllSecrets(listAllSecretsOptions)
+}
+
+// WithSecrets serves the secrets by ID and type from GetSecret.
+// ListAllSecrets honors the groups filter and pages by offset and limit.
+func (mc *IBMMockClient) WithSecrets(secrets []*sm.SecretResource) {
+	if mc == nil {
+		return
+	}
+	mc.getSecret = func(opts *sm.GetSecretOptions) (*sm.GetSecret, *core.DetailedResponse, error) {
+		for _, secret := range secrets {
+			if *secret.ID == *opts.ID && *secret.SecretType == *opts.SecretType {
+				return &sm.GetSecret{Resources: []sm.SecretResourceIntf{secret}}, nil, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("secret %s not found", *opts.ID)
+	}
+	mc.listAllSecrets = func(opts *sm.ListAllSecretsOptions) (*sm.ListSecrets, *core.DetailedResponse, error) {
+		var filtered []sm.SecretResourceIntf
+		for _, secret := range secrets {
+			if len(opts.Groups) > 0 && (secret.SecretGroupID == nil || !contains(opts.Groups, *secret.SecretGroupID)) {
+				continue
+			}
+			filtered = append(filtered, secret)
+		}
+		total := int64(len(filtered))
+		start, end := *opts.Offset, *opts.Offset+*opts.Limit
+		if start > total {
+			start = total
+		}
+		if end > total {
+			end = total
+		}
+		return &sm.ListSecrets{
+			Metadata:  &sm.CollectionMetadata{CollectionTotal: &total},
+			Resources: filtered[start:end],
+		}, nil, nil
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
 func (mc *IBMMockClient) WithValue(input *sm.GetSecretOptions, output *sm.GetSecret, err error) {
 	if mc != nil {
 		mc.getSecret = func(paramReq *sm.GetSecretOptions) (*sm.GetSecret, *core.DetailedResponse, error) {
@@ -30,6 +30,7 @@ import (
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	utils "github.com/external-secrets/external-secrets/pkg/utils"
 )
 
@@ -45,6 +46,15 @@ const (
 	errFetchSAKSecret                        = "could not fetch SecretAccessKey secret: %w"
 	errMissingSAK                            = "missing SecretAccessKey"
 	errJSONSecretUnmarshal                   = "unable to unmarshal secret: %w"
+	errListSecrets                           = "unable to list secrets: %w"
+	errDuplicateSecretName                   = "found multiple secrets for key %s"
+	errMetadataNotExist                      = "metadata %s does not exist in secret %s"
+	errMetadataNotConvertible                = "custom metadata of secret %s is not an object of values"
+	errUnexpectedResource                    = "unexpected resource type %T"
+
+	listSecretsLimit  = 200
+	labelSeparator    = ":"
+	propertySeparator = "."
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
@@ -53,10 +63,12 @@ var _ esv1beta1.Provider = &providerIBM{}
 
 type SecretManagerClient interface {
 	GetSecret(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error)
+	ListAllSecrets(listAllSecretsOptions *sm.ListAllSecretsOptions) (result *sm.ListSecrets, response *core.DetailedResponse, err error)
 }
 
 type providerIBM struct {
-	IBMClient SecretManagerClient
+	IBMClient     SecretManagerClient
+	secretGroupID string
 }
 
 type client struct {
@@ -100,175 +112,282 @@ func (c *client) setAuth(ctx context.Context) error {
 	return nil
 }
 
-// Empty GetAllSecrets.
+// GetAllSecrets lists the secrets of the secret group given by find.path
+// (or the secretGroupID of the store) and returns the ones matching
+// find.tags (IBM labels) and find.name.regexp, keyed by secret name.
+// The keys and values of each secret are resolved by its type handler.
 func (ibm *providerIBM) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not implemented")
-}
-
-func (ibm *providerIBM) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	if utils.IsNil(ibm.IBMClient) {
 		return nil, fmt.Errorf(errUninitalizedIBMProvider)
 	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
 
-	secretType := sm.GetSecretOptionsSecretTypeArbitraryConst
-	secretName := ref.Key
-	nameSplitted := strings.Split(secretName, "/")
-
-	if len(nameSplitted) > 1 {
-		secretType = nameSplitted[0]
-		secretName = nameSplitted[1]
+	secrets, err := ibm.listSecrets(ref.Path)
+	if err != nil {
+		return nil, err
 	}
 
-	switch secretType {
-	case sm.GetSecretOptionsSecretTypeArbitraryConst:
+	secretMap := make(map[string][]byte)
+	for _, secret := range secrets {
+		if secret.Name == nil || secret.ID == nil || secret.SecretType == nil {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(*secret.Name) {
+			continue
+		}
+		if !hasLabels(secret.Labels, ref.Tags) {
+			continue
+		}
+		values, err := getSecretValues(ibm, secret)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			if _, exists := secretMap[key]; exists {
+				return nil, fmt.Errorf(errDuplicateSecretName, key)
+			}
+			secretMap[key] = value
+		}
+	}
 
-		return getArbitrarySecret(ibm, &secretName)
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
 
-	case sm.CreateSecretOptionsSecretTypeUsernamePasswordConst:
+// listSecrets pages through the secrets of a secret group. If no group is given
+// the secretGroupID of the store is used, otherwise all secrets are listed.
+func (ibm *providerIBM) listSecrets(path *string) ([]*sm.SecretResource, error) {
+	opts := &sm.ListAllSecretsOptions{
+		Limit:  core.Int64Ptr(listSecretsLimit),
+		Offset: core.Int64Ptr(0),
+	}
+	switch {
+	case path != nil && *path != "":
+		opts.Groups = []string{*path}
+	case ibm.secretGroupID != "":
+		opts.Groups = []string{ibm.secretGroupID}
+	}
 
-		if ref.Property == "" {
-			return nil, fmt.Errorf("remoteRef.property required for secret type username_password")
+	var secrets []*sm.SecretResource
+	for {
+		response, _, err := ibm.IBMClient.ListAllSecrets(opts)
+		if err != nil {
+			return nil, fmt.Errorf(errListSecrets, err)
+		}
+		for _, resource := range response.Resources {
+			secret, ok := resource.(*sm.SecretResource)
+			if !ok {
+				return nil, fmt.Errorf(errUnexpectedResource, resource)
+			}
+			secrets = append(secrets, secret)
+		}
+		if len(response.Resources) < listSecretsLimit {
+			break
 		}
-		return getUsernamePasswordSecret(ibm, &secretName, ref)
+		opts.Offset = core.Int64Ptr(*opts.Offset + listSecretsLimit)
+	}
+	return secrets, nil
+}
 
+// getSecretValues returns the keys of a secret found by GetAllSecrets. Secret types
+// with a single value are keyed by the secret name. The types which GetSecret only
+// serves by remoteRef.property return one key per property, named name.property,
+// with the value GetSecret returns for that property.
+func getSecretValues(ibm *providerIBM, secret *sm.SecretResource) (map[string][]byte, error) {
+	name, secretType := *secret.Name, *secret.SecretType
+	// the listed secrets carry no secret data, so fetch each secret once.
+	resource, err := getSecretByType(ibm, secret.ID, secretType)
+	if err != nil {
+		return nil, err
+	}
+	var getProperty func(secret *sm.SecretResource, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error)
+	switch secretType {
+	case sm.GetSecretOptionsSecretTypeArbitraryConst:
+		value, err := getArbitrarySecret(resource)
+		return map[string][]byte{name: value}, err
 	case sm.CreateSecretOptionsSecretTypeIamCredentialsConst:
-
-		return getIamCredentialsSecret(ibm, &secretName)
-
+		value, err := getIamCredentialsSecret(resource)
+		return map[string][]byte{name: value}, err
+	case sm.CreateSecretOptionsSecretTypeKvConst:
+		value, err := getKVSecret(resource, esv1beta1.ExternalSecretDataRemoteRef{Key: *secret.ID})
+		return map[string][]byte{name: value}, err
+	case sm.CreateSecretOptionsSecretTypeUsernamePasswordConst:
+		getProperty = getUsernamePasswordSecret
 	case sm.CreateSecretOptionsSecretTypeImportedCertConst:
-
-		if ref.Property == "" {
-			return nil, fmt.Errorf("remoteRef.property required for secret type imported_cert")
-		}
-
-		return getImportCertSecret(ibm, &secretName, ref)
-
+		getProperty = getImportCertSecret
 	case sm.CreateSecretOptionsSecretTypePublicCertConst:
-
-		if ref.Property == "" {
-			return nil, fmt.Errorf("remoteRef.property required for secret type public_cert")
-		}
-
-		return getPublicCertSecret(ibm, &secretName, ref)
-
+		getProperty = getPublicCertSecret
 	case sm.CreateSecretOptionsSecretTypePrivateCertConst:
+		getProperty = getPrivateCertSecret
+	default:
+		return nil, fmt.Errorf("unknown secret type %s", secretType)
+	}
 
-		if ref.Property == "" {
-			return nil, fmt.Errorf("remoteRef.property required for secret type private_cert")
+	// Only string properties can be returned by GetSecret, others like ca_chain are skipped.
+	secretData, ok := resource.SecretData.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected secret data %T of secret %s", resource.SecretData, name)
+	}
+	values := make(map[string][]byte)
+	for property, v := range secretData {
+		if _, ok := v.(string); !ok {
+			continue
 		}
+		value, err := getProperty(resource, esv1beta1.ExternalSecretDataRemoteRef{Key: *secret.ID, Property: property})
+		if err != nil {
+			return nil, err
+		}
+		values[name+propertySeparator+property] = value
+	}
+	return values, nil
+}
 
-		return getPrivateCertSecret(ibm, &secretName, ref)
-
-	case sm.CreateSecretOptionsSecretTypeKvConst:
-
-		return getKVSecret(ibm, &secretName, ref)
-
-	default:
-		return nil, fmt.Errorf("unknown secret type %s", secretType)
+// hasLabels checks that the labels contain every tag.
+// Tags are matched against labels in the form key:value,
+// a tag with an empty value matches the label key.
+func hasLabels(labels []string, tags map[string]string) bool {
+	for key, value := range tags {
+		label := key
+		if value != "" {
+			label = key + labelSeparator + value
+		}
+		found := false
+		for _, l := range labels {
+			if l == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
 	}
+	return true
 }
 
-func getArbitrarySecret(ibm *providerIBM, secretName *string) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
-		&sm.GetSecretOptions{
-			SecretType: core.StringPtr(sm.GetSecretOptionsSecretTypeArbitraryConst),
-			ID:         secretName,
-		})
+// getSecretMetadata returns the custom metadata of a secret.
+func getSecretMetadata(ibm *providerIBM, secretType, secretName string) (map[string]interface{}, error) {
+	secret, err := getSecretByType(ibm, &secretName, secretType)
 	if err != nil {
 		return nil, err
 	}
+	if secret.CustomMetadata == nil {
+		return map[string]interface{}{}, nil
+	}
+	metadata, ok := secret.CustomMetadata.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf(errMetadataNotConvertible, secretName)
+	}
+	return metadata, nil
+}
 
-	secret := response.Resources[0].(*sm.SecretResource)
-	secretData := secret.SecretData.(map[string]interface{})
-	arbitrarySecretPayload := secretData["payload"].(string)
-	return []byte(arbitrarySecretPayload), nil
+func getMetadataProperty(metadata map[string]interface{}, property, secretName string) ([]byte, error) {
+	if property == "" {
+		return json.Marshal(metadata)
+	}
+	value, ok := metadata[property]
+	if !ok {
+		return nil, fmt.Errorf(errMetadataNotExist, property, secretName)
+	}
+	return getTypedKey(value)
 }
 
-func getImportCertSecret(ibm *providerIBM, secretName *string, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
-		&sm.GetSecretOptions{
-			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeImportedCertConst),
-			ID:         secretName,
-		})
-	if err != nil {
-		return nil, err
+func (ibm *providerIBM) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if utils.IsNil(ibm.IBMClient) {
+		return nil, fmt.Errorf(errUninitalizedIBMProvider)
 	}
 
-	secret := response.Resources[0].(*sm.SecretResource)
-	secretData := secret.SecretData.(map[string]interface{})
+	secretType := sm.GetSecretOptionsSecretTypeArbitraryConst
+	secretName := ref.Key
+	nameSplitted := strings.Split(secretName, "/")
 
-	if val, ok := secretData[ref.Property]; ok {
-		return []byte(val.(string)), nil
+	if len(nameSplitted) > 1 {
+		secretType = nameSplitted[0]
+		secretName = nameSplitted[1]
 	}
-	return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
-}
 
-func getPublicCertSecret(ibm *providerIBM, secretName *string, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
-		&sm.GetSecretOptions{
-			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypePublicCertConst),
-			ID:         secretName,
-		})
-	if err != nil {
-		return nil, err
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		metadata, err := getSecretMetadata(ibm, secretType, secretName)
+		if err != nil {
+			return nil, err
+		}
+		return getMetadataProperty(metadata, ref.Property, secretName)
 	}
 
-	secret := response.Resources[0].(*sm.SecretResource)
-	secretData := secret.SecretData.(map[string]interface{})
-
-	if val, ok := secretData[ref.Property]; ok {
-		return []byte(val.(string)), nil
+	switch secretType {
+	case sm.GetSecretOptionsSecretTypeArbitraryConst,
+		sm.CreateSecretOptionsSecretTypeIamCredentialsConst,
+		sm.CreateSecretOptionsSecretTypeKvConst:
+	case sm.CreateSecretOptionsSecretTypeUsernamePasswordConst,
+		sm.CreateSecretOptionsSecretTypeImportedCertConst,
+		sm.CreateSecretOptionsSecretTypePublicCertConst,
+		sm.CreateSecretOptionsSecretTypePrivateCertConst:
+		if ref.Property == "" {
+			return nil, fmt.Errorf("remoteRef.property required for secret type %s", secretType)
+		}
+	default:
+		return nil, fmt.Errorf("unknown secret type %s", secretType)
 	}
-	return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
-}
 
-func getPrivateCertSecret(ibm *providerIBM, secretName *string, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
-		&sm.GetSecretOptions{
-			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypePrivateCertConst),
-			ID:         secretName,
-		})
+	secret, err := getSecretByType(ibm, &secretName, secretType)
 	if err != nil {
 		return nil, err
 	}
 
-	secret := response.Resources[0].(*sm.SecretResource)
+	switch secretType {
+	case sm.GetSecretOptionsSecretTypeArbitraryConst:
+		return getArbitrarySecret(secret)
+	case sm.CreateSecretOptionsSecretTypeUsernamePasswordConst:
+		return getUsernamePasswordSecret(secret, ref)
+	case sm.CreateSecretOptionsSecretTypeIamCredentialsConst:
+		return getIamCredentialsSecret(secret)
+	case sm.CreateSecretOptionsSecretTypeImportedCertConst:
+		return getImportCertSecret(secret, ref)
+	case sm.CreateSecretOptionsSecretTypePublicCertConst:
+		return getPublicCertSecret(secret, ref)
+	case sm.CreateSecretOptionsSecretTypePrivateCertConst:
+		return getPrivateCertSecret(secret, ref)
+	default:
+		return getKVSecret(secret, ref)
+	}
+}
+
+func getArbitrarySecret(secret *sm.SecretResource) ([]byte, error) {
 	secretData := secret.SecretData.(map[string]interface{})
+	arbitrarySecretPayload := secretData["payload"].(string)
+	return []byte(arbitrarySecretPayload), nil
+}
 
-	if val, ok := secretData[ref.Property]; ok {
-		return []byte(val.(string)), nil
-	}
-	return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+func getImportCertSecret(secret *sm.SecretResource, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return getSecretDataProperty(secret, ref)
 }
 
-func getIamCredentialsSecret(ibm *providerIBM, secretName *string) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
-		&sm.GetSecretOptions{
-			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeIamCredentialsConst),
-			ID:         secretName,
-		})
-	if err != nil {
-		return nil, err
-	}
+func getPublicCertSecret(secret *sm.SecretResource, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return getSecretDataProperty(secret, ref)
+}
 
-	secret := response.Resources[0].(*sm.SecretResource)
+func getPrivateCertSecret(secret *sm.SecretResource, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return getSecretDataProperty(secret, ref)
+}
+
+func getIamCredentialsSecret(secret *sm.SecretResource) ([]byte, error) {
 	secretData := *secret.APIKey
 
 	return []byte(secretData), nil
 }
 
-func getUsernamePasswordSecret(ibm *providerIBM, secretName *string, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
-		&sm.GetSecretOptions{
-			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeUsernamePasswordConst),
-			ID:         secretName,
-		})
-	if err != nil {
-		return nil, err
-	}
+func getUsernamePasswordSecret(secret *sm.SecretResource, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	return getSecretDataProperty(secret, ref)
+}
 
-	secret := response.Resources[0].(*sm.SecretResource)
+// getSecretDataProperty returns a single property of the secret data.
+func getSecretDataProperty(secret *sm.SecretResource, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	secretData := secret.SecretData.(map[string]interface{})
 
 	if val, ok := secretData[ref.Property]; ok {
@@ -278,13 +397,8 @@ func getUsernamePasswordSecret(ibm *providerIBM, secretName *string, ref esv1bet
 }
 
 // Returns a secret of type kv and supports json path.
-func getKVSecret(ibm *providerIBM, secretName *string, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	secret, err := getSecretByType(ibm, secretName, sm.CreateSecretOptionsSecretTypeKvConst)
-	if err != nil {
-		return nil, err
-	}
-
-	log.Info("fetching secret", "secretName", secretName, "key", ref.Key)
+func getKVSecret(secret *sm.SecretResource, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	log.Info("fetching secret", "secretName", secret.Name, "key", ref.Key)
 
 	secretData := secret.SecretData.(map[string]interface{})
 
@@ -297,8 +411,7 @@ func getKVSecret(ibm *providerIBM, secretName *string, ref esv1beta1.ExternalSec
 
 	payloadJSONMap, ok := payloadJSON.(map[string]interface{})
 	if ok {
-		var payloadJSONByte []byte
-		payloadJSONByte, err = json.Marshal(payloadJSONMap)
+		payloadJSONByte, err := json.Marshal(payloadJSONMap)
 		if err != nil {
 			return nil, fmt.Errorf("marshaling payload from secret failed. %w", err)
 		}
@@ -370,6 +483,18 @@ func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1beta1.External
 		secretName = nameSplitted[1]
 	}
 
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		metadata, err := getSecretMetadata(ibm, secretType, secretName)
+		if err != nil {
+			return nil, err
+		}
+		secretMap := byteArrayMap(metadata)
+		if secretMap == nil {
+			return nil, fmt.Errorf(errMetadataNotConvertible, secretName)
+		}
+		return secretMap, nil
+	}
+
 	switch secretType {
 	case sm.GetSecretOptionsSecretTypeArbitraryConst:
 		response, _, err := ibm.IBMClient.GetSecret(
@@ -482,7 +607,11 @@ func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1beta1.External
 		return secretMap, nil
 
 	case sm.CreateSecretOptionsSecretTypeKvConst:
-		secret, err := getKVSecret(ibm, &secretName, ref)
+		kvSecret, err := getSecretByType(ibm, &secretName, sm.CreateSecretOptionsSecretTypeKvConst)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := getKVSecret(kvSecret, ref)
 		if err != nil {
 			return nil, err
 		}
@@ -613,6 +742,7 @@ func (ibm *providerIBM) NewClient(ctx context.Context, store esv1beta1.GenericSt
 	}
 
 	ibm.IBMClient = secretsManager
+	ibm.secretGroupID = ibmSpec.SecretGroupID
 	return ibm, nil
 }
 
@@ -701,3 +701,195 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func makeSecretResource(id, name, secretType, group string, labels []string, secretData map[string]interface{}) *sm.SecretResource {
+	return &sm.SecretResource{
+		ID:            utilpointer.StringPtr(id),
+		Name:          utilpointer.StringPtr(name),
+		SecretType:    utilpointer.StringPtr(secretType),
+		SecretGroupID: utilpointer.StringPtr(group),
+		Labels:        labels,
+		SecretData:    secretData,
+	}
+}
+
+func makeFindSecrets() []*sm.SecretResource {
+	return []*sm.SecretResource{
+		makeSecretResource("1", "db-password", sm.GetSecretOptionsSecretTypeArbitraryConst, "group-a",
+			[]string{"env:prod", "team:backend"}, map[string]interface{}{"payload": "s3cr3t"}),
+		makeSecretResource("2", "db-user", sm.CreateSecretOptionsSecretTypeUsernamePasswordConst, "group-a",
+			[]string{"env:prod"}, map[string]interface{}{"username": "user", "password": "pass"}),
+		makeSecretResource("3", "db-config", sm.CreateSecretOptionsSecretTypeKvConst, "group-b",
+			[]string{"env:dev", "shared"}, map[string]interface{}{"payload": map[string]interface{}{"host": "localhost"}}),
+		makeSecretResource("4", "api-key", sm.GetSecretOptionsSecretTypeArbitraryConst, "group-b",
+			[]string{"env:prod"}, map[string]interface{}{"payload": "key"}),
+		makeSecretResource("5", "tls", sm.CreateSecretOptionsSecretTypePrivateCertConst, "group-c",
+			nil, map[string]interface{}{"certificate": "cert", "private_key": "key", "issuing_ca": "ca", "ca_chain": []interface{}{"ca"}}),
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	tbl := []struct {
+		name          string
+		secretGroupID string
+		ref           esv1beta1.ExternalSecretFind
+		expected      map[string][]byte
+		expectError   string
+	}{
+		{
+			name: "find by name",
+			ref:  esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^db-"}},
+			expected: map[string][]byte{
+				"db-password":      []byte("s3cr3t"),
+				"db-user.username": []byte("user"),
+				"db-user.password": []byte("pass"),
+				"db-config":        []byte(`{"host":"localhost"}`),
+			},
+		},
+		{
+			name: "find by tags",
+			ref:  esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod", "team": "backend"}},
+			expected: map[string][]byte{
+				"db-password": []byte("s3cr3t"),
+			},
+		},
+		{
+			name: "find by tag without value",
+			ref:  esv1beta1.ExternalSecretFind{Tags: map[string]string{"shared": ""}},
+			expected: map[string][]byte{
+				"db-config": []byte(`{"host":"localhost"}`),
+			},
+		},
+		{
+			name: "find by path",
+			ref: esv1beta1.ExternalSecretFind{
+				Path: utilpointer.StringPtr("group-b"),
+				Tags: map[string]string{"env": "prod"},
+			},
+			expected: map[string][]byte{
+				"api-key": []byte("key"),
+			},
+		},
+		{
+			name:          "find in default secret group",
+			secretGroupID: "group-a",
+			ref:           esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expected: map[string][]byte{
+				"db-password":      []byte("s3cr3t"),
+				"db-user.username": []byte("user"),
+				"db-user.password": []byte("pass"),
+			},
+		},
+		{
+			name: "find certificate returns string properties",
+			ref:  esv1beta1.ExternalSecretFind{Path: utilpointer.StringPtr("group-c")},
+			expected: map[string][]byte{
+				"tls.certificate": []byte("cert"),
+				"tls.private_key": []byte("key"),
+				"tls.issuing_ca":  []byte("ca"),
+			},
+		},
+		{
+			name:        "invalid regexp",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "["}},
+			expectError: "could not compile find.name.regexp",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			mockClient := &fakesm.IBMMockClient{}
+			mockClient.WithSecrets(makeFindSecrets())
+			p := providerIBM{IBMClient: mockClient, secretGroupID: row.secretGroupID}
+			out, err := p.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expected) {
+				t.Errorf("unexpected secret data: expected %#v, got %#v", row.expected, out)
+			}
+			// every matching secret is fetched once, whatever the number of its properties
+			names := make(map[string]bool)
+			for key := range row.expected {
+				names[strings.SplitN(key, propertySeparator, 2)[0]] = true
+			}
+			if mockClient.GetSecretCalls != len(names) {
+				t.Errorf("unexpected number of GetSecret calls: %d (expected %d)", mockClient.GetSecretCalls, len(names))
+			}
+		})
+	}
+}
+
+func TestGetAllSecretsPagination(t *testing.T) {
+	var secrets []*sm.SecretResource
+	for i := 0; i < listSecretsLimit+10; i++ {
+		id := fmt.Sprintf("%d", i)
+		secrets = append(secrets, makeSecretResource(id, "secret-"+id, sm.GetSecretOptionsSecretTypeArbitraryConst, "group",
+			nil, map[string]interface{}{"payload": id}))
+	}
+	mockClient := &fakesm.IBMMockClient{}
+	mockClient.WithSecrets(secrets)
+	p := providerIBM{IBMClient: mockClient}
+	out, err := p.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(secrets) {
+		t.Errorf("expected %d secrets, got %d", len(secrets), len(out))
+	}
+}
+
+func TestGetSecretMetadata(t *testing.T) {
+	secret := makeSecretResource("1", "db-password", sm.GetSecretOptionsSecretTypeArbitraryConst, "group-a",
+		[]string{"env:prod"}, map[string]interface{}{"payload": "s3cr3t"})
+	secret.CustomMetadata = map[string]interface{}{"owner": "backend", "rotation": map[string]interface{}{"days": float64(30)}}
+	kvSecret := makeSecretResource("3", "db-config", sm.CreateSecretOptionsSecretTypeKvConst, "group-b",
+		nil, map[string]interface{}{"payload": map[string]interface{}{"host": "localhost"}})
+	kvSecret.CustomMetadata = map[string]interface{}{"owner": "frontend", "replicas": float64(2)}
+	mockClient := &fakesm.IBMMockClient{}
+	mockClient.WithSecrets([]*sm.SecretResource{secret, kvSecret})
+	p := providerIBM{IBMClient: mockClient}
+
+	out, err := p.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "1",
+		Property:       "owner",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != "backend" {
+		t.Errorf("unexpected metadata value: %s", out)
+	}
+
+	out, err = p.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "1",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"owner":"backend","rotation":{"days":30}}` {
+		t.Errorf("unexpected metadata: %s", out)
+	}
+
+	_, err = p.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "1",
+		Property:       "env",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	if !ErrorContains(err, "metadata env does not exist in secret 1") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	outMap, err := p.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            "kv/3",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string][]byte{"owner": []byte("frontend"), "replicas": []byte("2")}
+	if !reflect.DeepEqual(outMap, expected) {
+		t.Errorf("unexpected metadata: expected %#v, got %#v", expected, outMap)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infisical
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errAPIRequest = "infisical request for %q failed: %w"
+	errAPIStatus  = "infisical request for %q failed with status %d: %s"
+	errAPIDecode  = "could not decode infisical response for %q: %w"
+
+	defaultHostAPI = "https://app.infisical.com"
+)
+
+// apiClient reads the secrets of an environment with the raw secrets endpoints
+// of the v3 Infisical API, which return the values decrypted.
+type apiClient struct {
+	client      *http.Client
+	url         string
+	token       string
+	projectID   string
+	environment string
+}
+
+type secret struct {
+	SecretKey   string `json:"secretKey"`
+	SecretValue string `json:"secretValue"`
+}
+
+type secretResponse struct {
+	Secret secret `json:"secret"`
+}
+
+type secretsResponse struct {
+	Secrets []secret `json:"secrets"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// getSecret returns the value of the shared secret in the folder.
+// ok is false if the secret does not exist.
+func (c *apiClient) getSecret(ctx context.Context, folder, name string) (value string, ok bool, err error) {
+	query := c.query(folder)
+	query.Set("type", "shared")
+	var resp secretResponse
+	ok, err = c.get(ctx, "/api/v3/secrets/raw/"+url.PathEscape(name), query, &resp)
+	return resp.Secret.SecretValue, ok, err
+}
+
+// listSecrets returns the secrets in the folder, without those of subfolders.
+// ok is false if the folder does not exist.
+func (c *apiClient) listSecrets(ctx context.Context, folder string) (secrets []secret, ok bool, err error) {
+	var resp secretsResponse
+	ok, err = c.get(ctx, "/api/v3/secrets/raw", c.query(folder), &resp)
+	return resp.Secrets, ok, err
+}
+
+func (c *apiClient) query(folder string) url.Values {
+	query := url.Values{}
+	query.Set("workspaceId", c.projectID)
+	query.Set("environment", c.environment)
+	query.Set("secretPath", folder)
+	return query
+}
+
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, out interface{}) (bool, error) {
+	reqURL := strings.TrimSuffix(c.url, "/") + path + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return false, fmt.Errorf(errAPIRequest, path, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf(errAPIRequest, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf(errAPIStatus, path, resp.StatusCode, errorMessage(resp.Body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf(errAPIDecode, path, err)
+	}
+	return true, nil
+}
+
+// errorMessage returns the message of an Infisical error response, or the raw body
+// if it is not one.
+func errorMessage(body io.Reader) string {
+	raw := utils.ReadErrorBody(body)
+	var resp errorResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err == nil && resp.Message != "" {
+		return resp.Message
+	}
+	return raw
+}
@@ -0,0 +1,148 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const secretsPath = "/api/v3/secrets/raw"
+
+// Server is an http.Handler standing in for the raw secrets endpoints of the
+// Infisical API. It accepts a single service token, with access to one
+// environment of one project.
+type Server struct {
+	token       string
+	projectID   string
+	environment string
+
+	mu       sync.Mutex
+	folders  map[string]map[string]string
+	requests []*http.Request
+}
+
+type secret struct {
+	ID          string `json:"id"`
+	Workspace   string `json:"workspace"`
+	Environment string `json:"environment"`
+	Type        string `json:"type"`
+	SecretKey   string `json:"secretKey"`
+	SecretValue string `json:"secretValue"`
+}
+
+// NewServer returns an Infisical API accepting the token for the environment of the project.
+func NewServer(token, projectID, environment string) *Server {
+	return &Server{
+		token:       token,
+		projectID:   projectID,
+		environment: environment,
+		folders:     make(map[string]map[string]string),
+	}
+}
+
+// Put stores the secret in the folder, e.g: "/" or "/app/db".
+func (s *Server) Put(folder, name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	folder = path.Clean(folder)
+	if s.folders[folder] == nil {
+		s.folders[folder] = make(map[string]string)
+	}
+	s.folders[folder][name] = value
+}
+
+// Requests returns the requests received by the server.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request{}, s.requests...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+	query := r.URL.Query()
+	if query.Get("workspaceId") != s.projectID || query.Get("environment") != s.environment {
+		writeError(w, http.StatusForbidden, "You are not allowed to access this resource")
+		return
+	}
+	folder, ok := s.folders[path.Clean(query.Get("secretPath"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Folder not found")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == secretsPath:
+		secrets := make([]secret, 0, len(folder))
+		for name, value := range folder {
+			secrets = append(secrets, s.secret(name, value))
+		}
+		sort.Slice(secrets, func(i, j int) bool { return secrets[i].SecretKey < secrets[j].SecretKey })
+		writeJSON(w, map[string]interface{}{"secrets": secrets})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, secretsPath+"/"):
+		name, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), secretsPath+"/"))
+		if err != nil || query.Get("type") != "shared" {
+			writeError(w, http.StatusBadRequest, "Bad request")
+			return
+		}
+		value, ok := folder[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Secret not found")
+			return
+		}
+		writeJSON(w, map[string]interface{}{"secret": s.secret(name, value)})
+	default:
+		writeError(w, http.StatusNotFound, "Route not found")
+	}
+}
+
+func (s *Server) secret(name, value string) secret {
+	return secret{
+		ID:          name,
+		Workspace:   s.projectID,
+		Environment: s.environment,
+		Type:        "shared",
+		SecretKey:   name,
+		SecretValue: value,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"statusCode": status,
+		"message":    message,
+		"error":      http.StatusText(status),
+	})
+}
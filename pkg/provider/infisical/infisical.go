@@ -0,0 +1,218 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infisical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingStore        = "missing store provider infisical"
+	errInvalidHostAPI      = "invalid infisical host %q: %w"
+	errMissingProjectID    = "infisical projectID must be set"
+	errMissingEnvironment  = "infisical environment must be set"
+	errMissingToken        = "missing serviceToken secret name or key"
+	errPropertyNotFound    = "property %s not found in secret %s"
+	errFindTagsUnsupported = "infisical secrets can only be found by name and path, find.tags is not supported"
+)
+
+var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.SecretsClient = &Client{}
+
+// Provider satisfies the provider interface.
+type Provider struct{}
+
+// Client reads the secrets of an Infisical environment.
+type Client struct {
+	api             *apiClient
+	secretPath      string
+	nameTransformer esv1beta1.SecretNameTransformer
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Infisical: &esv1beta1.InfisicalProvider{},
+	})
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	token, err := utils.FetchSecretKey(ctx, kube, store.GetObjectKind().GroupVersionKind().Kind, namespace, &provider.Auth.SecretRef.ServiceToken)
+	if err != nil {
+		return nil, err
+	}
+	hostAPI := provider.HostAPI
+	if hostAPI == "" {
+		hostAPI = defaultHostAPI
+	}
+	return &Client{
+		api: &apiClient{
+			client:      &http.Client{},
+			url:         hostAPI,
+			token:       strings.TrimSpace(string(token)),
+			projectID:   provider.ProjectID,
+			environment: provider.Environment,
+		},
+		secretPath:      path.Join("/", provider.SecretPath),
+		nameTransformer: provider.NameTransformer,
+	}, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
+	provider, err := getProvider(store)
+	if err != nil {
+		return err
+	}
+	if provider.HostAPI != "" {
+		if _, err := url.Parse(provider.HostAPI); err != nil {
+			return fmt.Errorf(errInvalidHostAPI, provider.HostAPI, err)
+		}
+	}
+	if provider.ProjectID == "" {
+		return errors.New(errMissingProjectID)
+	}
+	if provider.Environment == "" {
+		return errors.New(errMissingEnvironment)
+	}
+	tokenRef := provider.Auth.SecretRef.ServiceToken
+	if tokenRef.Name == "" || tokenRef.Key == "" {
+		return errors.New(errMissingToken)
+	}
+	if err := utils.ValidateSecretSelector(store, tokenRef); err != nil {
+		return err
+	}
+	return utils.ValidateNameTransformer(provider.NameTransformer)
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.InfisicalProvider, error) {
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Infisical == nil {
+		return nil, errors.New(errMissingStore)
+	}
+	return spc.Provider.Infisical, nil
+}
+
+// GetSecret returns the value of the secret. The key is the name of the secret,
+// optionally prefixed by its folder, e.g: /app/db/PASSWORD. If property is set the
+// value is parsed as JSON and the property is resolved as gjson path.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	folder, name := path.Split(ref.Key)
+	value, ok, err := c.api.getSecret(ctx, c.folder(folder), name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	if ref.Property == "" {
+		return []byte(value), nil
+	}
+	val := gjson.Get(value, ref.Property)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns all secrets of the folder named key, or of the store's
+// secretPath if key is empty. The names are renamed with the store's name transformer.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	secrets, ok, err := c.api.listSecrets(ctx, c.folder(ref.Key))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	return utils.TransformKeys(c.nameTransformer, toSecretMap(secrets, nil))
+}
+
+// GetAllSecrets returns the secrets of the folder find.path, or of the store's
+// secretPath, with names matching find.name.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errFindTagsUnsupported)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	folder := ""
+	if ref.Path != nil {
+		folder = *ref.Path
+	}
+	secrets, ok, err := c.api.listSecrets(ctx, c.folder(folder))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	secretMap, err := utils.TransformKeys(c.nameTransformer, toSecretMap(secrets, matcher))
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
+
+// folder returns the absolute path of a folder. Relative paths are resolved
+// against the store's secretPath.
+func (c *Client) folder(p string) string {
+	if strings.HasPrefix(p, "/") {
+		return path.Clean(p)
+	}
+	return path.Join(c.secretPath, p)
+}
+
+// toSecretMap returns the secrets with names matching the matcher, or all secrets
+// if the matcher is nil.
+func toSecretMap(secrets []secret, matcher *find.Matcher) map[string][]byte {
+	secretMap := make(map[string][]byte, len(secrets))
+	for _, s := range secrets {
+		if matcher != nil && !matcher.MatchName(s.SecretKey) {
+			continue
+		}
+		secretMap[s.SecretKey] = []byte(s.SecretValue)
+	}
+	return secretMap
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	return nil
+}
+
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultUnknown, nil
+}
@@ -0,0 +1,352 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infisical
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/infisical/fake"
+)
+
+const (
+	testNamespace   = "default"
+	testToken       = "st.service-token"
+	testProjectID   = "6515c8a8e3f4c1a3b2d1e0f9"
+	testEnvironment = "prod"
+)
+
+func TestGetSecret(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	defer server.Close()
+	c := &Client{api: newTestAPI(server), secretPath: "/app"}
+
+	tbl := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "secret in store path",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "API_TOKEN"},
+			expValue: "t0ken",
+		},
+		{
+			name:     "secret in relative folder",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db/DB_PASSWORD"},
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "secret in absolute folder",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "/SENTRY_DSN"},
+			expValue: "https://sentry",
+		},
+		{
+			name:     "property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "CONFIG", Property: "port"},
+			expValue: "8080",
+		},
+		{
+			name:   "missing property",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "CONFIG", Property: "host"},
+			expErr: fmt.Sprintf(errPropertyNotFound, "host", "CONFIG"),
+		},
+		{
+			name:   "missing secret",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "db/DB_USER"},
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+		{
+			name:   "missing folder",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "cache/REDIS_PASSWORD"},
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecret(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	defer server.Close()
+
+	tbl := []struct {
+		name        string
+		key         string
+		transformer esv1beta1.SecretNameTransformer
+		expValue    map[string][]byte
+		expErr      string
+	}{
+		{
+			name:     "store path",
+			expValue: map[string][]byte{"API_TOKEN": []byte("t0ken"), "CONFIG": []byte(`{"port":8080}`)},
+		},
+		{
+			name:     "relative folder",
+			key:      "db",
+			expValue: map[string][]byte{"DB_PASSWORD": []byte("s3cr3t")},
+		},
+		{
+			name:     "absolute folder",
+			key:      "/",
+			expValue: map[string][]byte{"SENTRY_DSN": []byte("https://sentry")},
+		},
+		{
+			name:        "lower-snake",
+			transformer: esv1beta1.SecretNameTransformerLowerSnake,
+			expValue:    map[string][]byte{"api_token": []byte("t0ken"), "config": []byte(`{"port":8080}`)},
+		},
+		{
+			name:   "missing folder",
+			key:    "cache",
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			c := &Client{api: newTestAPI(server), secretPath: "/app", nameTransformer: row.transformer}
+			out, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: row.key})
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expValue) {
+				t.Errorf("unexpected secrets: %v, expected: %v", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	defer server.Close()
+
+	db := "db"
+	tbl := []struct {
+		name        string
+		ref         esv1beta1.ExternalSecretFind
+		transformer esv1beta1.SecretNameTransformer
+		expValue    map[string][]byte
+		expErr      string
+	}{
+		{
+			name:     "find by name",
+			ref:      esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "TOKEN$"}},
+			expValue: map[string][]byte{"API_TOKEN": []byte("t0ken")},
+		},
+		{
+			name:     "find by path",
+			ref:      esv1beta1.ExternalSecretFind{Path: &db},
+			expValue: map[string][]byte{"DB_PASSWORD": []byte("s3cr3t")},
+		},
+		{
+			name:        "find with transformer",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "TOKEN$"}},
+			transformer: esv1beta1.SecretNameTransformerUpperCamel,
+			expValue:    map[string][]byte{"ApiToken": []byte("t0ken")},
+		},
+		{
+			name:   "find by tags",
+			ref:    esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expErr: errFindTagsUnsupported,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			c := &Client{api: newTestAPI(server), secretPath: "/app", nameTransformer: row.transformer}
+			row.ref.ConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+			out, err := c.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expValue) {
+				t.Errorf("unexpected secrets: %v, expected: %v", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	server := httptest.NewServer(newTestServer())
+	defer server.Close()
+
+	tbl := []struct {
+		name        string
+		token       string
+		environment string
+		expErr      string
+	}{
+		{
+			name:        "token with trailing newline",
+			token:       testToken + "\n",
+			environment: testEnvironment,
+		},
+		{
+			name:        "invalid token",
+			token:       "other-token",
+			environment: testEnvironment,
+			expErr:      "failed with status 401: Invalid token",
+		},
+		{
+			name:        "forbidden environment",
+			token:       testToken,
+			environment: "dev",
+			expErr:      "failed with status 403: You are not allowed to access this resource",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "infisical", Namespace: testNamespace},
+				Data:       map[string][]byte{"token": []byte(row.token)},
+			}).Build()
+			store := &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Infisical: &esv1beta1.InfisicalProvider{
+					HostAPI:     server.URL,
+					ProjectID:   testProjectID,
+					Environment: row.environment,
+					SecretPath:  "/app",
+					Auth:        esv1beta1.InfisicalAuth{SecretRef: esv1beta1.InfisicalAuthSecretRef{ServiceToken: esmeta.SecretKeySelector{Name: "infisical", Key: "token"}}},
+				}}},
+			}
+			c, err := (&Provider{}).NewClient(context.Background(), store, kube, testNamespace)
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "API_TOKEN"})
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	namespace := testNamespace
+	auth := esv1beta1.InfisicalAuth{SecretRef: esv1beta1.InfisicalAuthSecretRef{
+		ServiceToken: esmeta.SecretKeySelector{Name: "infisical", Key: "token"},
+	}}
+	namespacedAuth := esv1beta1.InfisicalAuth{SecretRef: esv1beta1.InfisicalAuthSecretRef{
+		ServiceToken: esmeta.SecretKeySelector{Name: "infisical", Key: "token", Namespace: &namespace},
+	}}
+	tbl := []struct {
+		name     string
+		kind     string
+		provider esv1beta1.InfisicalProvider
+		expErr   string
+	}{
+		{
+			name:     "missing projectID",
+			provider: esv1beta1.InfisicalProvider{Environment: testEnvironment, Auth: auth},
+			expErr:   errMissingProjectID,
+		},
+		{
+			name:     "missing environment",
+			provider: esv1beta1.InfisicalProvider{ProjectID: testProjectID, Auth: auth},
+			expErr:   errMissingEnvironment,
+		},
+		{
+			name:     "missing token",
+			provider: esv1beta1.InfisicalProvider{ProjectID: testProjectID, Environment: testEnvironment},
+			expErr:   errMissingToken,
+		},
+		{
+			name:     "invalid host",
+			provider: esv1beta1.InfisicalProvider{HostAPI: "http://infisical:port", ProjectID: testProjectID, Environment: testEnvironment, Auth: auth},
+			expErr:   "invalid infisical host",
+		},
+		{
+			name:     "namespace on SecretStore token",
+			provider: esv1beta1.InfisicalProvider{ProjectID: testProjectID, Environment: testEnvironment, Auth: namespacedAuth},
+			expErr:   "namespace not allowed with namespaced SecretStore",
+		},
+		{
+			name:     "missing namespace on ClusterSecretStore token",
+			kind:     esv1beta1.ClusterSecretStoreKind,
+			provider: esv1beta1.InfisicalProvider{ProjectID: testProjectID, Environment: testEnvironment, Auth: auth},
+			expErr:   "cluster scope requires namespace",
+		},
+		{
+			name:     "valid",
+			provider: esv1beta1.InfisicalProvider{ProjectID: testProjectID, Environment: testEnvironment, SecretPath: "/app", Auth: auth},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			var store esv1beta1.GenericStore = &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Infisical: &row.provider}},
+			}
+			if row.kind == esv1beta1.ClusterSecretStoreKind {
+				store = &esv1beta1.ClusterSecretStore{
+					TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+					Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Infisical: &row.provider}},
+				}
+			}
+			err := (&Provider{}).ValidateStore(store)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func newTestServer() *fake.Server {
+	api := fake.NewServer(testToken, testProjectID, testEnvironment)
+	api.Put("/", "SENTRY_DSN", "https://sentry")
+	api.Put("/app", "API_TOKEN", "t0ken")
+	api.Put("/app", "CONFIG", `{"port":8080}`)
+	api.Put("/app/db", "DB_PASSWORD", "s3cr3t")
+	return api
+}
+
+func newTestAPI(server *httptest.Server) *apiClient {
+	return &apiClient{
+		client:      server.Client(),
+		url:         server.URL,
+		token:       testToken,
+		projectID:   testProjectID,
+		environment: testEnvironment,
+	}
+}
+
+func ErrorContains(out error, want string) bool {
+	if out == nil {
+		return want == ""
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(out.Error(), want)
+}
@@ -17,14 +17,18 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -32,15 +36,32 @@ import (
 )
 
 const (
-	errPropertyNotFound                    = "property field not found on extrenal secrets"
-	errKubernetesCredSecretName            = "kubernetes credentials are empty"
-	errInvalidClusterStoreMissingNamespace = "invalid clusterStore missing Cert namespace"
-	errFetchCredentialsSecret              = "could not fetch Credentials secret: %w"
-	errMissingCredentials                  = "missing Credentials: %v"
-	errUninitalizedKubernetesProvider      = "provider kubernetes is not initialized"
-	errEmptyKey                            = "key %s found but empty"
+	errPropertyNotFound                      = "property field not found on extrenal secrets"
+	errKubernetesCredSecretName              = "kubernetes credentials are empty"
+	errInvalidClusterStoreMissingNamespace   = "invalid clusterStore missing Cert namespace"
+	errFetchCredentialsSecret                = "could not fetch Credentials secret: %w"
+	errMissingCredentials                    = "missing Credentials: %v"
+	errUninitalizedKubernetesProvider        = "provider kubernetes is not initialized"
+	errEmptyKey                              = "key %s found but empty"
+	errRemoteServerRequiresCA                = "a CABundle or CAProvider is required for server %s"
+	errInvalidClusterStoreMissingSANamespace = "invalid clusterStore missing ServiceAccount namespace"
+	errServiceAccountToken                   = "could not request token for service account %s: %w"
+	errMissingImpersonateUserName            = "Impersonate.UserName cannot be empty"
+
+	configMapKeyPrefix = "configmap/"
+	secretKeyPrefix    = "secret/"
+
+	// the token of the referenced service account is only used to create a client once.
+	serviceAccountTokenExpirationSeconds = 600
 )
 
+// inClusterURLs are the server urls of the cluster the operator runs in.
+var inClusterURLs = map[string]bool{
+	"":                           true,
+	"kubernetes.default":         true,
+	"https://kubernetes.default": true,
+}
+
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &ProviderKubernetes{}
 var _ esv1beta1.Provider = &ProviderKubernetes{}
@@ -49,15 +70,20 @@ type KClient interface {
 	Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Secret, error)
 }
 
+type CMClient interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error)
+}
+
 type RClient interface {
 	Create(ctx context.Context, SelfSubjectAccessReview *authv1.SelfSubjectAccessReview, opts metav1.CreateOptions) (*authv1.SelfSubjectAccessReview, error)
 }
 
 // ProviderKubernetes is a provider for Kubernetes.
 type ProviderKubernetes struct {
-	Client       KClient
-	ReviewClient RClient
-	Namespace    string
+	Client          KClient
+	ConfigMapClient CMClient
+	ReviewClient    RClient
+	Namespace       string
 }
 
 var _ esv1beta1.SecretsClient = &ProviderKubernetes{}
@@ -67,6 +93,7 @@ type BaseClient struct {
 	store       *esv1beta1.KubernetesProvider
 	namespace   string
 	storeKind   string
+	coreV1      typedcorev1.CoreV1Interface
 	Certificate []byte
 	Key         []byte
 	CA          []byte
@@ -81,6 +108,20 @@ func init() {
 
 // NewClient constructs a Kubernetes Provider.
 func (k *ProviderKubernetes) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	// the config of the operator is used for the cluster it runs in and
+	// to request service account tokens, which controller-runtime/client does not support
+	restCfg, err := ctrlcfg.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, err
+	}
+	return k.newClient(ctx, store, kube, restCfg, clientset.CoreV1(), namespace)
+}
+
+func (k *ProviderKubernetes) newClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, restCfg *rest.Config, coreV1 typedcorev1.CoreV1Interface, namespace string) (esv1beta1.SecretsClient, error) {
 	storeSpec := store.GetSpec()
 	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Kubernetes == nil {
 		return nil, fmt.Errorf("no store type or wrong store type")
@@ -92,29 +133,21 @@ func (k *ProviderKubernetes) NewClient(ctx context.Context, store esv1beta1.Gene
 		store:     storeSpecKubernetes,
 		namespace: namespace,
 		storeKind: store.GetObjectKind().GroupVersionKind().Kind,
+		coreV1:    coreV1,
 	}
 
-	if err := bStore.setAuth(ctx); err != nil {
+	config, err := bStore.getConfig(ctx, restCfg)
+	if err != nil {
 		return nil, err
 	}
 
-	config := &rest.Config{
-		Host:        bStore.store.Server.URL,
-		BearerToken: string(bStore.BearerToken),
-		TLSClientConfig: rest.TLSClientConfig{
-			Insecure: false,
-			CertData: bStore.Certificate,
-			KeyData:  bStore.Key,
-			CAData:   bStore.CA,
-		},
-	}
-
 	kubeClientSet, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring clientset: %w", err)
 	}
 
 	k.Client = kubeClientSet.CoreV1().Secrets(bStore.store.RemoteNamespace)
+	k.ConfigMapClient = kubeClientSet.CoreV1().ConfigMaps(bStore.store.RemoteNamespace)
 	k.Namespace = bStore.store.RemoteNamespace
 	k.ReviewClient = kubeClientSet.AuthorizationV1().SelfSubjectAccessReviews()
 
@@ -143,12 +176,17 @@ func (k *ProviderKubernetes) GetSecret(ctx context.Context, ref esv1beta1.Extern
 	return val, nil
 }
 
+// GetSecretMap returns the data of the Secret named key. A key prefixed with
+// configmap/ reads the data of a ConfigMap instead, secret/ is optional.
 func (k *ProviderKubernetes) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if strings.HasPrefix(ref.Key, configMapKeyPrefix) {
+		return k.getConfigMap(ctx, strings.TrimPrefix(ref.Key, configMapKeyPrefix))
+	}
 	if utils.IsNil(k.Client) {
 		return nil, fmt.Errorf(errUninitalizedKubernetesProvider)
 	}
 	opts := metav1.GetOptions{}
-	secretOut, err := k.Client.Get(ctx, ref.Key, opts)
+	secretOut, err := k.Client.Get(ctx, strings.TrimPrefix(ref.Key, secretKeyPrefix), opts)
 
 	if err != nil {
 		return nil, err
@@ -162,10 +200,78 @@ func (k *ProviderKubernetes) GetSecretMap(ctx context.Context, ref esv1beta1.Ext
 	return payload, nil
 }
 
+func (k *ProviderKubernetes) getConfigMap(ctx context.Context, name string) (map[string][]byte, error) {
+	if utils.IsNil(k.ConfigMapClient) {
+		return nil, fmt.Errorf(errUninitalizedKubernetesProvider)
+	}
+	configMap, err := k.ConfigMapClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var payload map[string][]byte
+	if len(configMap.Data)+len(configMap.BinaryData) != 0 {
+		payload = make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+		for key, val := range configMap.BinaryData {
+			payload[key] = val
+		}
+		for key, val := range configMap.Data {
+			payload[key] = []byte(val)
+		}
+	}
+
+	return payload, nil
+}
+
 func (k *ProviderKubernetes) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
+// getConfig returns the config of the remote server, or of the cluster the
+// operator runs in if the server has no CA. Only the server and CA are taken
+// from the operator's config, the credentials are always those of auth.
+func (k *BaseClient) getConfig(ctx context.Context, restCfg *rest.Config) (*rest.Config, error) {
+	var config *rest.Config
+	if isInCluster(k.store.Server) {
+		if err := k.setCredentials(ctx); err != nil {
+			return nil, err
+		}
+		config = rest.AnonymousClientConfig(restCfg)
+		config.BearerToken = string(k.BearerToken)
+		config.TLSClientConfig.CertData = k.Certificate
+		config.TLSClientConfig.KeyData = k.Key
+	} else {
+		if err := k.setAuth(ctx); err != nil {
+			return nil, err
+		}
+		config = &rest.Config{
+			Host:        k.store.Server.URL,
+			BearerToken: string(k.BearerToken),
+			TLSClientConfig: rest.TLSClientConfig{
+				Insecure: false,
+				CertData: k.Certificate,
+				KeyData:  k.Key,
+				CAData:   k.CA,
+			},
+		}
+	}
+
+	if impersonate := k.store.Server.Impersonate; impersonate != nil {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: impersonate.UserName,
+			UID:      impersonate.UID,
+			Groups:   impersonate.Groups,
+			Extra:    impersonate.Extra,
+		}
+	}
+	return config, nil
+}
+
+// isInCluster reports whether the server is the cluster the operator runs in.
+func isInCluster(server esv1beta1.KubernetesServer) bool {
+	return len(server.CABundle) == 0 && server.CAProvider == nil && inClusterURLs[server.URL]
+}
+
 func (k *BaseClient) setAuth(ctx context.Context) error {
 	var err error
 	if len(k.store.Server.CABundle) > 0 {
@@ -184,13 +290,21 @@ func (k *BaseClient) setAuth(ctx context.Context) error {
 		return fmt.Errorf("no Certificate Authority provided")
 	}
 
+	return k.setCredentials(ctx)
+}
+
+func (k *BaseClient) setCredentials(ctx context.Context) error {
+	var err error
 	if k.store.Auth.Token != nil {
 		k.BearerToken, err = k.fetchSecretKey(ctx, k.store.Auth.Token.BearerToken, "bearerToken")
 		if err != nil {
 			return err
 		}
 	} else if k.store.Auth.ServiceAccount != nil {
-		return fmt.Errorf("not implemented yet")
+		k.BearerToken, err = k.serviceAccountToken(ctx, k.store.Auth.ServiceAccount.ServiceAccountRef)
+		if err != nil {
+			return err
+		}
 	} else if k.store.Auth.Cert != nil {
 		k.Certificate, err = k.fetchSecretKey(ctx, k.store.Auth.Cert.ClientCert, "cert")
 		if err != nil {
@@ -207,6 +321,29 @@ func (k *BaseClient) setAuth(ctx context.Context) error {
 	return nil
 }
 
+// serviceAccountToken requests a token for the service account from the
+// cluster the operator runs in.
+func (k *BaseClient) serviceAccountToken(ctx context.Context, ref esmeta.ServiceAccountSelector) ([]byte, error) {
+	namespace := k.namespace
+	// only ClusterStore is allowed to set namespace (and then it's required)
+	if k.storeKind == esv1beta1.ClusterSecretStoreKind {
+		if ref.Namespace == nil {
+			return nil, fmt.Errorf(errInvalidClusterStoreMissingSANamespace)
+		}
+		namespace = *ref.Namespace
+	}
+	expirationSeconds := int64(serviceAccountTokenExpirationSeconds)
+	tokenRequest, err := k.coreV1.ServiceAccounts(namespace).CreateToken(ctx, ref.Name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf(errServiceAccountToken, ref.Name, err)
+	}
+	return []byte(tokenRequest.Status.Token), nil
+}
+
 func (k *BaseClient) fetchSecretKey(ctx context.Context, key esmeta.SecretKeySelector, component string) ([]byte, error) {
 	keySecret := &corev1.Secret{}
 	keySecretName := key.Name
@@ -268,8 +405,11 @@ func (k *ProviderKubernetes) Validate() (esv1beta1.ValidationResult, error) {
 func (k *ProviderKubernetes) ValidateStore(store esv1beta1.GenericStore) error {
 	storeSpec := store.GetSpec()
 	k8sSpec := storeSpec.Provider.Kubernetes
-	if k8sSpec.Server.CABundle == nil && k8sSpec.Server.CAProvider == nil {
-		return fmt.Errorf("a CABundle or CAProvider is required")
+	if k8sSpec.Server.CABundle == nil && k8sSpec.Server.CAProvider == nil && !isInCluster(k8sSpec.Server) {
+		return fmt.Errorf(errRemoteServerRequiresCA, k8sSpec.Server.URL)
+	}
+	if k8sSpec.Server.Impersonate != nil && k8sSpec.Server.Impersonate.UserName == "" {
+		return fmt.Errorf(errMissingImpersonateUserName)
 	}
 
 	if k8sSpec.Auth.Cert != nil {
@@ -292,11 +432,24 @@ func (k *ProviderKubernetes) ValidateStore(store esv1beta1.GenericStore) error {
 		if err := utils.ValidateSecretSelector(store, k8sSpec.Auth.Token.BearerToken); err != nil {
 			return err
 		}
+	} else if k8sSpec.Auth.ServiceAccount != nil {
+		if k8sSpec.Auth.ServiceAccount.ServiceAccountRef.Name == "" {
+			return fmt.Errorf("ServiceAccount.Name cannot be empty")
+		}
+		if err := utils.ValidateServiceAccountSelector(store, k8sSpec.Auth.ServiceAccount.ServiceAccountRef); err != nil {
+			return err
+		}
 	} else {
 		return fmt.Errorf("an Auth type must be specified")
 	}
 
-	if k8sSpec.Auth.Cert != nil && k8sSpec.Auth.Token != nil {
+	authMethods := 0
+	for _, set := range []bool{k8sSpec.Auth.Cert != nil, k8sSpec.Auth.Token != nil, k8sSpec.Auth.ServiceAccount != nil} {
+		if set {
+			authMethods++
+		}
+	}
+	if authMethods > 1 {
 		return fmt.Errorf("only one authentication method is allowed")
 	}
 
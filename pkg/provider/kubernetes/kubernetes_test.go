@@ -21,9 +21,14 @@ import (
 	"strings"
 	"testing"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 	fclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -50,6 +55,19 @@ func (fk fakeClient) Get(ctx context.Context, name string, opts metav1.GetOption
 	return &secret, nil
 }
 
+type fakeConfigMapClient struct {
+	configMapMap map[string]corev1.ConfigMap
+}
+
+func (fk fakeConfigMapClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error) {
+	configMap, ok := fk.configMapMap[name]
+
+	if !ok {
+		return nil, errors.New(errSomethingWentWrong)
+	}
+	return &configMap, nil
+}
+
 type fakeReviewClient struct {
 	authReview *authv1.SelfSubjectAccessReview
 }
@@ -155,7 +173,7 @@ func TestKubernetesSecretManagerSetAuth(t *testing.T) {
 	fs2.Data["key"] = []byte("secret-key")
 
 	fk := fclient.NewClientBuilder().WithObjects(fs, fs2).Build()
-	bc := BaseClient{fk, &kp, "", "", nil, nil, nil, nil}
+	bc := BaseClient{kube: fk, store: &kp}
 
 	ctx := context.Background()
 
@@ -273,10 +291,11 @@ func TestValidateStore(t *testing.T) {
 	}
 	secretName := "my-secret-name"
 	secretKey := "my-secert-key"
+	store.Spec.Provider.Kubernetes.Server.URL = "https://remote.cluster"
 	err := p.ValidateStore(store)
 	if err == nil {
 		t.Errorf(errExpectedErr)
-	} else if err.Error() != "a CABundle or CAProvider is required" {
+	} else if err.Error() != "a CABundle or CAProvider is required for server https://remote.cluster" {
 		t.Errorf("service CA test failed, got %v", err.Error())
 	}
 
@@ -353,6 +372,170 @@ func TestValidateStore(t *testing.T) {
 	} else if err.Error() != "only one authentication method is allowed" {
 		t.Errorf("KeySelector test failed: expected only one auth method allowed, got %v", err)
 	}
+	store.Spec.Provider.Kubernetes.Auth = esv1beta1.KubernetesAuth{ServiceAccount: &esv1beta1.ServiceAccountAuth{}}
+	err = p.ValidateStore(store)
+	if err == nil {
+		t.Errorf(errExpectedErr)
+	} else if err.Error() != "ServiceAccount.Name cannot be empty" {
+		t.Errorf("ServiceAccount test failed: expected service account name is required, got %v", err)
+	}
+	store.Spec.Provider.Kubernetes.Auth.ServiceAccount.ServiceAccountRef.Name = "reader"
+	store.Spec.Provider.Kubernetes.Server.Impersonate = &esv1beta1.KubernetesImpersonation{Groups: []string{"readers"}}
+	err = p.ValidateStore(store)
+	if err == nil {
+		t.Errorf(errExpectedErr)
+	} else if err.Error() != errMissingImpersonateUserName {
+		t.Errorf("Impersonate test failed: expected user name is required, got %v", err)
+	}
+	store.Spec.Provider.Kubernetes.Server = esv1beta1.KubernetesServer{
+		Impersonate: &esv1beta1.KubernetesImpersonation{UserName: "system:serviceaccount:team-a:reader"},
+	}
+	err = p.ValidateStore(store)
+	if err != nil {
+		t.Errorf("in-cluster test failed: expected no CA to be required, got %v", err)
+	}
+}
+
+func TestKubernetesSecretManagerGetConfigMap(t *testing.T) {
+	configMap := corev1.ConfigMap{
+		Data:       map[string]string{"foo": "bar"},
+		BinaryData: map[string][]byte{"blob": {0x00, 0x01}},
+	}
+	fk := fakeConfigMapClient{configMapMap: map[string]corev1.ConfigMap{"Key": configMap}}
+	kp := ProviderKubernetes{Client: fakeClient{}, ConfigMapClient: fk}
+	ctx := context.Background()
+
+	output, err := kp.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "configmap/Key"})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := map[string][]byte{"foo": []byte("bar"), "blob": {0x00, 0x01}}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("unexpected configmap data: %v", output)
+	}
+
+	value, err := kp.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "configmap/Key", Property: "foo"})
+	if err != nil || string(value) != "bar" {
+		t.Errorf("unexpected configmap property: '%s', %v", value, err)
+	}
+
+	_, err = kp.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "secret/Key"})
+	if err == nil || err.Error() != errSomethingWentWrong {
+		t.Errorf("expected a secret to be read with the secret/ prefix, got %v", err)
+	}
+
+	kp = ProviderKubernetes{Client: fakeClient{}}
+	_, err = kp.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "configmap/Key"})
+	if err == nil || err.Error() != errUninitalizedKubernetesProvider {
+		t.Errorf("test nil ConfigMapClient failed, got %v", err)
+	}
+}
+
+func TestKubernetesSecretManagerGetConfig(t *testing.T) {
+	controllerConfig := &rest.Config{
+		Host:        "https://10.0.0.1:443",
+		BearerToken: "controller-token",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAFile: "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt",
+		},
+	}
+	clientset := k8sfake.NewSimpleClientset()
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create := action.(k8stesting.CreateAction)
+		if create.GetSubresource() != "token" || create.GetNamespace() != "team-a" {
+			return true, nil, errors.New(errSomethingWentWrong)
+		}
+		return true, &authenticationv1.TokenRequest{
+			Status: authenticationv1.TokenRequestStatus{Token: "reader-token"},
+		}, nil
+	})
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("remote-token")},
+	}
+	kube := fclient.NewClientBuilder().WithObjects(tokenSecret).Build()
+	impersonate := &esv1beta1.KubernetesImpersonation{
+		UserName: "system:serviceaccount:team-b:reader",
+		Groups:   []string{"readers"},
+	}
+
+	tbl := []struct {
+		name     string
+		provider esv1beta1.KubernetesProvider
+		expHost  string
+		expToken string
+		expCA    string
+		expErr   string
+	}{
+		{
+			name: "in-cluster with service account",
+			provider: esv1beta1.KubernetesProvider{
+				Server: esv1beta1.KubernetesServer{URL: "kubernetes.default", Impersonate: impersonate},
+				Auth: esv1beta1.KubernetesAuth{
+					ServiceAccount: &esv1beta1.ServiceAccountAuth{ServiceAccountRef: v1.ServiceAccountSelector{Name: "reader"}},
+				},
+			},
+			expHost:  controllerConfig.Host,
+			expToken: "reader-token",
+		},
+		{
+			name: "remote with token",
+			provider: esv1beta1.KubernetesProvider{
+				Server: esv1beta1.KubernetesServer{URL: "https://remote.cluster", CABundle: []byte("ca"), Impersonate: impersonate},
+				Auth: esv1beta1.KubernetesAuth{
+					Token: &esv1beta1.TokenAuth{BearerToken: v1.SecretKeySelector{Name: "remote", Key: "token"}},
+				},
+			},
+			expHost:  "https://remote.cluster",
+			expToken: "remote-token",
+			expCA:    "ca",
+		},
+		{
+			name: "in-cluster without credentials",
+			provider: esv1beta1.KubernetesProvider{
+				Server: esv1beta1.KubernetesServer{},
+			},
+			expErr: "no credentials provided",
+		},
+		{
+			name: "remote without CA",
+			provider: esv1beta1.KubernetesProvider{
+				Server: esv1beta1.KubernetesServer{URL: "https://remote.cluster"},
+				Auth: esv1beta1.KubernetesAuth{
+					Token: &esv1beta1.TokenAuth{BearerToken: v1.SecretKeySelector{Name: "remote", Key: "token"}},
+				},
+			},
+			expErr: "no Certificate Authority provided",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			bc := BaseClient{kube: kube, store: &row.provider, namespace: "team-a", coreV1: clientset.CoreV1()}
+			config, err := bc.getConfig(context.Background(), controllerConfig)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if err != nil {
+				return
+			}
+			if config.Host != row.expHost {
+				t.Errorf("unexpected host: %s", config.Host)
+			}
+			if config.BearerToken != row.expToken {
+				t.Errorf("unexpected token: %s", config.BearerToken)
+			}
+			if row.expCA != "" && string(config.CAData) != row.expCA {
+				t.Errorf("unexpected CA: %s", config.CAData)
+			}
+			if row.expCA == "" && config.CAFile != controllerConfig.CAFile {
+				t.Errorf("unexpected CA file: %s", config.CAFile)
+			}
+			if config.Impersonate.UserName != impersonate.UserName || !reflect.DeepEqual(config.Impersonate.Groups, impersonate.Groups) {
+				t.Errorf("unexpected impersonation: %v", config.Impersonate)
+			}
+		})
+	}
 }
 
 func ErrorContains(out error, want string) bool {
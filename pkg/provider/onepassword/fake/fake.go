@@ -25,6 +25,7 @@ type OnePasswordMockClient struct {
 	MockVaults       map[string][]onepassword.Vault
 	MockItems        map[string][]onepassword.Item // ID and Title only
 	MockItemFields   map[string]map[string][]*onepassword.ItemField
+	MockItemVersions map[string]map[string]map[int][]*onepassword.ItemField
 	MockFileContents map[string][]byte
 }
 
@@ -34,6 +35,7 @@ func NewMockClient() *OnePasswordMockClient {
 		MockVaults:       map[string][]onepassword.Vault{},
 		MockItems:        map[string][]onepassword.Item{},
 		MockItemFields:   map[string]map[string][]*onepassword.ItemField{},
+		MockItemVersions: map[string]map[string]map[int][]*onepassword.ItemField{},
 		MockFileContents: map[string][]byte{},
 	}
 }
@@ -69,6 +71,24 @@ func (mockClient *OnePasswordMockClient) GetItem(itemUUID, vaultUUID string) (*o
 	return &onepassword.Item{}, errors.New("status 400: Invalid Item UUID")
 }
 
+// GetItemVersion returns a version of a *onepassword.Item, you must preload.
+func (mockClient *OnePasswordMockClient) GetItemVersion(itemUUID, vaultUUID string, version int) (*onepassword.Item, error) {
+	fields, ok := mockClient.MockItemVersions[vaultUUID][itemUUID][version]
+	if !ok {
+		return nil, errors.New("status 404: Invalid Item Version")
+	}
+	for _, item := range mockClient.MockItems[vaultUUID] {
+		if item.ID == itemUUID {
+			item.Fields = fields
+			item.Version = version
+
+			return &item, nil
+		}
+	}
+
+	return nil, errors.New("status 400: Invalid Item UUID")
+}
+
 // GetItems returns []onepassword.Item, you must preload.
 func (mockClient *OnePasswordMockClient) GetItems(vaultUUID string) ([]onepassword.Item, error) {
 	return mockClient.MockItems[vaultUUID], nil
@@ -177,6 +197,21 @@ func (mockClient *OnePasswordMockClient) AppendItemField(vaultID, itemID string,
 	return mockClient
 }
 
+// AppendItemVersion adds a previous version of an item with its fields to the mock client.
+func (mockClient *OnePasswordMockClient) AppendItemVersion(vaultID, itemID string, version int, itemFields ...onepassword.ItemField) *OnePasswordMockClient {
+	if mockClient.MockItemVersions[vaultID] == nil {
+		mockClient.MockItemVersions[vaultID] = make(map[string]map[int][]*onepassword.ItemField)
+	}
+	if mockClient.MockItemVersions[vaultID][itemID] == nil {
+		mockClient.MockItemVersions[vaultID][itemID] = make(map[int][]*onepassword.ItemField)
+	}
+	for i := range itemFields {
+		mockClient.MockItemVersions[vaultID][itemID][version] = append(mockClient.MockItemVersions[vaultID][itemID][version], &itemFields[i])
+	}
+
+	return mockClient
+}
+
 // SetFileContents adds file contents to the mock client.
 func (mockClient *OnePasswordMockClient) SetFileContents(name string, contents []byte) *OnePasswordMockClient {
 	// no need to test or mock same file names in different vaults, because we only GetFileContent after findItem, which already tests getting the right item from the right vault
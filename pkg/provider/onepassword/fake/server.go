@@ -0,0 +1,139 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var titleFilterRegexp = regexp.MustCompile(`^title eq (".*")$`)
+
+// NewServiceAccountServer returns a server which serves the vaults, items and files
+// of the mock client through the 1Password service account REST API.
+// Requests must be authenticated with the given bearer token.
+func NewServiceAccountServer(mockClient *OnePasswordMockClient, token string) *httptest.Server {
+	return httptest.NewServer(&serviceAccountHandler{mockClient: mockClient, token: token})
+}
+
+type serviceAccountHandler struct {
+	mockClient *OnePasswordMockClient
+	token      string
+}
+
+func (h *serviceAccountHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+h.token {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/v1/vaults") {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	title, hasFilter := parseTitleFilter(r.URL.Query().Get("filter"))
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/vaults"), "/"), "/")
+
+	switch {
+	// /v1/vaults?filter=title eq "name"
+	case len(parts) == 1 && parts[0] == "" && hasFilter:
+		writeJSON(w, h.mockClient.MockVaults[title])
+	// /v1/vaults/{vault}/items
+	case len(parts) == 2 && parts[1] == "items":
+		if hasFilter {
+			items, _ := h.mockClient.GetItemsByTitle(title, parts[0])
+			writeJSON(w, items)
+			return
+		}
+		writeJSON(w, h.mockClient.MockItems[parts[0]])
+	// /v1/vaults/{vault}/items/{item}
+	case len(parts) == 3 && parts[1] == "items":
+		item, err := h.mockClient.GetItem(parts[2], parts[0])
+		if err != nil {
+			writeError(w, http.StatusNotFound, "Invalid Item UUID")
+			return
+		}
+		writeJSON(w, item)
+	// /v1/vaults/{vault}/items/{item}/versions/{version}
+	case len(parts) == 5 && parts[1] == "items" && parts[3] == "versions":
+		version, err := strconv.Atoi(parts[4])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid Item Version")
+			return
+		}
+		item, err := h.mockClient.GetItemVersion(parts[2], parts[0], version)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "Invalid Item Version")
+			return
+		}
+		writeJSON(w, item)
+	// /v1/vaults/{vault}/items/{item}/files/{file}/content
+	case len(parts) == 6 && parts[1] == "items" && parts[3] == "files" && parts[5] == "content":
+		h.writeFileContent(w, parts[0], parts[2], parts[4])
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (h *serviceAccountHandler) writeFileContent(w http.ResponseWriter, vaultID, itemID, fileID string) {
+	for _, item := range h.mockClient.MockItems[vaultID] {
+		if item.ID != itemID {
+			continue
+		}
+		for _, file := range item.Files {
+			if file.ID != fileID {
+				continue
+			}
+			if contents, ok := h.mockClient.MockFileContents[file.Name]; ok {
+				_, _ = w.Write(contents)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "Invalid File UUID")
+}
+
+func parseTitleFilter(filter string) (string, bool) {
+	match := titleFilterRegexp.FindStringSubmatch(filter)
+	if match == nil {
+		return "", false
+	}
+	title, err := strconv.Unquote(match[1])
+	if err != nil {
+		return "", false
+	}
+	return title, true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  status,
+		"message": message,
+	})
+}
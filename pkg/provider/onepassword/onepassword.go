@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/1Password/connect-sdk-go/connect"
 	"github.com/1Password/connect-sdk-go/onepassword"
@@ -26,6 +28,7 @@ import (
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
@@ -39,10 +42,15 @@ const (
 	errOnePasswordStoreNilSpecProviderOnePassword = "nil spec.provider.onepassword"
 	errOnePasswordStoreMissingRefName             = "missing: spec.provider.onepassword.auth.secretRef.connectTokenSecretRef.name"
 	errOnePasswordStoreMissingRefKey              = "missing: spec.provider.onepassword.auth.secretRef.connectTokenSecretRef.key"
+	errOnePasswordStoreMissingConnectHost         = "missing: spec.provider.onepassword.connectHost"
+	errOnePasswordStoreMissingSARefName           = "missing: spec.provider.onepassword.auth.secretRef.serviceAccountTokenSecretRef.name"
+	errOnePasswordStoreMissingSARefKey            = "missing: spec.provider.onepassword.auth.secretRef.serviceAccountTokenSecretRef.key"
+	errOnePasswordStoreMultipleTokens             = "only one of connectTokenSecretRef and serviceAccountTokenSecretRef may be set"
+	errOnePasswordStoreInvalidServiceAccountURL   = "unable to parse URL: spec.provider.onepassword.serviceAccountURL: %w"
 	errOnePasswordStoreAtLeastOneVault            = "must be at least one vault: spec.provider.onepassword.vaults"
 	errOnePasswordStoreInvalidConnectHost         = "unable to parse URL: spec.provider.onepassword.connectHost: %w"
 	errOnePasswordStoreNonUniqueVaultNumbers      = "vault order numbers must be unique"
-	errFetchK8sSecret                             = "could not fetch ConnectToken Secret: %w"
+	errFetchK8sSecret                             = "could not fetch token Secret: %w"
 	errMissingToken                               = "missing Secret Token"
 	errGetVault                                   = "error finding 1Password Vault: %w"
 	errExpectedOneVault                           = "expected one 1Password Vault matching %w"
@@ -51,18 +59,35 @@ const (
 	errKeyNotFound                                = "key not found in 1Password Vaults: %w"
 	errDocumentNotFound                           = "error finding 1Password Document: %w"
 	errExpectedOneField                           = "expected one 1Password ItemField matching %w"
-	errTagsNotImplemented                         = "'find.tags' is not implemented in the 1Password provider"
-	errVersionNotImplemented                      = "'remoteRef.version' is not implemented in the 1Password provider"
+	errInvalidVersion                             = "invalid 'remoteRef.version' %q: must be a number"
+	errVersionNotAvailable                        = "version %d of 1Password Item '%s' is not available: 1Password Connect only serves the current version"
+	errGetItemVersion                             = "error finding version %d of 1Password Item: %w"
 
 	documentCategory      = "DOCUMENT"
+	tagSeparator          = "/"
 	fieldsWithLabelFormat = "'%s' in '%s', got %d"
 	incorrectCountFormat  = "'%s', got %d"
 )
 
+// Client is the subset of the 1Password API the provider requires.
+// It is implemented by the 1Password Connect client and the service account client.
+type Client interface {
+	GetVaultsByTitle(title string) ([]onepassword.Vault, error)
+	GetItem(uuid, vaultUUID string) (*onepassword.Item, error)
+	GetItems(vaultUUID string) ([]onepassword.Item, error)
+	GetItemsByTitle(title, vaultUUID string) ([]onepassword.Item, error)
+	GetFileContent(file *onepassword.File) ([]byte, error)
+}
+
+// ItemVersionClient is implemented by clients that can read the version history of an item.
+type ItemVersionClient interface {
+	GetItemVersion(uuid, vaultUUID string, version int) (*onepassword.Item, error)
+}
+
 // ProviderOnePassword is a provider for 1Password.
 type ProviderOnePassword struct {
 	vaults map[string]int
-	client connect.Client
+	client Client
 }
 
 // https://github.com/external-secrets/external-secrets/issues/644
@@ -73,29 +98,49 @@ var _ esv1beta1.Provider = &ProviderOnePassword{}
 func (provider *ProviderOnePassword) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	config := store.GetSpec().Provider.OnePassword
 
+	if config.Auth.SecretRef.ServiceAccountToken != nil {
+		token, err := getToken(ctx, store, kube, namespace, *config.Auth.SecretRef.ServiceAccountToken)
+		if err != nil {
+			return nil, err
+		}
+		serviceAccountURL := config.ServiceAccountURL
+		if serviceAccountURL == "" {
+			serviceAccountURL = defaultServiceAccountURL
+		}
+		provider.client = newServiceAccountClient(serviceAccountURL, token)
+	} else {
+		token, err := getToken(ctx, store, kube, namespace, config.Auth.SecretRef.ConnectToken)
+		if err != nil {
+			return nil, err
+		}
+		provider.client = connect.NewClientWithUserAgent(config.ConnectHost, token, userAgent)
+	}
+	provider.vaults = config.Vaults
+
+	return provider, nil
+}
+
+func getToken(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string, ref esmeta.SecretKeySelector) (string, error) {
 	credentialsSecret := &corev1.Secret{}
 	objectKey := types.NamespacedName{
-		Name:      config.Auth.SecretRef.ConnectToken.Name,
+		Name:      ref.Name,
 		Namespace: namespace,
 	}
 
 	// only ClusterSecretStore is allowed to set namespace (and then it's required)
 	if store.GetObjectKind().GroupVersionKind().Kind == esv1beta1.ClusterSecretStoreKind {
-		objectKey.Namespace = *config.Auth.SecretRef.ConnectToken.Namespace
+		objectKey.Namespace = *ref.Namespace
 	}
 
 	err := kube.Get(ctx, objectKey, credentialsSecret)
 	if err != nil {
-		return nil, fmt.Errorf(errFetchK8sSecret, err)
+		return "", fmt.Errorf(errFetchK8sSecret, err)
 	}
-	token := credentialsSecret.Data[config.Auth.SecretRef.ConnectToken.Key]
+	token := credentialsSecret.Data[ref.Key]
 	if (token == nil) || (len(token) == 0) {
-		return nil, fmt.Errorf(errMissingToken)
+		return "", fmt.Errorf(errMissingToken)
 	}
-	provider.client = connect.NewClientWithUserAgent(config.ConnectHost, string(token), userAgent)
-	provider.vaults = config.Vaults
-
-	return provider, nil
+	return string(token), nil
 }
 
 // ValidateStore checks if the provided store is valid.
@@ -118,16 +163,26 @@ func validateStore(store esv1beta1.GenericStore) error {
 
 	// check mandatory fields
 	config := storeSpec.Provider.OnePassword
-	if config.Auth.SecretRef.ConnectToken.Name == "" {
-		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingRefName))
-	}
-	if config.Auth.SecretRef.ConnectToken.Key == "" {
-		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingRefKey))
-	}
+	if config.Auth.SecretRef.ServiceAccountToken != nil {
+		if err := validateServiceAccountToken(store, config); err != nil {
+			return fmt.Errorf(errOnePasswordStore, err)
+		}
+	} else {
+		if config.Auth.SecretRef.ConnectToken.Name == "" {
+			return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingRefName))
+		}
+		if config.Auth.SecretRef.ConnectToken.Key == "" {
+			return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingRefKey))
+		}
+		// the Connect Server is only optional for service accounts
+		if config.ConnectHost == "" {
+			return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingConnectHost))
+		}
 
-	// check namespace compared to kind
-	if err := utils.ValidateSecretSelector(store, config.Auth.SecretRef.ConnectToken); err != nil {
-		return fmt.Errorf(errOnePasswordStore, err)
+		// check namespace compared to kind
+		if err := utils.ValidateSecretSelector(store, config.Auth.SecretRef.ConnectToken); err != nil {
+			return fmt.Errorf(errOnePasswordStore, err)
+		}
 	}
 
 	// check at least one vault
@@ -148,13 +203,29 @@ func validateStore(store esv1beta1.GenericStore) error {
 	return nil
 }
 
-// GetSecret returns a single secret from the provider.
-func (provider *ProviderOnePassword) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	if ref.Version != "" {
-		return nil, fmt.Errorf(errVersionNotImplemented)
+func validateServiceAccountToken(store esv1beta1.GenericStore, config *esv1beta1.OnePasswordProvider) error {
+	tokenRef := config.Auth.SecretRef.ServiceAccountToken
+	if config.Auth.SecretRef.ConnectToken.Name != "" {
+		return fmt.Errorf(errOnePasswordStoreMultipleTokens)
+	}
+	if tokenRef.Name == "" {
+		return fmt.Errorf(errOnePasswordStoreMissingSARefName)
+	}
+	if tokenRef.Key == "" {
+		return fmt.Errorf(errOnePasswordStoreMissingSARefKey)
 	}
+	if err := utils.ValidateSecretSelector(store, *tokenRef); err != nil {
+		return err
+	}
+	if _, err := url.Parse(config.ServiceAccountURL); err != nil {
+		return fmt.Errorf(errOnePasswordStoreInvalidServiceAccountURL, err)
+	}
+	return nil
+}
 
-	item, err := provider.findItem(ref.Key)
+// GetSecret returns a single secret from the provider.
+func (provider *ProviderOnePassword) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	item, err := provider.findItemVersion(ref.Key, ref.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -184,11 +255,7 @@ func (provider *ProviderOnePassword) Validate() (esv1beta1.ValidationResult, err
 
 // GetSecretMap returns multiple k/v pairs from the provider, for dataFrom.extract.
 func (provider *ProviderOnePassword) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
-	if ref.Version != "" {
-		return nil, fmt.Errorf(errVersionNotImplemented)
-	}
-
-	item, err := provider.findItem(ref.Key)
+	item, err := provider.findItemVersion(ref.Key, ref.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -204,10 +271,6 @@ func (provider *ProviderOnePassword) GetSecretMap(ctx context.Context, ref esv1b
 
 // GetAllSecrets syncs multiple 1Password Items into a single Kubernetes Secret, for dataFrom.find.
 func (provider *ProviderOnePassword) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	if ref.Tags != nil {
-		return nil, fmt.Errorf(errTagsNotImplemented)
-	}
-
 	secretData := make(map[string][]byte)
 	sortedVaults := sortVaults(provider.vaults)
 	for _, vaultName := range sortedVaults {
@@ -260,6 +323,32 @@ func (provider *ProviderOnePassword) findItem(name string) (*onepassword.Item, e
 	return nil, fmt.Errorf(errKeyNotFound, fmt.Errorf("%s in: %v", name, provider.vaults))
 }
 
+// findItemVersion finds an item and returns the given version of it.
+// The current version is returned when version is empty.
+func (provider *ProviderOnePassword) findItemVersion(name, version string) (*onepassword.Item, error) {
+	item, err := provider.findItem(name)
+	if err != nil || version == "" {
+		return item, err
+	}
+	number, err := strconv.Atoi(version)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidVersion, version)
+	}
+	if item.Version == number {
+		return item, nil
+	}
+
+	versionClient, ok := provider.client.(ItemVersionClient)
+	if !ok {
+		return nil, fmt.Errorf(errVersionNotAvailable, number, name)
+	}
+	item, err = versionClient.GetItemVersion(item.ID, item.Vault.ID, number)
+	if err != nil {
+		return nil, fmt.Errorf(errGetItemVersion, number, err)
+	}
+	return item, nil
+}
+
 func (provider *ProviderOnePassword) getField(item *onepassword.Item, property string) ([]byte, error) {
 	// default to a field labeled "password"
 	fieldLabel := "password"
@@ -391,6 +480,9 @@ func (provider *ProviderOnePassword) getAllForVault(vaultID string, ref esv1beta
 		if ref.Path != nil && *ref.Path != item.Title {
 			continue
 		}
+		if !hasTags(item, ref.Tags) {
+			continue
+		}
 
 		// handle files
 		if item.Category == documentCategory {
@@ -412,6 +504,31 @@ func (provider *ProviderOnePassword) getAllForVault(vaultID string, ref esv1beta
 	return nil
 }
 
+// hasTags checks that the item has a tag for every key/value pair.
+// 1Password tags are plain strings which are nested with a '/'.
+// The pair team: backend matches the tag "team/backend",
+// the pair production: "" matches the tag "production".
+func hasTags(item onepassword.Item, tags map[string]string) bool {
+	for key, value := range tags {
+		tag := key
+		if value != "" {
+			tag = strings.Join([]string{key, value}, tagSeparator)
+		}
+		found := false
+		for _, itemTag := range item.Tags {
+			if itemTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
 func countFieldsWithLabel(fieldLabel string, fields []*onepassword.ItemField) int {
 	count := 0
 	for _, field := range fields {
@@ -15,6 +15,7 @@ package onepassword
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -42,6 +43,7 @@ const (
 	// fields and files.
 	key1, key2, key3, key4                   = "key1", "key2", "key3", "key4"
 	value1, value2, value3, value4           = "value1", "value2", "value3", "value4"
+	oldValue1                                = "old-value1"
 	sharedKey1, sharedValue1                 = "sharedkey1", "sharedvalue1"
 	otherKey1                                = "otherkey1"
 	filePNG, filePNGID                       = "file.png", "file-id"
@@ -600,6 +602,32 @@ func TestValidateStore(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreAtLeastOneVault)),
 		},
+		{
+			checkNote: "invalid: connect token without connectHost",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ConnectToken: esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+								},
+							},
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingConnectHost)),
+		},
 		{
 			checkNote: "invalid: url",
 			store: &esv1beta1.SecretStore{
@@ -626,6 +654,87 @@ func TestValidateStore(t *testing.T) {
 				},
 			},
 			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreInvalidConnectHost, fmt.Errorf("parse \":/invalid.invalid\": missing protocol scheme"))),
+		}, {
+			checkNote: "valid secretStore with service account token",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ServiceAccountToken: &esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+								},
+							},
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			checkNote: "invalid: service account token without key",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ServiceAccountToken: &esmeta.SecretKeySelector{
+										Name: mySecret,
+									},
+								},
+							},
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingSARefKey)),
+		},
+		{
+			checkNote: "invalid: connect and service account token",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ConnectToken: esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+									ServiceAccountToken: &esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+								},
+							},
+							ConnectHost: connectHost,
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMultipleTokens)),
 		},
 	}
 
@@ -654,9 +763,10 @@ func TestValidateStore(t *testing.T) {
 }
 
 // most functionality is tested in TestFindItem
-//   here we just check that an empty Property defaults to "password",
-//   files are loaded, and
-//   the data or errors are properly returned
+//
+//	here we just check that an empty Property defaults to "password",
+//	files are loaded, and
+//	the data or errors are properly returned
 func TestGetSecret(t *testing.T) {
 	type check struct {
 		checkNote     string
@@ -682,6 +792,10 @@ func TestGetSecret(t *testing.T) {
 					AppendItemField(myVaultID, myItemID, onepassword.ItemField{
 						Label: password,
 						Value: value2,
+					}).
+					AppendItemVersion(myVaultID, myItemID, 1, onepassword.ItemField{
+						Label: key1,
+						Value: oldValue1,
 					}),
 			},
 			checks: []check{
@@ -703,13 +817,42 @@ func TestGetSecret(t *testing.T) {
 					expectedErr:   nil,
 				},
 				{
-					checkNote: "'ref.version' not implemented",
+					checkNote: "'ref.version' of previous version",
+					ref: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:      myItem,
+						Property: key1,
+						Version:  "1",
+					},
+					expectedValue: oldValue1,
+					expectedErr:   nil,
+				},
+				{
+					checkNote: "'ref.version' of current version",
+					ref: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:      myItem,
+						Property: key1,
+						Version:  "0",
+					},
+					expectedValue: value1,
+					expectedErr:   nil,
+				},
+				{
+					checkNote: "'ref.version' does not exist",
 					ref: esv1beta1.ExternalSecretDataRemoteRef{
 						Key:      myItem,
 						Property: key1,
 						Version:  "123",
 					},
-					expectedErr: fmt.Errorf(errVersionNotImplemented),
+					expectedErr: fmt.Errorf(errGetItemVersion, 123, errors.New("status 404: Invalid Item Version")),
+				},
+				{
+					checkNote: "'ref.version' is not a number",
+					ref: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:      myItem,
+						Property: key1,
+						Version:  "latest",
+					},
+					expectedErr: fmt.Errorf(errInvalidVersion, "latest"),
 				},
 			},
 		},
@@ -814,8 +957,9 @@ func TestGetSecret(t *testing.T) {
 }
 
 // most functionality is tested in TestFindItem. here we just check:
-//   all keys are fetched and the map is compiled correctly,
-//   files are loaded, and the data or errors are properly returned.
+//
+//	all keys are fetched and the map is compiled correctly,
+//	files are loaded, and the data or errors are properly returned.
 func TestGetSecretMap(t *testing.T) {
 	type check struct {
 		checkNote   string
@@ -841,6 +985,10 @@ func TestGetSecretMap(t *testing.T) {
 					AppendItemField(myVaultID, myItemID, onepassword.ItemField{
 						Label: password,
 						Value: value2,
+					}).
+					AppendItemVersion(myVaultID, myItemID, 1, onepassword.ItemField{
+						Label: key1,
+						Value: oldValue1,
 					}),
 			},
 			checks: []check{
@@ -867,13 +1015,46 @@ func TestGetSecretMap(t *testing.T) {
 					expectedErr: nil,
 				},
 				{
-					checkNote: "'ref.version' not implemented",
+					checkNote: "'ref.version' of previous version",
+					ref: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:      myItem,
+						Property: key1,
+						Version:  "1",
+					},
+					expectedMap: map[string][]byte{
+						key1: []byte(oldValue1),
+					},
+					expectedErr: nil,
+				},
+				{
+					checkNote: "'ref.version' of current version",
+					ref: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:      myItem,
+						Property: key1,
+						Version:  "0",
+					},
+					expectedMap: map[string][]byte{
+						key1: []byte(value1),
+					},
+					expectedErr: nil,
+				},
+				{
+					checkNote: "'ref.version' does not exist",
 					ref: esv1beta1.ExternalSecretDataRemoteRef{
 						Key:      myItem,
 						Property: key1,
 						Version:  "123",
 					},
-					expectedErr: fmt.Errorf(errVersionNotImplemented),
+					expectedErr: fmt.Errorf(errGetItemVersion, 123, errors.New("status 404: Invalid Item Version")),
+				},
+				{
+					checkNote: "'ref.version' is not a number",
+					ref: esv1beta1.ExternalSecretDataRemoteRef{
+						Key:      myItem,
+						Property: key1,
+						Version:  "latest",
+					},
+					expectedErr: fmt.Errorf(errInvalidVersion, "latest"),
 				},
 			},
 		},
@@ -1079,7 +1260,7 @@ func TestGetAllSecrets(t *testing.T) {
 					expectedErr: nil,
 				},
 				{
-					checkNote: "error when find.tags",
+					checkNote: "no items with find.tags",
 					ref: esv1beta1.ExternalSecretFind{
 						Name: &esv1beta1.FindName{
 							RegExp: "key*",
@@ -1088,7 +1269,8 @@ func TestGetAllSecrets(t *testing.T) {
 							"asdf": "fdas",
 						},
 					},
-					expectedErr: fmt.Errorf(errTagsNotImplemented),
+					expectedMap: map[string][]byte{},
+					expectedErr: nil,
 				},
 			},
 		},
@@ -1290,6 +1472,72 @@ func TestGetAllSecrets(t *testing.T) {
 				},
 			},
 		},
+		{
+			setupNote: "one vault, three tagged items",
+			provider: &ProviderOnePassword{
+				vaults: map[string]int{myVault: 1},
+				client: fake.NewMockClient().
+					AddPredictableVault(myVault).
+					AppendItem(myVaultID, onepassword.Item{
+						ID:    myItemID,
+						Title: myItem,
+						Vault: onepassword.ItemVault{ID: myVaultID},
+						Tags:  []string{"team/backend", "production"},
+					}).
+					AppendItemField(myVaultID, myItemID, onepassword.ItemField{Label: key1, Value: value1}).
+					AppendItem(myVaultID, onepassword.Item{
+						ID:    mySharedItemID,
+						Title: mySharedItem,
+						Vault: onepassword.ItemVault{ID: myVaultID},
+						Tags:  []string{"team/backend"},
+					}).
+					AppendItemField(myVaultID, mySharedItemID, onepassword.ItemField{Label: key2, Value: value2}).
+					AppendItem(myVaultID, onepassword.Item{
+						ID:    myOtherItemID,
+						Title: myOtherItem,
+						Vault: onepassword.ItemVault{ID: myVaultID},
+						Tags:  []string{"team/frontend", "production"},
+					}).
+					AppendItemField(myVaultID, myOtherItemID, onepassword.ItemField{Label: key3, Value: value3}),
+			},
+			checks: []check{
+				{
+					checkNote: "find by nested tag",
+					ref: esv1beta1.ExternalSecretFind{
+						Tags: map[string]string{"team": "backend"},
+					},
+					expectedMap: map[string][]byte{
+						key1: []byte(value1),
+						key2: []byte(value2),
+					},
+					expectedErr: nil,
+				},
+				{
+					checkNote: "find by plain tag",
+					ref: esv1beta1.ExternalSecretFind{
+						Tags: map[string]string{"production": ""},
+					},
+					expectedMap: map[string][]byte{
+						key1: []byte(value1),
+						key3: []byte(value3),
+					},
+					expectedErr: nil,
+				},
+				{
+					checkNote: "find by all tags and name",
+					ref: esv1beta1.ExternalSecretFind{
+						Name: &esv1beta1.FindName{
+							RegExp: "key*",
+						},
+						Tags: map[string]string{"team": "backend", "production": ""},
+					},
+					expectedMap: map[string][]byte{
+						key1: []byte(value1),
+					},
+					expectedErr: nil,
+				},
+			},
+		},
 	}
 
 	// run the tests
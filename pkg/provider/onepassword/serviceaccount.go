@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onepassword
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/1Password/connect-sdk-go/onepassword"
+)
+
+const (
+	defaultServiceAccountURL = "https://api.1password.com"
+	serviceAccountTimeout    = 30 * time.Second
+
+	errServiceAccountRequest  = "unable to create request to 1Password: %w"
+	errServiceAccountResponse = "status %d: %s"
+	errServiceAccountDecode   = "unable to decode response of 1Password: %w"
+)
+
+// serviceAccountClient talks to the 1Password service account REST API
+// which uses the same resources as 1Password Connect.
+// Unlike Connect it serves the version history of an item.
+type serviceAccountClient struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// https://github.com/external-secrets/external-secrets/issues/644
+var _ Client = &serviceAccountClient{}
+var _ ItemVersionClient = &serviceAccountClient{}
+
+func newServiceAccountClient(serviceAccountURL, token string) *serviceAccountClient {
+	return &serviceAccountClient{
+		url:        strings.TrimSuffix(serviceAccountURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: serviceAccountTimeout},
+	}
+}
+
+// GetVaultsByTitle returns the vaults with the given title.
+func (c *serviceAccountClient) GetVaultsByTitle(title string) ([]onepassword.Vault, error) {
+	var vaults []onepassword.Vault
+	err := c.getJSON("/v1/vaults?filter="+titleFilter(title), &vaults)
+	return vaults, err
+}
+
+// GetItem returns the current version of an item including its fields and files.
+func (c *serviceAccountClient) GetItem(uuid, vaultUUID string) (*onepassword.Item, error) {
+	var item onepassword.Item
+	err := c.getJSON(fmt.Sprintf("/v1/vaults/%s/items/%s", vaultUUID, uuid), &item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetItemVersion returns the given version of an item including its fields and files.
+func (c *serviceAccountClient) GetItemVersion(uuid, vaultUUID string, version int) (*onepassword.Item, error) {
+	var item onepassword.Item
+	err := c.getJSON(fmt.Sprintf("/v1/vaults/%s/items/%s/versions/%d", vaultUUID, uuid, version), &item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetItems returns the summaries of all items in a vault.
+func (c *serviceAccountClient) GetItems(vaultUUID string) ([]onepassword.Item, error) {
+	var items []onepassword.Item
+	err := c.getJSON(fmt.Sprintf("/v1/vaults/%s/items", vaultUUID), &items)
+	return items, err
+}
+
+// GetItemsByTitle returns the summaries of the items in a vault with the given title.
+func (c *serviceAccountClient) GetItemsByTitle(title, vaultUUID string) ([]onepassword.Item, error) {
+	var items []onepassword.Item
+	err := c.getJSON(fmt.Sprintf("/v1/vaults/%s/items?filter=%s", vaultUUID, titleFilter(title)), &items)
+	return items, err
+}
+
+// GetFileContent returns the content of a file attached to an item.
+func (c *serviceAccountClient) GetFileContent(file *onepassword.File) ([]byte, error) {
+	if content, err := file.Content(); err == nil {
+		return content, nil
+	}
+	content, err := c.get(file.ContentPath)
+	if err != nil {
+		return nil, err
+	}
+	file.SetContent(content)
+	return content, nil
+}
+
+func (c *serviceAccountClient) getJSON(path string, out interface{}) error {
+	body, err := c.get(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf(errServiceAccountDecode, err)
+	}
+	return nil
+}
+
+func (c *serviceAccountClient) get(path string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, c.url+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf(errServiceAccountRequest, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// errors are returned in the form {"status": 404, "message": "Invalid Item UUID"}
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, fmt.Errorf(errServiceAccountResponse, resp.StatusCode, apiErr.Message)
+	}
+	return body, nil
+}
+
+func titleFilter(title string) string {
+	return url.QueryEscape(fmt.Sprintf("title eq %q", title))
+}
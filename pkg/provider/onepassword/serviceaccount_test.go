@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onepassword
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/1Password/connect-sdk-go/onepassword"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fake "github.com/external-secrets/external-secrets/pkg/provider/onepassword/fake"
+)
+
+func TestServiceAccountClient(t *testing.T) {
+	mockClient := fake.NewMockClient().
+		AddPredictableVault(myVault).
+		AppendItem(myVaultID, onepassword.Item{
+			ID:      myItemID,
+			Title:   myItem,
+			Vault:   onepassword.ItemVault{ID: myVaultID},
+			Version: 2,
+			Tags:    []string{"team/backend"},
+		}).
+		AppendItemField(myVaultID, myItemID, onepassword.ItemField{Label: password, Value: value2}).
+		AppendItemVersion(myVaultID, myItemID, 1, onepassword.ItemField{Label: password, Value: value1}).
+		AppendItem(myVaultID, onepassword.Item{
+			ID:       mySharedItemID,
+			Title:    mySharedItem,
+			Vault:    onepassword.ItemVault{ID: myVaultID},
+			Category: documentCategory,
+			Files: []*onepassword.File{
+				{
+					ID:          myFilePNGID,
+					Name:        myFilePNG,
+					ContentPath: "/v1/vaults/" + myVaultID + "/items/" + mySharedItemID + "/files/" + myFilePNGID + "/content",
+				},
+			},
+		}).
+		SetFileContents(myFilePNG, []byte(myContents))
+
+	server := fake.NewServiceAccountServer(mockClient, token)
+	defer server.Close()
+
+	provider := &ProviderOnePassword{
+		vaults: map[string]int{myVault: 1},
+		client: newServiceAccountClient(server.URL, token),
+	}
+
+	t.Run("current version", func(t *testing.T) {
+		got, err := provider.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: myItem})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != value2 {
+			t.Errorf("expected %s, got %s", value2, got)
+		}
+	})
+
+	t.Run("previous version", func(t *testing.T) {
+		got, err := provider.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: myItem, Version: "1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != value1 {
+			t.Errorf("expected %s, got %s", value1, got)
+		}
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		_, err := provider.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: myItem, Version: "5"})
+		expected := "error finding version 5 of 1Password Item: status 404: Invalid Item Version"
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("file content", func(t *testing.T) {
+		got, err := provider.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: mySharedItem})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != myContents {
+			t.Errorf("expected %s, got %s", myContents, got)
+		}
+	})
+
+	t.Run("find by tags", func(t *testing.T) {
+		got, err := provider.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+			Tags: map[string]string{"team": "backend"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string][]byte{password: []byte(value2)}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %#v, got %#v", expected, got)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		invalid := &ProviderOnePassword{
+			vaults: map[string]int{myVault: 1},
+			client: newServiceAccountClient(server.URL, "invalid"),
+		}
+		_, err := invalid.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: myItem})
+		expected := "error finding 1Password Vault: status 401: Invalid token"
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error %q, got %v", expected, err)
+		}
+	})
+}
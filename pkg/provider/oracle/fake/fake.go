@@ -15,12 +15,66 @@ package fake
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
 
 	secrets "github.com/oracle/oci-go-sdk/v56/secrets"
+	"github.com/oracle/oci-go-sdk/v56/vault"
 )
 
 type OracleMockClient struct {
-	getSecret func(ctx context.Context, request secrets.GetSecretBundleByNameRequest) (response secrets.GetSecretBundleByNameResponse, err error)
+	getSecret   func(ctx context.Context, request secrets.GetSecretBundleByNameRequest) (response secrets.GetSecretBundleByNameResponse, err error)
+	listSecrets func(ctx context.Context, request vault.ListSecretsRequest) (response vault.ListSecretsResponse, err error)
+}
+
+func (mc *OracleMockClient) ListSecrets(ctx context.Context, request vault.ListSecretsRequest) (response vault.ListSecretsResponse, err error) {
+	return mc.listSecrets(ctx, request)
+}
+
+// WithSecrets serves the given secrets by their name.
+// ListSecrets returns the summaries in pages of pageSize items.
+func (mc *OracleMockClient) WithSecrets(summaries []vault.SecretSummary, values map[string]string, pageSize int) {
+	if mc == nil {
+		return
+	}
+	mc.listSecrets = func(ctx context.Context, request vault.ListSecretsRequest) (vault.ListSecretsResponse, error) {
+		start := 0
+		if request.Page != nil {
+			start, _ = strconv.Atoi(*request.Page)
+		}
+		end := start + pageSize
+		resp := vault.ListSecretsResponse{}
+		if end < len(summaries) {
+			resp.OpcNextPage = strPtr(strconv.Itoa(end))
+		} else {
+			end = len(summaries)
+		}
+		for _, summary := range summaries[start:end] {
+			if request.LifecycleState != "" && summary.LifecycleState != request.LifecycleState {
+				continue
+			}
+			resp.Items = append(resp.Items, summary)
+		}
+		return resp, nil
+	}
+	mc.getSecret = func(ctx context.Context, request secrets.GetSecretBundleByNameRequest) (secrets.GetSecretBundleByNameResponse, error) {
+		value, ok := values[*request.SecretName]
+		if !ok {
+			return secrets.GetSecretBundleByNameResponse{}, fmt.Errorf("secret %s not found", *request.SecretName)
+		}
+		return secrets.GetSecretBundleByNameResponse{
+			SecretBundle: secrets.SecretBundle{
+				SecretBundleContent: secrets.Base64SecretBundleContentDetails{
+					Content: strPtr(base64.StdEncoding.EncodeToString([]byte(value))),
+				},
+			},
+		}, nil
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
 }
 
 func (mc *OracleMockClient) GetSecretBundleByName(ctx context.Context, request secrets.GetSecretBundleByNameRequest) (response secrets.GetSecretBundleByNameResponse, err error) {
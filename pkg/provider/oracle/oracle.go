@@ -16,10 +16,14 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/oracle/oci-go-sdk/v56/common"
 	"github.com/oracle/oci-go-sdk/v56/common/auth"
 	"github.com/oracle/oci-go-sdk/v56/secrets"
+	"github.com/oracle/oci-go-sdk/v56/vault"
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,6 +31,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
@@ -50,6 +55,8 @@ const (
 	errJSONSecretUnmarshal                   = "unable to unmarshal secret: %w"
 	errMissingKey                            = "missing Key in secret: %s"
 	errUnexpectedContent                     = "unexpected secret bundle content"
+	errMissingCompartment                    = "missing Compartment: it is required to find secrets"
+	errInvalidVersion                        = "invalid version %q: use a version number or one of CURRENT, PENDING, PREVIOUS, LATEST"
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
@@ -57,40 +64,90 @@ var _ esv1beta1.SecretsClient = &VaultManagementService{}
 var _ esv1beta1.Provider = &VaultManagementService{}
 
 type VaultManagementService struct {
-	Client VMInterface
-	vault  string
+	Client      VMInterface
+	VaultClient VaultInterface
+	vault       string
+	compartment string
 }
 
 type VMInterface interface {
 	GetSecretBundleByName(ctx context.Context, request secrets.GetSecretBundleByNameRequest) (secrets.GetSecretBundleByNameResponse, error)
 }
 
-// Empty GetAllSecrets.
-func (vms *VaultManagementService) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not implemented")
+type VaultInterface interface {
+	ListSecrets(ctx context.Context, request vault.ListSecretsRequest) (vault.ListSecretsResponse, error)
 }
 
-func (vms *VaultManagementService) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	if utils.IsNil(vms.Client) {
+// GetAllSecrets lists the active secrets of the vault within its compartment
+// and returns the current version of those matching find.name.regexp and find.tags (freeform tags).
+func (vms *VaultManagementService) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if utils.IsNil(vms.Client) || utils.IsNil(vms.VaultClient) {
 		return nil, fmt.Errorf(errUninitalizedOracleProvider)
 	}
+	if vms.compartment == "" {
+		return nil, fmt.Errorf(errMissingCompartment)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+
+	secretMap := make(map[string][]byte)
+	var page *string
+	for {
+		resp, err := vms.VaultClient.ListSecrets(ctx, vault.ListSecretsRequest{
+			CompartmentId:  &vms.compartment,
+			VaultId:        &vms.vault,
+			LifecycleState: vault.SecretSummaryLifecycleStateActive,
+			Page:           page,
+		})
+		if err != nil {
+			return nil, util.SanitizeErr(err)
+		}
+		for _, summary := range resp.Items {
+			if summary.SecretName == nil {
+				continue
+			}
+			name := *summary.SecretName
+			if matcher != nil && !matcher.MatchName(name) {
+				continue
+			}
+			if !hasTags(summary.FreeformTags, ref.Tags) {
+				continue
+			}
+			payload, err := vms.getSecretBundle(ctx, name, "")
+			if err != nil {
+				return nil, err
+			}
+			secretMap[name] = payload
+		}
+		if resp.OpcNextPage == nil {
+			break
+		}
+		page = resp.OpcNextPage
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
 
-	sec, err := vms.Client.GetSecretBundleByName(ctx, secrets.GetSecretBundleByNameRequest{
-		VaultId:    &vms.vault,
-		SecretName: &ref.Key,
-		Stage:      secrets.GetSecretBundleByNameStageEnum(ref.Version),
-	})
-	if err != nil {
-		return nil, util.SanitizeErr(err)
+func hasTags(secretTags, tags map[string]string) bool {
+	for k, v := range tags {
+		if val, ok := secretTags[k]; !ok || val != v {
+			return false
+		}
 	}
+	return true
+}
 
-	bt, ok := sec.SecretBundleContent.(secrets.Base64SecretBundleContentDetails)
-	if !ok {
-		return nil, fmt.Errorf(errUnexpectedContent)
+func (vms *VaultManagementService) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if utils.IsNil(vms.Client) {
+		return nil, fmt.Errorf(errUninitalizedOracleProvider)
 	}
 
-	payload, err := base64.StdEncoding.DecodeString(*bt.Content)
+	payload, err := vms.getSecretBundle(ctx, ref.Key, ref.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +165,48 @@ func (vms *VaultManagementService) GetSecret(ctx context.Context, ref esv1beta1.
 	return []byte(val.String()), nil
 }
 
+func (vms *VaultManagementService) getSecretBundle(ctx context.Context, name, version string) ([]byte, error) {
+	request := secrets.GetSecretBundleByNameRequest{
+		VaultId:    &vms.vault,
+		SecretName: &name,
+	}
+	if err := setSecretVersion(&request, version); err != nil {
+		return nil, err
+	}
+	sec, err := vms.Client.GetSecretBundleByName(ctx, request)
+	if err != nil {
+		return nil, util.SanitizeErr(err)
+	}
+
+	bt, ok := sec.SecretBundleContent.(secrets.Base64SecretBundleContentDetails)
+	if !ok {
+		return nil, fmt.Errorf(errUnexpectedContent)
+	}
+
+	return base64.StdEncoding.DecodeString(*bt.Content)
+}
+
+// setSecretVersion selects a secret version either by its number or by its stage.
+// Without a version the vault returns the current version.
+func setSecretVersion(request *secrets.GetSecretBundleByNameRequest, version string) error {
+	if version == "" {
+		return nil
+	}
+	if number, err := strconv.ParseInt(version, 10, 64); err == nil {
+		request.VersionNumber = &number
+		return nil
+	}
+	switch stage := secrets.GetSecretBundleByNameStageEnum(strings.ToUpper(version)); stage {
+	case secrets.GetSecretBundleByNameStageCurrent,
+		secrets.GetSecretBundleByNameStagePending,
+		secrets.GetSecretBundleByNameStagePrevious,
+		secrets.GetSecretBundleByNameStageLatest:
+		request.Stage = stage
+		return nil
+	}
+	return fmt.Errorf(errInvalidVersion, version)
+}
+
 func (vms *VaultManagementService) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
 	data, err := vms.GetSecret(ctx, ref)
 	if err != nil {
@@ -143,7 +242,7 @@ func (vms *VaultManagementService) NewClient(ctx context.Context, store esv1beta
 		configurationProvider common.ConfigurationProvider
 	)
 	if oracleSpec.Auth == nil {
-		configurationProvider, err = auth.InstancePrincipalConfigurationProvider()
+		configurationProvider, err = getPrincipalConfigurationProvider()
 	} else {
 		configurationProvider, err = getUserAuthConfigurationProvider(ctx, kube, oracleSpec, namespace, store.GetObjectKind().GroupVersionKind().Kind, oracleSpec.Region)
 	}
@@ -158,12 +257,30 @@ func (vms *VaultManagementService) NewClient(ctx context.Context, store esv1beta
 
 	secretManagementService.SetRegion(oracleSpec.Region)
 
+	vaultClient, err := vault.NewVaultsClientWithConfigurationProvider(configurationProvider)
+	if err != nil {
+		return nil, fmt.Errorf(errOracleClient, err)
+	}
+
+	vaultClient.SetRegion(oracleSpec.Region)
+
 	return &VaultManagementService{
-		Client: secretManagementService,
-		vault:  oracleSpec.Vault,
+		Client:      secretManagementService,
+		VaultClient: vaultClient,
+		vault:       oracleSpec.Vault,
+		compartment: oracleSpec.Compartment,
 	}, nil
 }
 
+// getPrincipalConfigurationProvider authenticates with the resource principal
+// when it is provided to the workload through the environment and falls back to the instance principal.
+func getPrincipalConfigurationProvider() (common.ConfigurationProvider, error) {
+	if os.Getenv(auth.ResourcePrincipalVersionEnvVar) != "" {
+		return auth.ResourcePrincipalConfigurationProvider()
+	}
+	return auth.InstancePrincipalConfigurationProvider()
+}
+
 func getSecretData(ctx context.Context, kube kclient.Client, namespace, storeKind string, secretRef esmeta.SecretKeySelector) (string, error) {
 	if secretRef.Name == "" {
 		return "", fmt.Errorf(errORACLECredSecretName)
@@ -2,7 +2,9 @@
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
+
     http://www.apache.org/licenses/LICENSE-2.0
+
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
 WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
@@ -20,6 +22,7 @@ import (
 	"testing"
 
 	secrets "github.com/oracle/oci-go-sdk/v56/secrets"
+	"github.com/oracle/oci-go-sdk/v56/vault"
 	utilpointer "k8s.io/utils/pointer"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -66,7 +69,7 @@ func makeValidVaultTestCase() *vaultTestCase {
 func makeValidRef() *esv1beta1.ExternalSecretDataRemoteRef {
 	return &esv1beta1.ExternalSecretDataRemoteRef{
 		Key:     "test-secret",
-		Version: "default",
+		Version: "CURRENT",
 	}
 }
 
@@ -178,6 +181,123 @@ func TestGetSecretMap(t *testing.T) {
 	}
 }
 
+func TestSetSecretVersion(t *testing.T) {
+	tbl := []struct {
+		version       string
+		expectStage   secrets.GetSecretBundleByNameStageEnum
+		expectVersion *int64
+		expectError   string
+	}{
+		{version: ""},
+		{version: "3", expectVersion: utilpointer.Int64(3)},
+		{version: "CURRENT", expectStage: secrets.GetSecretBundleByNameStageCurrent},
+		{version: "pending", expectStage: secrets.GetSecretBundleByNameStagePending},
+		{version: "Previous", expectStage: secrets.GetSecretBundleByNameStagePrevious},
+		{version: "default", expectError: `invalid version "default"`},
+	}
+	for _, row := range tbl {
+		request := secrets.GetSecretBundleByNameRequest{}
+		err := setSecretVersion(&request, row.version)
+		if !ErrorContains(err, row.expectError) {
+			t.Errorf("[%s] unexpected error: %v, expected: '%s'", row.version, err, row.expectError)
+		}
+		if request.Stage != row.expectStage {
+			t.Errorf("[%s] unexpected stage: expected %s, got %s", row.version, row.expectStage, request.Stage)
+		}
+		if !reflect.DeepEqual(request.VersionNumber, row.expectVersion) {
+			t.Errorf("[%s] unexpected version number: expected %v, got %v", row.version, row.expectVersion, request.VersionNumber)
+		}
+	}
+}
+
+func makeSecretSummary(name string, state vault.SecretSummaryLifecycleStateEnum, tags map[string]string) vault.SecretSummary {
+	return vault.SecretSummary{
+		SecretName:     utilpointer.StringPtr(name),
+		LifecycleState: state,
+		FreeformTags:   tags,
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	summaries := []vault.SecretSummary{
+		makeSecretSummary("db-password", vault.SecretSummaryLifecycleStateActive, map[string]string{"env": "prod", "team": "backend"}),
+		makeSecretSummary("db-user", vault.SecretSummaryLifecycleStateActive, map[string]string{"env": "prod"}),
+		makeSecretSummary("db-host", vault.SecretSummaryLifecycleStateActive, map[string]string{"env": "dev"}),
+		makeSecretSummary("db-old", vault.SecretSummaryLifecycleStatePendingDeletion, map[string]string{"env": "prod"}),
+		makeSecretSummary("api-key", vault.SecretSummaryLifecycleStateActive, map[string]string{"env": "prod"}),
+	}
+	values := map[string]string{
+		"db-password": "s3cr3t",
+		"db-user":     "user",
+		"db-host":     "localhost",
+		"db-old":      "old",
+		"api-key":     "key",
+	}
+	tbl := []struct {
+		name        string
+		compartment string
+		ref         esv1beta1.ExternalSecretFind
+		expected    map[string][]byte
+		expectError string
+	}{
+		{
+			name:        "find by name",
+			compartment: "compartment-OCID",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^db-"}},
+			expected: map[string][]byte{
+				"db-password": []byte("s3cr3t"),
+				"db-user":     []byte("user"),
+				"db-host":     []byte("localhost"),
+			},
+		},
+		{
+			name:        "find by tags",
+			compartment: "compartment-OCID",
+			ref:         esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expected: map[string][]byte{
+				"db-password": []byte("s3cr3t"),
+				"db-user":     []byte("user"),
+				"api-key":     []byte("key"),
+			},
+		},
+		{
+			name:        "find by name and tags",
+			compartment: "compartment-OCID",
+			ref: esv1beta1.ExternalSecretFind{
+				Name: &esv1beta1.FindName{RegExp: "^db-"},
+				Tags: map[string]string{"env": "prod", "team": "backend"},
+			},
+			expected: map[string][]byte{
+				"db-password": []byte("s3cr3t"),
+			},
+		},
+		{
+			name:        "missing compartment",
+			ref:         esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}},
+			expectError: errMissingCompartment,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			mockClient := &fakeoracle.OracleMockClient{}
+			mockClient.WithSecrets(summaries, values, 2)
+			sm := VaultManagementService{
+				Client:      mockClient,
+				VaultClient: mockClient,
+				vault:       vaultOCID,
+				compartment: row.compartment,
+			}
+			out, err := sm.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expectError) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expectError)
+			}
+			if err == nil && !reflect.DeepEqual(out, row.expected) {
+				t.Errorf("unexpected secret data: expected %#v, got %#v", row.expected, out)
+			}
+		})
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
@@ -19,13 +19,15 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sync"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	senhaseguraAuth "github.com/external-secrets/external-secrets/pkg/provider/senhasegura/auth"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 type clientDSMInterface interface {
@@ -41,6 +43,11 @@ var _ esv1beta1.SecretsClient = &DSM{}
 type DSM struct {
 	isoSession *senhaseguraAuth.SenhaseguraIsoSession
 	dsmClient  clientDSMInterface
+
+	// appSecrets caches the secrets of the application for the lifetime of the client,
+	// which is a single reconcile, so every key does not download the whole secret set again
+	mu         sync.Mutex
+	appSecrets *IsoDappResponse
 }
 
 /*
@@ -78,25 +85,44 @@ var (
 	errInvalidResponseBody = errors.New("invalid HTTP response body received from senhasegura")
 	errInvalidHTTPCode     = errors.New("received invalid HTTP code from senhasegura")
 	errApplicationError    = errors.New("received application error from senhasegura")
+	errFindNotSupported    = errors.New("senhasegura DSM only supports find by name")
 )
 
 /*
 	New creates an senhasegura DSM client based on ISO session
 */
 func New(isoSession *senhaseguraAuth.SenhaseguraIsoSession) (*DSM, error) {
-	return &DSM{
+	dsm := &DSM{
 		isoSession: isoSession,
-		dsmClient:  &DSM{},
-	}, nil
+	}
+	dsm.dsmClient = dsm
+	return dsm, nil
+}
+
+/*
+	getAppSecrets returns the secrets of the application, they are fetched once per client
+*/
+func (dsm *DSM) getAppSecrets() (*IsoDappResponse, error) {
+	dsm.mu.Lock()
+	defer dsm.mu.Unlock()
+	if dsm.appSecrets != nil {
+		return dsm.appSecrets, nil
+	}
+	appSecrets, err := dsm.dsmClient.FetchSecrets()
+	if err != nil {
+		return nil, err
+	}
+	dsm.appSecrets = &appSecrets
+	return dsm.appSecrets, nil
 }
 
 /*
 	GetSecret implements ESO interface and get a single secret from senhasegura provider with DSM service
 */
 func (dsm *DSM) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (resp []byte, err error) {
-	appSecrets, err := dsm.FetchSecrets()
+	appSecrets, err := dsm.getAppSecrets()
 	if err != nil {
-		return []byte(""), err
+		return nil, err
 	}
 
 	for _, v := range appSecrets.Application.Secrets {
@@ -122,7 +148,7 @@ func (dsm *DSM) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataR
 		}
 	}
 
-	return []byte(""), esv1beta1.NoSecretErr
+	return nil, esv1beta1.NoSecretErr
 }
 
 /*
@@ -130,7 +156,7 @@ func (dsm *DSM) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataR
 */
 func (dsm *DSM) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (secretData map[string][]byte, err error) {
 	secretData = make(map[string][]byte)
-	appSecrets, err := dsm.FetchSecrets()
+	appSecrets, err := dsm.getAppSecrets()
 	if err != nil {
 		return secretData, err
 	}
@@ -149,12 +175,35 @@ func (dsm *DSM) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDa
 
 /*
 	GetAllSecrets implements ESO interface and returns multiple secrets from senhasegura provider with DSM service
-
-	TODO: GetAllSecrets functionality is to get secrets from either regexp-matching against the names or via metadata label matching.
-	https://github.com/external-secrets/external-secrets/pull/830#discussion_r858657107
+	Secrets are matched by their identity with find.name.regexp, the value of each secret is its json-encoded data content
+	Secrets have no metadata in DSM, so find.tags and find.path are not supported
 */
 func (dsm *DSM) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (secretData map[string][]byte, err error) {
-	return nil, fmt.Errorf("GetAllSecrets not implemented yet")
+	if ref.Name == nil || len(ref.Tags) > 0 || ref.Path != nil {
+		return nil, errFindNotSupported
+	}
+	matcher, err := find.New(*ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	appSecrets, err := dsm.getAppSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	secretData = make(map[string][]byte)
+	for _, v := range appSecrets.Application.Secrets {
+		if !matcher.MatchName(v.Identity) {
+			continue
+		}
+		jsonStr, err := json.Marshal(v.Data)
+		if err != nil {
+			return nil, err
+		}
+		secretData[v.Identity] = jsonStr
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretData)
 }
 
 /*
@@ -208,9 +257,12 @@ func (dsm *DSM) FetchSecrets() (respObj IsoDappResponse, err error) {
 }
 
 /*
-	Close implements ESO interface and do nothing in senhasegura
+	Close implements ESO interface and drops the cached secrets
 */
 func (dsm *DSM) Close(ctx context.Context) error {
+	dsm.mu.Lock()
+	defer dsm.mu.Unlock()
+	dsm.appSecrets = nil
 	return nil
 }
 
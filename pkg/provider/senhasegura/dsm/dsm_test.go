@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dsm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const fakeAppSecrets = `{
+	"response": {"status": 200, "message": "", "error": false, "error_code": 0},
+	"application": {
+		"name": "example",
+		"secrets": [
+			{"identity": "db-credentials", "data": [{"user": "admin", "password": "s3cr3t"}]},
+			{"identity": "db-config", "data": [{"host": "localhost"}]},
+			{"identity": "api-key", "data": [{"key": "abc"}]}
+		]
+	}
+}`
+
+type fakeDSMClient struct {
+	calls int
+}
+
+func (c *fakeDSMClient) FetchSecrets() (respObj IsoDappResponse, err error) {
+	c.calls++
+	err = json.Unmarshal([]byte(fakeAppSecrets), &respObj)
+	return respObj, err
+}
+
+func newFakeDSM() (*DSM, *fakeDSMClient) {
+	client := &fakeDSMClient{}
+	return &DSM{dsmClient: client}, client
+}
+
+func TestGetSecret(t *testing.T) {
+	tbl := []struct {
+		test   string
+		ref    esv1beta1.ExternalSecretDataRemoteRef
+		expVal []byte
+		expErr error
+	}{
+		{
+			test:   "should return property of secret",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "db-credentials", Property: "password"},
+			expVal: []byte("s3cr3t"),
+		},
+		{
+			test:   "should return json-encoded data without property",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "db-config"},
+			expVal: []byte(`[{"host":"localhost"}]`),
+		},
+		{
+			test:   "should return no secret error for unknown identity",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "unknown"},
+			expErr: esv1beta1.NoSecretErr,
+		},
+		{
+			test:   "should return no secret error for unknown property",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "db-credentials", Property: "unknown"},
+			expErr: esv1beta1.NoSecretErr,
+		},
+	}
+	for _, tc := range tbl {
+		t.Run(tc.test, func(t *testing.T) {
+			dsm, _ := newFakeDSM()
+			val, err := dsm.GetSecret(context.Background(), tc.ref)
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expVal, val)
+		})
+	}
+}
+
+func TestFetchSecretsIsCached(t *testing.T) {
+	dsm, client := newFakeDSM()
+	for _, key := range []string{"db-credentials", "db-config", "api-key"} {
+		_, err := dsm.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+		assert.Nil(t, err)
+		_, err = dsm.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, client.calls)
+
+	// closing the client drops the cache
+	assert.Nil(t, dsm.Close(context.Background()))
+	_, err := dsm.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "api-key"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	tbl := []struct {
+		test    string
+		ref     esv1beta1.ExternalSecretFind
+		expData map[string][]byte
+		expErr  string
+	}{
+		{
+			test: "should find secrets by identity",
+			ref:  esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^db-"}},
+			expData: map[string][]byte{
+				"db-credentials": []byte(`[{"password":"s3cr3t","user":"admin"}]`),
+				"db-config":      []byte(`[{"host":"localhost"}]`),
+			},
+		},
+		{
+			test:   "should not find secrets by tags",
+			ref:    esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expErr: errFindNotSupported.Error(),
+		},
+		{
+			test:   "should not find secrets with invalid regexp",
+			ref:    esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "["}},
+			expErr: "could not compile find.name.regexp",
+		},
+	}
+	for _, tc := range tbl {
+		t.Run(tc.test, func(t *testing.T) {
+			dsm, _ := newFakeDSM()
+			data, err := dsm.GetAllSecrets(context.Background(), tc.ref)
+			if tc.expErr != "" {
+				assert.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expData, data)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+#!/usr/bin/env bash
+# Regenerates the keys and the encrypted documents used by the tests of the sops
+# provider. Requires sops, age-keygen and gpg on the PATH. The keys are only
+# used by these tests.
+set -euo pipefail
+cd "$(dirname "$0")"
+
+PASSPHRASE=external-secrets
+
+if [ ! -f age.key ]; then
+	age-keygen -o age.key
+fi
+AGE_RECIPIENT=$(age-keygen -y age.key)
+
+export GNUPGHOME=$(mktemp -d)
+trap 'rm -rf "$GNUPGHOME"' EXIT
+if [ ! -f pgp.asc ]; then
+	gpg --batch --pinentry-mode loopback --passphrase "$PASSPHRASE" \
+		--quick-generate-key "external-secrets <test@example.com>" rsa3072 sign,encr never
+	gpg --batch --pinentry-mode loopback --passphrase "$PASSPHRASE" --armor --export-secret-keys > pgp.asc
+fi
+gpg --batch --pinentry-mode loopback --passphrase "$PASSPHRASE" --import pgp.asc
+PGP_FINGERPRINT=$(gpg --with-colons --list-keys test@example.com | awk -F: '/^fpr/ {print $10; exit}')
+
+sops encrypt --age "$AGE_RECIPIENT" --input-type yaml --output-type yaml \
+	db.plain.yaml > db.yaml
+sops encrypt --pgp "$PGP_FINGERPRINT" --input-type json --output-type json \
+	api.plain.json > api.json
+sops encrypt --age "$AGE_RECIPIENT" --pgp "$PGP_FINGERPRINT" --input-type yaml --output-type yaml \
+	shared.plain.yaml > shared.yaml
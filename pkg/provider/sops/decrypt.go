@@ -0,0 +1,178 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"go.mozilla.org/sops/v3"
+	"go.mozilla.org/sops/v3/aes"
+	"go.mozilla.org/sops/v3/keyservice"
+	sopsjson "go.mozilla.org/sops/v3/stores/json"
+	sopsyaml "go.mozilla.org/sops/v3/stores/yaml"
+	"google.golang.org/grpc"
+)
+
+const (
+	errNoMetadata     = "document is not encrypted with sops"
+	errLoadDocument   = "could not parse document: %w"
+	errNoDataKey      = "none of the configured keys can decrypt the data key"
+	errDecryptTree    = "could not decrypt document values: %w"
+	errMACMismatch    = "document MAC does not match, the document was modified"
+	errUnsupportedKey = "sops key type %T is not supported"
+	errEncrypt        = "encryption is not supported"
+)
+
+// keys are the private keys able to decrypt a data key.
+// They serve as sops key service, so the keys of a store are used instead of
+// those of the environment and the GnuPG keyring of the controller.
+type keys struct {
+	age []age.Identity
+	pgp openpgp.EntityList
+}
+
+var _ keyservice.KeyServiceClient = &keys{}
+
+// decrypt decrypts a sops document and verifies its MAC. Documents named *.json
+// are read in the JSON format, all others in the YAML format.
+// The result is made of map[string]interface{}, []interface{} and scalars.
+func decrypt(name string, document []byte, keys *keys) (map[string]interface{}, error) {
+	var store sops.Store = &sopsyaml.Store{}
+	if path.Ext(name) == ".json" {
+		store = &sopsjson.Store{}
+	}
+	tree, err := store.LoadEncryptedFile(document)
+	if errors.Is(err, sops.MetadataNotFound) {
+		return nil, errors.New(errNoMetadata)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errLoadDocument, err)
+	}
+
+	dataKey, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{keys})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errNoDataKey, err)
+	}
+	cipher := aes.NewCipher()
+	mac, err := tree.Decrypt(dataKey, cipher)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptTree, err)
+	}
+	originalMAC, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil || originalMAC != mac {
+		return nil, errors.New(errMACMismatch)
+	}
+
+	out := make(map[string]interface{})
+	for _, branch := range tree.Branches {
+		for k, v := range fromBranch(branch) {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+// fromBranch converts a sops tree to maps and slices, leaving out comments.
+func fromBranch(branch sops.TreeBranch) map[string]interface{} {
+	out := make(map[string]interface{}, len(branch))
+	for _, item := range branch {
+		key, ok := item.Key.(string)
+		if !ok {
+			continue
+		}
+		out[key] = fromValue(item.Value)
+	}
+	return out
+}
+
+func fromValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case sops.TreeBranch:
+		return fromBranch(v)
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			// sops keeps the comments of a list as items of the list
+			if _, ok := item.(sops.Comment); ok {
+				continue
+			}
+			out = append(out, fromValue(item))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Decrypt decrypts the data key of a document with the age identities or PGP private keys.
+func (k *keys) Decrypt(ctx context.Context, req *keyservice.DecryptRequest, opts ...grpc.CallOption) (*keyservice.DecryptResponse, error) {
+	var (
+		dataKey []byte
+		err     error
+	)
+	switch key := req.Key.KeyType.(type) {
+	case *keyservice.Key_AgeKey:
+		dataKey, err = k.decryptAge(req.Ciphertext)
+	case *keyservice.Key_PgpKey:
+		dataKey, err = k.decryptPGP(req.Ciphertext)
+	default:
+		err = fmt.Errorf(errUnsupportedKey, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &keyservice.DecryptResponse{Plaintext: dataKey}, nil
+}
+
+// Encrypt is not supported, the provider only reads documents.
+func (k *keys) Encrypt(ctx context.Context, req *keyservice.EncryptRequest, opts ...grpc.CallOption) (*keyservice.EncryptResponse, error) {
+	return nil, errors.New(errEncrypt)
+}
+
+func (k *keys) decryptAge(ciphertext []byte) ([]byte, error) {
+	if len(k.age) == 0 {
+		return nil, errors.New(errNoDataKey)
+	}
+	r, err := age.Decrypt(armor.NewReader(bytes.NewReader(ciphertext)), k.age...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func (k *keys) decryptPGP(ciphertext []byte) ([]byte, error) {
+	if len(k.pgp) == 0 {
+		return nil, errors.New(errNoDataKey)
+	}
+	block, err := pgparmor.Decode(bytes.NewReader(ciphertext))
+	if err != nil {
+		return nil, err
+	}
+	md, err := openpgp.ReadMessage(block.Body, k.pgp, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(md.UnverifiedBody)
+}
@@ -0,0 +1,495 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"go.mozilla.org/sops/v3/logging"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errMissingStore        = "missing store provider sops"
+	errMissingSource       = "exactly one of source.configMapRef, source.secretRef and source.path must be set"
+	errMissingSourceName   = "source name must be set"
+	errPathNotAllowed      = "source.path is only allowed in a ClusterSecretStore"
+	errMissingKeys         = "at least one of auth.ageSecretRef and auth.pgpSecretRef must be set"
+	errMissingNamespace    = "namespace is required on %s in a ClusterSecretStore"
+	errFetchSource         = "could not fetch source %s: %w"
+	errReadSource          = "could not read source %s: %w"
+	errFetchKey            = "could not fetch %s: %w"
+	errMissingKey          = "key %s not found in secret %s"
+	errParseAge            = "could not parse age identities: %w"
+	errParsePGP            = "could not parse PGP private keys: %w"
+	errDecryptPGP          = "could not decrypt PGP private key %X: %w"
+	errDecryptDocument     = "could not decrypt document %s: %w"
+	errPropertyNotFound    = "property %s not found in document %s"
+	errNotAnObject         = "document %s is not an object at %s"
+	errFindTagsUnsupported = "find by tags is not supported by sops"
+)
+
+// https://github.com/external-secrets/external-secrets/issues/644
+var _ esv1beta1.SecretsClient = &Client{}
+var _ esv1beta1.Provider = &Provider{}
+
+// Provider satisfies the provider interface.
+type Provider struct{}
+
+// Client reads the documents of the source and decrypts them with the keys of the store.
+type Client struct {
+	kube      client.Client
+	store     *esv1beta1.SopsProvider
+	namespace string
+	storeKind string
+	keys      *keys
+
+	// documents caches the decrypted documents for the lifetime of the client.
+	documents map[string]map[string]interface{}
+	// source caches the encrypted documents for the lifetime of the client.
+	source map[string][]byte
+}
+
+func init() {
+	// sops logs every recovered data key
+	logging.SetLevel(logrus.WarnLevel)
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Sops: &esv1beta1.SopsProvider{},
+	})
+}
+
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	provider, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		kube:      kube,
+		store:     provider,
+		namespace: namespace,
+		storeKind: store.GetObjectKind().GroupVersionKind().Kind,
+		documents: make(map[string]map[string]interface{}),
+	}
+	c.keys, err = c.getKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
+	provider, err := getProvider(store)
+	if err != nil {
+		return err
+	}
+	source := provider.Source
+	sources := 0
+	for _, set := range []bool{source.ConfigMapRef != nil, source.SecretRef != nil, source.Path != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errors.New(errMissingSource)
+	}
+	if (source.ConfigMapRef != nil && source.ConfigMapRef.Name == "") || (source.SecretRef != nil && source.SecretRef.Name == "") {
+		return errors.New(errMissingSourceName)
+	}
+	if source.Path != "" && store.GetObjectKind().GroupVersionKind().Kind != esv1beta1.ClusterSecretStoreKind {
+		return errors.New(errPathNotAllowed)
+	}
+	if provider.Auth.AgeSecretRef == nil && provider.Auth.PGPSecretRef == nil {
+		return errors.New(errMissingKeys)
+	}
+	for _, ref := range []*esmeta.SecretKeySelector{provider.Auth.AgeSecretRef, provider.Auth.PGPSecretRef, provider.Auth.PGPPassphraseSecretRef} {
+		if ref == nil {
+			continue
+		}
+		if err := utils.ValidateSecretSelector(store, *ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.SopsProvider, error) {
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Sops == nil {
+		return nil, errors.New(errMissingStore)
+	}
+	return spc.Provider.Sops, nil
+}
+
+// GetSecret returns the value at the gjson path given by property in the document named by key.
+// Without property the whole document is returned as JSON.
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	document, err := c.getDocument(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return raw, nil
+	}
+	result := gjson.GetBytes(raw, ref.Property)
+	if !result.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	if result.Type == gjson.String {
+		return []byte(result.Str), nil
+	}
+	return []byte(result.Raw), nil
+}
+
+// GetSecretMap returns the properties of the document, or of the object at the path given by property.
+// Values which are no strings are returned as JSON.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf(errNotAnObject, ref.Key, ref.Property)
+	}
+	secretMap := make(map[string][]byte, len(object))
+	for k, v := range object {
+		var str string
+		if err := json.Unmarshal(v, &str); err == nil {
+			secretMap[k] = []byte(str)
+			continue
+		}
+		secretMap[k] = []byte(v)
+	}
+	return secretMap, nil
+}
+
+// GetAllSecrets flattens all documents of the source to their leaf values,
+// named by the document and the path to the value, e.g. 'db.yaml/users/0/password'.
+// find.name matches these names and find.path is a prefix of them.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errFindTagsUnsupported)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+
+	source, err := c.getSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(source))
+	for name := range source {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	secretMap := make(map[string][]byte)
+	for _, name := range names {
+		if ref.Path != nil && !strings.HasPrefix(name, *ref.Path) && !strings.HasPrefix(*ref.Path, name) {
+			continue
+		}
+		document, err := c.getDocument(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for path, value := range flatten(name, document) {
+			if ref.Path != nil && !strings.HasPrefix(path, *ref.Path) {
+				continue
+			}
+			if matcher != nil && !matcher.MatchName(path) {
+				continue
+			}
+			secretMap[path] = value
+		}
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
+
+// flatten returns the leaf values of the tree by their path, separated by slashes.
+func flatten(prefix string, value interface{}) map[string][]byte {
+	out := make(map[string][]byte)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			for path, leaf := range flatten(prefix+"/"+k, item) {
+				out[path] = leaf
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			for path, leaf := range flatten(prefix+"/"+strconv.Itoa(i), item) {
+				out[path] = leaf
+			}
+		}
+	case string:
+		out[prefix] = []byte(v)
+	case []byte:
+		out[prefix] = v
+	case bool:
+		out[prefix] = []byte(strconv.FormatBool(v))
+	case nil:
+		out[prefix] = []byte{}
+	default:
+		out[prefix] = []byte(fmt.Sprint(v))
+	}
+	return out
+}
+
+func (c *Client) getDocument(ctx context.Context, name string) (map[string]interface{}, error) {
+	if document, ok := c.documents[name]; ok {
+		return document, nil
+	}
+	source, err := c.getSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, ok := source[name]
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	document, err := decrypt(name, encrypted, c.keys)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptDocument, name, err)
+	}
+	c.documents[name] = document
+	return document, nil
+}
+
+// getSource returns the encrypted documents by their key in the ConfigMap or Secret,
+// or by their path in the file bundle.
+func (c *Client) getSource(ctx context.Context) (map[string][]byte, error) {
+	if c.source != nil {
+		return c.source, nil
+	}
+	source := c.store.Source
+	if source.Path != "" {
+		if c.storeKind != esv1beta1.ClusterSecretStoreKind {
+			return nil, errors.New(errPathNotAllowed)
+		}
+		documents, err := readFiles(source.Path)
+		if err != nil {
+			return nil, fmt.Errorf(errReadSource, source.Path, err)
+		}
+		c.source = documents
+		return c.source, nil
+	}
+	if source.ConfigMapRef != nil {
+		key, err := c.objectKey(source.ConfigMapRef.Name, source.ConfigMapRef.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		cm := &corev1.ConfigMap{}
+		if err := c.kube.Get(ctx, key, cm); err != nil {
+			return nil, fmt.Errorf(errFetchSource, key.Name, err)
+		}
+		c.source = make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+		for k, v := range cm.Data {
+			c.source[k] = []byte(v)
+		}
+		for k, v := range cm.BinaryData {
+			c.source[k] = v
+		}
+		return c.source, nil
+	}
+	if source.SecretRef == nil {
+		return nil, errors.New(errMissingSource)
+	}
+	key, err := c.objectKey(source.SecretRef.Name, source.SecretRef.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf(errFetchSource, key.Name, err)
+	}
+	c.source = secret.Data
+	return c.source, nil
+}
+
+// readFiles reads the file at path, or the files below the directory at path
+// by their relative path. Hidden files and directories like .git are skipped.
+// Symbolic links are followed, as volumes and git-sync checkouts are made of them.
+func readFiles(path string) (map[string][]byte, error) {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{filepath.Base(path): data}, nil
+	}
+	documents := make(map[string][]byte)
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file != path && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := os.Stat(file)
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		name, err := filepath.Rel(path, file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		documents[filepath.ToSlash(name)] = data
+		return nil
+	})
+	return documents, err
+}
+
+func (c *Client) getKeys(ctx context.Context) (*keys, error) {
+	auth := c.store.Auth
+	if auth.AgeSecretRef == nil && auth.PGPSecretRef == nil {
+		return nil, errors.New(errMissingKeys)
+	}
+	k := &keys{}
+	if auth.AgeSecretRef != nil {
+		identities, err := c.secretKeyRef(ctx, auth.AgeSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		k.age, err = age.ParseIdentities(bytes.NewReader(identities))
+		if err != nil {
+			return nil, fmt.Errorf(errParseAge, err)
+		}
+	}
+	if auth.PGPSecretRef != nil {
+		privateKeys, err := c.secretKeyRef(ctx, auth.PGPSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		k.pgp, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(privateKeys))
+		if err != nil {
+			return nil, fmt.Errorf(errParsePGP, err)
+		}
+		if auth.PGPPassphraseSecretRef != nil {
+			passphrase, err := c.secretKeyRef(ctx, auth.PGPPassphraseSecretRef)
+			if err != nil {
+				return nil, err
+			}
+			if err := decryptPGPKeys(k.pgp, bytes.TrimSpace(passphrase)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return k, nil
+}
+
+// decryptPGPKeys decrypts the passphrase protected private keys of the entities.
+func decryptPGPKeys(entities openpgp.EntityList, passphrase []byte) error {
+	for _, entity := range entities {
+		if entity.PrivateKey != nil && entity.PrivateKey.Encrypted {
+			if err := entity.PrivateKey.Decrypt(passphrase); err != nil {
+				return fmt.Errorf(errDecryptPGP, entity.PrimaryKey.Fingerprint, err)
+			}
+		}
+		for _, subkey := range entity.Subkeys {
+			if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+				if err := subkey.PrivateKey.Decrypt(passphrase); err != nil {
+					return fmt.Errorf(errDecryptPGP, subkey.PublicKey.Fingerprint, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) secretKeyRef(ctx context.Context, ref *esmeta.SecretKeySelector) ([]byte, error) {
+	key, err := c.objectKey(ref.Name, ref.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf(errFetchKey, ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf(errMissingKey, ref.Key, ref.Name)
+	}
+	return value, nil
+}
+
+func (c *Client) objectKey(name string, namespace *string) (client.ObjectKey, error) {
+	key := client.ObjectKey{
+		Name:      name,
+		Namespace: c.namespace,
+	}
+	if c.storeKind == esv1beta1.ClusterSecretStoreKind {
+		if namespace == nil {
+			return key, fmt.Errorf(errMissingNamespace, name)
+		}
+		key.Namespace = *namespace
+	}
+	return key, nil
+}
+
+func (c *Client) Close(ctx context.Context) error {
+	return nil
+}
+
+func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultUnknown, nil
+}
@@ -0,0 +1,622 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sops
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// The documents in _testdata are encrypted by the sops binary, see _testdata/generate.sh.
+// db.yaml is encrypted with age, api.json with PGP and shared.yaml with both.
+const testNamespace = "default"
+
+func TestGetSecret(t *testing.T) {
+	c := newTestClient(t, testDocuments(t, "db.yaml", "api.json"), testKeys(t, "age", "pgp"))
+
+	tbl := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretDataRemoteRef
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "string property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db.password"},
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "int property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db.port"},
+			expValue: "5432",
+		},
+		{
+			name:     "float property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db.ratio"},
+			expValue: "0.5",
+		},
+		{
+			name:     "bool property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db.tls"},
+			expValue: "true",
+		},
+		{
+			name:     "list after comment",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "users.0.name"},
+			expValue: "alice",
+		},
+		{
+			name:     "list property",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "users.1.name"},
+			expValue: "bob",
+		},
+		{
+			name:     "unencrypted suffix",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "description_unencrypted"},
+			expValue: "not a secret",
+		},
+		{
+			name:     "whole json document",
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "api.json"},
+			expValue: `{"api":{"retries":3,"token":"t0ken"}}`,
+		},
+		{
+			name:   "missing property",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db.missing"},
+			expErr: fmt.Sprintf(errPropertyNotFound, "db.missing", "db.yaml"),
+		},
+		{
+			name:   "missing document",
+			ref:    esv1beta1.ExternalSecretDataRemoteRef{Key: "missing.yaml"},
+			expErr: esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := c.GetSecret(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	c := newTestClient(t, testDocuments(t, "db.yaml"), testKeys(t, "age"))
+
+	out, err := c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"user":     "admin",
+		"password": "s3cr3t",
+		"port":     "5432",
+		"tls":      "true",
+		"ratio":    "0.5",
+	}
+	if len(out) != len(expected) {
+		t.Errorf("unexpected keys: %v", out)
+	}
+	for k, v := range expected {
+		if string(out[k]) != v {
+			t.Errorf("unexpected value of %s: '%s', expected: '%s'", k, out[k], v)
+		}
+	}
+
+	_, err = c.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db.user"})
+	if !ErrorContains(err, fmt.Sprintf(errNotAnObject, "db.yaml", "db.user")) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	c := newTestClient(t, testDocuments(t, "db.yaml", "api.json", "shared.yaml"), testKeys(t, "age", "pgp"))
+
+	path := "db.yaml/users"
+	tbl := []struct {
+		name     string
+		ref      esv1beta1.ExternalSecretFind
+		expValue map[string]string
+		expErr   string
+	}{
+		{
+			name: "find by name",
+			ref:  esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "(password|token)$"}},
+			expValue: map[string]string{
+				"db.yaml_db_password":  "s3cr3t",
+				"api.json_api_token":   "t0ken",
+				"shared.yaml_password": "s3cr3t",
+			},
+		},
+		{
+			name: "find by path",
+			ref:  esv1beta1.ExternalSecretFind{Path: &path},
+			expValue: map[string]string{
+				"db.yaml_users_0_name": "alice",
+				"db.yaml_users_1_name": "bob",
+			},
+		},
+		{
+			name:   "find by tags",
+			ref:    esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			expErr: errFindTagsUnsupported,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row.ref.ConversionStrategy = esv1beta1.ExternalSecretConversionDefault
+			out, err := c.GetAllSecrets(context.Background(), row.ref)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if len(out) != len(row.expValue) {
+				t.Errorf("unexpected keys: %v", out)
+			}
+			for k, v := range row.expValue {
+				if string(out[k]) != v {
+					t.Errorf("unexpected value of %s: '%s', expected: '%s'", k, out[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tbl := []struct {
+		name     string
+		keys     map[string][]byte
+		document string
+		property string
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "age",
+			keys:     testKeys(t, "age"),
+			document: "db.yaml",
+			property: "db.user",
+			expValue: "admin",
+		},
+		{
+			name:     "pgp with passphrase",
+			keys:     testKeys(t, "pgp"),
+			document: "api.json",
+			property: "api.token",
+			expValue: "t0ken",
+		},
+		{
+			name:     "age of a document with several recipients",
+			keys:     testKeys(t, "age"),
+			document: "shared.yaml",
+			property: "password",
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "pgp of a document with several recipients",
+			keys:     testKeys(t, "pgp"),
+			document: "shared.yaml",
+			property: "password",
+			expValue: "s3cr3t",
+		},
+		{
+			name:     "pgp without recipient",
+			keys:     testKeys(t, "pgp"),
+			document: "db.yaml",
+			expErr:   errNoDataKey,
+		},
+		{
+			name:     "other age key",
+			keys:     map[string][]byte{"age": []byte(newAgeIdentity(t).String())},
+			document: "db.yaml",
+			expErr:   errNoDataKey,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			c := newTestClient(t, testDocuments(t, row.document), row.keys)
+			out, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: row.document, Property: row.property})
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	documents := testDocuments(t, "db.yaml", "db.plain.yaml")
+
+	var swapped yaml.Node
+	if err := yaml.Unmarshal([]byte(documents["db.yaml"]), &swapped); err != nil {
+		t.Fatal(err)
+	}
+	db := swapped.Content[0].Content[1]
+	db.Content[1].Value, db.Content[3].Value = db.Content[3].Value, db.Content[1].Value
+	swappedDocument, err := yaml.Marshal(&swapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbl := []struct {
+		name     string
+		document string
+		expValue string
+		expErr   string
+	}{
+		{
+			name:     "plain document",
+			document: documents["db.plain.yaml"],
+			expErr:   errNoMetadata,
+		},
+		{
+			name:     "modified unencrypted value",
+			document: strings.Replace(documents["db.yaml"], "not a secret", "modified", 1),
+			expErr:   errMACMismatch,
+		},
+		{
+			name:     "removed comment",
+			document: removeLine(documents["db.yaml"], "type:comment]"),
+			expValue: `{"db":{"password":"s3cr3t","port":5432,"ratio":0.5,"tls":true,"user":"admin"},"description_unencrypted":"not a secret","users":[{"name":"alice"},{"name":"bob"}]}`,
+		},
+		{
+			name:     "swapped encrypted values",
+			document: string(swappedDocument),
+			expErr:   "could not decrypt document values",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			c := newTestClient(t, map[string]string{"doc.yaml": row.document}, testKeys(t, "age"))
+			out, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "doc.yaml"})
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: '%s', expected: '%s'", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestNewClientClusterSecretStore(t *testing.T) {
+	identity := newAgeIdentity(t)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sops-keys", Namespace: testNamespace},
+		Data:       ageAuth(identity),
+	}).Build()
+	store := &esv1beta1.ClusterSecretStore{
+		TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Sops: &esv1beta1.SopsProvider{
+					Source: esv1beta1.SopsSource{ConfigMapRef: &esv1beta1.SopsSourceRef{Name: "sops"}},
+					Auth:   esv1beta1.SopsAuth{AgeSecretRef: &esmeta.SecretKeySelector{Name: "sops-keys", Key: "age"}},
+				},
+			},
+		},
+	}
+	_, err := (&Provider{}).NewClient(context.Background(), store, kube, "other")
+	if !ErrorContains(err, fmt.Sprintf(errMissingNamespace, "sops-keys")) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	namespace := testNamespace
+	store.Spec.Provider.Sops.Auth.AgeSecretRef.Namespace = &namespace
+	_, err = (&Provider{}).NewClient(context.Background(), store, kube, "other")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPathSource(t *testing.T) {
+	// a git-sync like checkout: the directory is a link to the worktree
+	dir := t.TempDir()
+	worktree := filepath.Join(dir, ".worktrees", "abc")
+	for _, d := range []string{filepath.Join(worktree, "prod"), filepath.Join(worktree, ".git")} {
+		if err := os.MkdirAll(d, 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string][]byte{
+		filepath.Join(worktree, "prod", "db.yaml"): readTestdata(t, "db.yaml"),
+		filepath.Join(worktree, "api.json"):        readTestdata(t, "api.json"),
+		filepath.Join(worktree, ".git", "config"):  []byte("[core]"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(name, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkout := filepath.Join(dir, "checkout")
+	if err := os.Symlink(worktree, checkout); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := testKeys(t, "age", "pgp")
+	namespace := testNamespace
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sops-keys", Namespace: testNamespace},
+		Data:       keys,
+	}).Build()
+	store := &esv1beta1.ClusterSecretStore{
+		TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Sops: &esv1beta1.SopsProvider{
+					Source: esv1beta1.SopsSource{Path: checkout},
+					Auth: esv1beta1.SopsAuth{
+						AgeSecretRef:           &esmeta.SecretKeySelector{Name: "sops-keys", Key: "age", Namespace: &namespace},
+						PGPSecretRef:           &esmeta.SecretKeySelector{Name: "sops-keys", Key: "pgp", Namespace: &namespace},
+						PGPPassphraseSecretRef: &esmeta.SecretKeySelector{Name: "sops-keys", Key: "passphrase", Namespace: &namespace},
+					},
+				},
+			},
+		},
+	}
+	if err := (&Provider{}).ValidateStore(store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, err := (&Provider{}).NewClient(context.Background(), store, kube, "other")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "prod/db.yaml", Property: "db.password"})
+	if err != nil || string(out) != "s3cr3t" {
+		t.Errorf("unexpected value: '%s', error: %v", out, err)
+	}
+	all, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Name:               &esv1beta1.FindName{RegExp: "(password|token|config)$"},
+		ConversionStrategy: esv1beta1.ExternalSecretConversionDefault,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"prod_db.yaml_db_password": "s3cr3t",
+		"api.json_api_token":       "t0ken",
+	}
+	if len(all) != len(expected) {
+		t.Errorf("unexpected keys: %v", all)
+	}
+	for k, v := range expected {
+		if string(all[k]) != v {
+			t.Errorf("unexpected value of %s: '%s', expected: '%s'", k, all[k], v)
+		}
+	}
+
+	// a single file is named by its base name
+	store.Spec.Provider.Sops.Source.Path = filepath.Join(checkout, "prod", "db.yaml")
+	c, err = (&Provider{}).NewClient(context.Background(), store, kube, "other")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml", Property: "db.user"})
+	if err != nil || string(out) != "admin" {
+		t.Errorf("unexpected value: '%s', error: %v", out, err)
+	}
+
+	store.Spec.Provider.Sops.Source.Path = filepath.Join(dir, "missing")
+	c, err = (&Provider{}).NewClient(context.Background(), store, kube, "other")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db.yaml"})
+	if !ErrorContains(err, "could not read source") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	ageRef := &esmeta.SecretKeySelector{Name: "sops-keys", Key: "age"}
+	tbl := []struct {
+		name   string
+		store  esv1beta1.SopsProvider
+		expErr string
+	}{
+		{
+			name:   "missing source",
+			store:  esv1beta1.SopsProvider{Auth: esv1beta1.SopsAuth{AgeSecretRef: ageRef}},
+			expErr: errMissingSource,
+		},
+		{
+			name: "both sources",
+			store: esv1beta1.SopsProvider{
+				Source: esv1beta1.SopsSource{ConfigMapRef: &esv1beta1.SopsSourceRef{Name: "sops"}, SecretRef: &esv1beta1.SopsSourceRef{Name: "sops"}},
+				Auth:   esv1beta1.SopsAuth{AgeSecretRef: ageRef},
+			},
+			expErr: errMissingSource,
+		},
+		{
+			name: "missing source name",
+			store: esv1beta1.SopsProvider{
+				Source: esv1beta1.SopsSource{SecretRef: &esv1beta1.SopsSourceRef{}},
+				Auth:   esv1beta1.SopsAuth{AgeSecretRef: ageRef},
+			},
+			expErr: errMissingSourceName,
+		},
+		{
+			name: "path and configMapRef",
+			store: esv1beta1.SopsProvider{
+				Source: esv1beta1.SopsSource{ConfigMapRef: &esv1beta1.SopsSourceRef{Name: "sops"}, Path: "/secrets"},
+				Auth:   esv1beta1.SopsAuth{AgeSecretRef: ageRef},
+			},
+			expErr: errMissingSource,
+		},
+		{
+			name: "path in a SecretStore",
+			store: esv1beta1.SopsProvider{
+				Source: esv1beta1.SopsSource{Path: "/secrets"},
+				Auth:   esv1beta1.SopsAuth{AgeSecretRef: ageRef},
+			},
+			expErr: errPathNotAllowed,
+		},
+		{
+			name: "missing keys",
+			store: esv1beta1.SopsProvider{
+				Source: esv1beta1.SopsSource{ConfigMapRef: &esv1beta1.SopsSourceRef{Name: "sops"}},
+			},
+			expErr: errMissingKeys,
+		},
+		{
+			name: "valid",
+			store: esv1beta1.SopsProvider{
+				Source: esv1beta1.SopsSource{ConfigMapRef: &esv1beta1.SopsSourceRef{Name: "sops"}},
+				Auth:   esv1beta1.SopsAuth{AgeSecretRef: ageRef},
+			},
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			row := row
+			store := &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{Sops: &row.store},
+				},
+			}
+			err := (&Provider{}).ValidateStore(store)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+		})
+	}
+}
+
+func newTestClient(t *testing.T, documents map[string]string, keys map[string][]byte) esv1beta1.SecretsClient {
+	t.Helper()
+	objects := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "sops", Namespace: testNamespace},
+			Data:       documents,
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "sops-keys", Namespace: testNamespace},
+			Data:       keys,
+		},
+	}
+	auth := esv1beta1.SopsAuth{}
+	if _, ok := keys["age"]; ok {
+		auth.AgeSecretRef = &esmeta.SecretKeySelector{Name: "sops-keys", Key: "age"}
+	}
+	if _, ok := keys["pgp"]; ok {
+		auth.PGPSecretRef = &esmeta.SecretKeySelector{Name: "sops-keys", Key: "pgp"}
+	}
+	if _, ok := keys["passphrase"]; ok {
+		auth.PGPPassphraseSecretRef = &esmeta.SecretKeySelector{Name: "sops-keys", Key: "passphrase"}
+	}
+	store := &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Sops: &esv1beta1.SopsProvider{
+					Source: esv1beta1.SopsSource{ConfigMapRef: &esv1beta1.SopsSourceRef{Name: "sops"}},
+					Auth:   auth,
+				},
+			},
+		},
+	}
+	c, err := (&Provider{}).NewClient(context.Background(), store, clientfake.NewClientBuilder().WithObjects(objects...).Build(), testNamespace)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return c
+}
+
+// testDocuments reads the encrypted documents from _testdata.
+func testDocuments(t *testing.T, names ...string) map[string]string {
+	t.Helper()
+	documents := make(map[string]string, len(names))
+	for _, name := range names {
+		documents[name] = string(readTestdata(t, name))
+	}
+	return documents
+}
+
+// testKeys returns the private keys of _testdata by the key of the keys secret.
+func testKeys(t *testing.T, kinds ...string) map[string][]byte {
+	t.Helper()
+	keys := make(map[string][]byte)
+	for _, kind := range kinds {
+		switch kind {
+		case "age":
+			keys["age"] = readTestdata(t, "age.key")
+		case "pgp":
+			keys["pgp"] = readTestdata(t, "pgp.asc")
+			keys["passphrase"] = []byte("external-secrets\n")
+		}
+	}
+	return keys
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("_testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func removeLine(document, substr string) string {
+	lines := strings.Split(document, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if !strings.Contains(line, substr) {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func newAgeIdentity(t *testing.T) *age.X25519Identity {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+func ageAuth(identity *age.X25519Identity) map[string][]byte {
+	return map[string][]byte{"age": []byte("# created: test\n" + identity.String() + "\n")}
+}
+
+func ErrorContains(out error, want string) bool {
+	if out == nil {
+		return want == ""
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(out.Error(), want)
+}
@@ -19,11 +19,15 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	tpl "text/template"
 	"time"
 
@@ -35,6 +39,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/template/v2"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
@@ -43,6 +48,14 @@ import (
 var _ esv1beta1.SecretsClient = &WebHook{}
 var _ esv1beta1.Provider = &Provider{}
 
+const (
+	defaultRetryBackoff = time.Second
+	// Retry-After waits above this limit are not honored, the error is returned instead.
+	// The exponential backoff is capped at the same limit.
+	maxRetryAfter = 30 * time.Second
+	maxRetries    = 10
+)
+
 // Provider satisfies the provider interface.
 type Provider struct{}
 
@@ -53,6 +66,11 @@ type WebHook struct {
 	storeKind string
 	http      *http.Client
 	url       string
+
+	// responses caches the successful responses by rendered request,
+	// so data entries hitting the same endpoint make a single call.
+	responses      map[string][]byte
+	responsesMutex sync.Mutex
 }
 
 func init() {
@@ -67,6 +85,7 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 		store:     store,
 		namespace: namespace,
 		storeKind: store.GetObjectKind().GroupVersionKind().Kind,
+		responses: make(map[string][]byte),
 	}
 	provider, err := getProvider(store)
 	if err != nil {
@@ -74,7 +93,7 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	}
 	whClient.url = provider.URL
 
-	whClient.http, err = whClient.getHTTPClient(provider)
+	whClient.http, err = whClient.getHTTPClient(ctx, provider)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +101,32 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 }
 
 func (p *Provider) ValidateStore(store esv1beta1.GenericStore) error {
+	provider, err := getProvider(store)
+	if err != nil {
+		return err
+	}
+	if provider.ClientTLS != nil {
+		if provider.ClientTLS.CertSecretRef.Name == "" || provider.ClientTLS.CertSecretRef.Key == "" {
+			return fmt.Errorf("clientTLS.certSecretRef name and key are required")
+		}
+		if provider.ClientTLS.KeySecretRef.Name == "" || provider.ClientTLS.KeySecretRef.Key == "" {
+			return fmt.Errorf("clientTLS.keySecretRef name and key are required")
+		}
+	}
+	if provider.Find != nil && provider.Find.URL == "" {
+		return fmt.Errorf("find.url is required")
+	}
+	if provider.Retry != nil {
+		if provider.Retry.MaxRetries < 0 {
+			return fmt.Errorf("retry.maxRetries must not be negative")
+		}
+		if provider.Retry.MaxRetries > maxRetries {
+			return fmt.Errorf("retry.maxRetries must not exceed %d", maxRetries)
+		}
+		if provider.Retry.Backoff != nil && provider.Retry.Backoff.Duration < 0 {
+			return fmt.Errorf("retry.backoff must not be negative")
+		}
+	}
 	return nil
 }
 
@@ -111,10 +156,67 @@ func (w *WebHook) getStoreSecret(ctx context.Context, ref esmeta.SecretKeySelect
 	return secret, nil
 }
 
-// Empty GetAllSecrets.
+// GetAllSecrets calls the find endpoint and returns the properties of the
+// object selected by its json path, filtered by find.name and find.path.
 func (w *WebHook) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not implemented")
+	provider, err := getProvider(w.store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store: %w", err)
+	}
+	if provider.Find == nil {
+		return nil, fmt.Errorf("find is not configured on the webhook store")
+	}
+	data, err := w.getFindTemplateData(ctx, ref, provider.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	result, err := w.callWebhook(ctx, provider, provider.Find.Method, provider.Find.URL, provider.Find.Body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	jsondata := interface{}(nil)
+	if err := yaml.Unmarshal(result, &jsondata); err != nil {
+		return nil, fmt.Errorf("failed to parse response json: %w", err)
+	}
+	if provider.Find.Result.JSONPath != "" {
+		jsondata, err = jsonpath.Get(provider.Find.Result.JSONPath, jsondata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get response path %s: %w", provider.Find.Result.JSONPath, err)
+		}
+	}
+	jsonvalue, ok := jsondata.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to get response (wrong type: %T)", jsondata)
+	}
+
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	values := make(map[string][]byte)
+	for rKey, rValue := range jsonvalue {
+		if matcher != nil && !matcher.MatchName(rKey) {
+			continue
+		}
+		if ref.Path != nil && !strings.HasPrefix(rKey, *ref.Path) {
+			continue
+		}
+		// Values which are no strings are passed on as json
+		if jVal, ok := rValue.(string); ok {
+			values[rKey] = []byte(jVal)
+			continue
+		}
+		jVal, err := json.Marshal(rValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode response value of key '%s': %w", rKey, err)
+		}
+		values[rKey] = jVal
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, values)
 }
 
 func (w *WebHook) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
@@ -203,6 +305,27 @@ func (w *WebHook) getTemplateData(ctx context.Context, ref esv1beta1.ExternalSec
 			"property": url.QueryEscape(ref.Property),
 		},
 	}
+	return w.addSecretsTemplateData(ctx, data, secrets)
+}
+
+func (w *WebHook) getFindTemplateData(ctx context.Context, ref esv1beta1.ExternalSecretFind, secrets []esv1beta1.WebhookSecret) (map[string]map[string]string, error) {
+	data := map[string]map[string]string{
+		"find": {},
+		"tags": {},
+	}
+	if ref.Name != nil {
+		data["find"]["name"] = url.QueryEscape(ref.Name.RegExp)
+	}
+	if ref.Path != nil {
+		data["find"]["path"] = url.QueryEscape(*ref.Path)
+	}
+	for tKey, tVal := range ref.Tags {
+		data["tags"][tKey] = url.QueryEscape(tVal)
+	}
+	return w.addSecretsTemplateData(ctx, data, secrets)
+}
+
+func (w *WebHook) addSecretsTemplateData(ctx context.Context, data map[string]map[string]string, secrets []esv1beta1.WebhookSecret) (map[string]map[string]string, error) {
 	for _, secref := range secrets {
 		if _, ok := data[secref.Name]; !ok {
 			data[secref.Name] = make(map[string]string)
@@ -219,71 +342,226 @@ func (w *WebHook) getTemplateData(ctx context.Context, ref esv1beta1.ExternalSec
 }
 
 func (w *WebHook) getWebhookData(ctx context.Context, provider *esv1beta1.WebhookProvider, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	if w.http == nil {
-		return nil, fmt.Errorf("http client not initialized")
-	}
 	data, err := w.getTemplateData(ctx, ref, provider.Secrets)
 	if err != nil {
 		return nil, err
 	}
-	method := provider.Method
+	return w.callWebhook(ctx, provider, provider.Method, provider.URL, provider.Body, data)
+}
+
+// callWebhook renders the request and returns the response body,
+// from the cache if the same request was already made by this client.
+func (w *WebHook) callWebhook(ctx context.Context, provider *esv1beta1.WebhookProvider, method, urlTpl, bodyTpl string, data map[string]map[string]string) ([]byte, error) {
+	if w.http == nil {
+		return nil, fmt.Errorf("http client not initialized")
+	}
+	if method == "" {
+		method = provider.Method
+	}
 	if method == "" {
 		method = http.MethodGet
 	}
-	url, err := executeTemplateString(provider.URL, data)
+	url, err := executeTemplateString(urlTpl, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse url: %w", err)
 	}
-	body, err := executeTemplate(provider.Body, data)
+	body, err := executeTemplate(bodyTpl, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse body: %w", err)
 	}
-
-	req, err := http.NewRequestWithContext(ctx, method, url, &body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	header := make(http.Header)
 	for hKey, hValueTpl := range provider.Headers {
 		hValue, err := executeTemplateString(hValueTpl, data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse header %s: %w", hKey, err)
 		}
-		req.Header.Add(hKey, hValue)
+		header.Add(hKey, hValue)
+	}
+
+	key := cacheKey(method, url, body.Bytes(), header)
+	w.responsesMutex.Lock()
+	cached, ok := w.responses[key]
+	w.responsesMutex.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	result, err := w.doRequest(ctx, provider.Retry, method, url, body.Bytes(), header)
+	if err != nil {
+		return nil, err
+	}
+	w.responsesMutex.Lock()
+	w.responses[key] = result
+	w.responsesMutex.Unlock()
+	return result, nil
+}
+
+// doRequest sends the request, retrying connection errors and responses
+// with status 429 or 5xx as configured.
+func (w *WebHook) doRequest(ctx context.Context, retry *esv1beta1.WebhookRetry, method, url string, body []byte, header http.Header) ([]byte, error) {
+	maxRetries := 0
+	backoff := defaultRetryBackoff
+	if retry != nil {
+		maxRetries = retry.MaxRetries
+		if retry.Backoff != nil {
+			backoff = retry.Backoff.Duration
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header = header.Clone()
+
+		wait := retryWait(backoff, attempt)
+		resp, err := w.http.Do(req)
+		if err != nil {
+			if attempt >= maxRetries || ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to call endpoint: %w", err)
+			}
+		} else {
+			result, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return result, readErr
+			}
+			if !isRetryable(resp.StatusCode) || attempt >= maxRetries {
+				return nil, fmt.Errorf("endpoint gave error %s", resp.Status)
+			}
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > maxRetryAfter {
+					return nil, fmt.Errorf("endpoint gave error %s, retry after %s exceeds %s", resp.Status, retryAfter, maxRetryAfter)
+				}
+				wait = retryAfter
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("failed to call endpoint: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// retryWait doubles the backoff for every attempt, up to maxRetryAfter.
+func retryWait(backoff time.Duration, attempt int) time.Duration {
+	wait := backoff
+	for i := 0; i < attempt && wait < maxRetryAfter; i++ {
+		wait *= 2
 	}
+	if wait > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return wait
+}
+
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
 
-	resp, err := w.http.Do(req)
+// parseRetryAfter reads the Retry-After header, given either as seconds or as http date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call endpoint: %w", err)
+		return 0, false
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("endpoint gave error %s", resp.Status)
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
 	}
-	return io.ReadAll(resp.Body)
+	return 0, true
+}
+
+func cacheKey(method, url string, body []byte, header http.Header) string {
+	var key strings.Builder
+	key.WriteString(method)
+	key.WriteString(" ")
+	key.WriteString(url)
+	key.WriteString("\n")
+	hKeys := make([]string, 0, len(header))
+	for hKey := range header {
+		hKeys = append(hKeys, hKey)
+	}
+	sort.Strings(hKeys)
+	for _, hKey := range hKeys {
+		key.WriteString(hKey)
+		key.WriteString(": ")
+		key.WriteString(strings.Join(header[hKey], ","))
+		key.WriteString("\n")
+	}
+	key.WriteString("\n")
+	key.Write(body)
+	return key.String()
 }
 
-func (w *WebHook) getHTTPClient(provider *esv1beta1.WebhookProvider) (*http.Client, error) {
+func (w *WebHook) getHTTPClient(ctx context.Context, provider *esv1beta1.WebhookProvider) (*http.Client, error) {
 	client := &http.Client{}
 	if provider.Timeout != nil {
 		client.Timeout = provider.Timeout.Duration
 	}
-	if len(provider.CABundle) == 0 && provider.CAProvider == nil {
-		// No need to process ca stuff if it is not there
+	if len(provider.CABundle) == 0 && provider.CAProvider == nil && provider.ClientTLS == nil {
+		// No need to process tls stuff if it is not there
 		return client, nil
 	}
-	caCertPool, err := w.getCACertPool(provider)
-	if err != nil {
-		return nil, err
-	}
 
 	tlsConf := &tls.Config{
-		RootCAs:    caCertPool,
 		MinVersion: tls.VersionTLS12,
 	}
+	if len(provider.CABundle) > 0 || provider.CAProvider != nil {
+		caCertPool, err := w.getCACertPool(provider)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.RootCAs = caCertPool
+	}
+	if provider.ClientTLS != nil {
+		cert, err := w.getClientCertificate(ctx, provider.ClientTLS)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
 	client.Transport = &http.Transport{TLSClientConfig: tlsConf}
 	return client, nil
 }
 
+func (w *WebHook) getClientCertificate(ctx context.Context, clientTLS *esv1beta1.WebhookClientTLS) (tls.Certificate, error) {
+	certSecret, err := w.getStoreSecret(ctx, clientTLS.CertSecretRef)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	cert, ok := certSecret.Data[clientTLS.CertSecretRef.Key]
+	if !ok {
+		return tls.Certificate{}, fmt.Errorf("missing key %s in client certificate secret %s", clientTLS.CertSecretRef.Key, clientTLS.CertSecretRef.Name)
+	}
+	keySecret, err := w.getStoreSecret(ctx, clientTLS.KeySecretRef)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	key, ok := keySecret.Data[clientTLS.KeySecretRef.Key]
+	if !ok {
+		return tls.Certificate{}, fmt.Errorf("missing key %s in client key secret %s", clientTLS.KeySecretRef.Key, clientTLS.KeySecretRef.Name)
+	}
+	pair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+	return pair, nil
+}
+
 func (w *WebHook) getCACertPool(provider *esv1beta1.WebhookProvider) (*x509.CertPool, error) {
 	caCertPool := x509.NewCertPool()
 	if len(provider.CABundle) > 0 {
@@ -391,6 +669,9 @@ func (w *WebHook) getCertFromConfigMap(provider *esv1beta1.WebhookProvider) ([]b
 }
 
 func (w *WebHook) Close(ctx context.Context) error {
+	w.responsesMutex.Lock()
+	w.responses = make(map[string][]byte)
+	w.responsesMutex.Unlock()
 	return nil
 }
 
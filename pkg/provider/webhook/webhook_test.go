@@ -16,18 +16,31 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 type testCase struct {
@@ -339,3 +352,311 @@ func makeClusterSecretStore(url string, args args) *esv1beta1.ClusterSecretStore
 	}
 	return store
 }
+
+func TestWebhookGetAllSecrets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if want := "/api/list?name=%5Edb-&env=prod"; req.URL.String() != want {
+			t.Errorf("unexpected api path: %s, expected %s", req.URL.String(), want)
+		}
+		rw.Write([]byte(`{"secrets":{"db-user":"admin","db-config":{"port":5432},"other":"value"}}`))
+	}))
+	defer ts.Close()
+
+	testStore := makeClusterSecretStore(ts.URL, args{})
+	testStore.Spec.Provider.Webhook.Find = &esv1beta1.WebhookFind{
+		URL: ts.URL + "/api/list?name={{ .find.name }}&env={{ .tags.env }}",
+		Result: esv1beta1.WebhookResult{
+			JSONPath: "$.secrets",
+		},
+	}
+	client, err := (&Provider{}).NewClient(context.Background(), testStore, nil, "testnamespace")
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+	secrets, err := client.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+		Name: &esv1beta1.FindName{RegExp: "^db-"},
+		Tags: map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	want := map[string][]byte{
+		"db-user":   []byte("admin"),
+		"db-config": []byte(`{"port":5432}`),
+	}
+	if !reflect.DeepEqual(secrets, want) {
+		t.Errorf("unexpected secrets: %s (expected %s)", secrets, want)
+	}
+}
+
+func TestWebhookGetAllSecretsNotConfigured(t *testing.T) {
+	testStore := makeClusterSecretStore("http://localhost", args{})
+	client, err := (&Provider{}).NewClient(context.Background(), testStore, nil, "testnamespace")
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+	_, err = client.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	if err == nil || err.Error() != "find is not configured on the webhook store" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhookResponseCache(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Write([]byte(`{"user":"admin","password":"secret"}`))
+	}))
+	defer ts.Close()
+
+	testStore := makeClusterSecretStore(ts.URL, args{URL: "/api/secrets"})
+	testStore.Spec.Provider.Webhook.Headers = nil
+	client, err := (&Provider{}).NewClient(context.Background(), testStore, nil, "testnamespace")
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+	for _, key := range []string{"user", "password"} {
+		if _, err := client.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: key}); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("unexpected number of calls: %d (expected 1)", calls)
+	}
+
+	// the cache is cleared on close
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if _, err := client.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "user"}); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("unexpected number of calls: %d (expected 2)", calls)
+	}
+}
+
+func TestWebhookRetry(t *testing.T) {
+	tbl := []struct {
+		name       string
+		retry      *esv1beta1.WebhookRetry
+		retryAfter string
+		failures   int32
+		wantCalls  int32
+		wantErr    string
+	}{
+		{
+			name:      "no retries by default",
+			failures:  1,
+			wantCalls: 1,
+			wantErr:   "endpoint gave error 503",
+		},
+		{
+			name:       "retry honoring retry-after",
+			retry:      &esv1beta1.WebhookRetry{MaxRetries: 2, Backoff: &metav1.Duration{Duration: time.Hour}},
+			retryAfter: "0",
+			failures:   2,
+			wantCalls:  3,
+		},
+		{
+			name:      "retries exhausted",
+			retry:     &esv1beta1.WebhookRetry{MaxRetries: 1, Backoff: &metav1.Duration{}},
+			failures:  2,
+			wantCalls: 2,
+			wantErr:   "endpoint gave error 503",
+		},
+		{
+			name:       "retry-after too long",
+			retry:      &esv1beta1.WebhookRetry{MaxRetries: 1},
+			retryAfter: "3600",
+			failures:   1,
+			wantCalls:  1,
+			wantErr:    "retry after 1h0m0s exceeds 30s",
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			var calls int32
+			ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= row.failures {
+					if row.retryAfter != "" {
+						rw.Header().Set("Retry-After", row.retryAfter)
+					}
+					rw.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				rw.Write([]byte("secret-value"))
+			}))
+			defer ts.Close()
+
+			testStore := makeClusterSecretStore(ts.URL, args{URL: "/api/secret"})
+			testStore.Spec.Provider.Webhook.Retry = row.retry
+			client, err := (&Provider{}).NewClient(context.Background(), testStore, nil, "testnamespace")
+			if err != nil {
+				t.Fatalf("error creating client: %s", err.Error())
+			}
+			secret, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "key"})
+			if row.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), row.wantErr) {
+					t.Errorf("unexpected error: %v (expected '%s')", err, row.wantErr)
+				}
+			} else if err != nil || string(secret) != "secret-value" {
+				t.Errorf("unexpected result: '%s', %v", secret, err)
+			}
+			if atomic.LoadInt32(&calls) != row.wantCalls {
+				t.Errorf("unexpected number of calls: %d (expected %d)", calls, row.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	tbl := []struct {
+		backoff  time.Duration
+		attempt  int
+		wantWait time.Duration
+	}{
+		{backoff: time.Second, attempt: 0, wantWait: time.Second},
+		{backoff: time.Second, attempt: 3, wantWait: 8 * time.Second},
+		{backoff: time.Second, attempt: 5, wantWait: maxRetryAfter},
+		{backoff: time.Second, attempt: 100, wantWait: maxRetryAfter},
+		{backoff: time.Hour, attempt: 0, wantWait: maxRetryAfter},
+		{backoff: 0, attempt: 100, wantWait: 0},
+	}
+	for _, row := range tbl {
+		if wait := retryWait(row.backoff, row.attempt); wait != row.wantWait {
+			t.Errorf("%s, %d: unexpected wait %s (expected %s)", row.backoff, row.attempt, wait, row.wantWait)
+		}
+	}
+}
+
+func TestWebhookValidateStoreRetry(t *testing.T) {
+	tbl := []struct {
+		retry   *esv1beta1.WebhookRetry
+		wantErr string
+	}{
+		{retry: &esv1beta1.WebhookRetry{MaxRetries: 3, Backoff: &metav1.Duration{Duration: time.Second}}},
+		{retry: &esv1beta1.WebhookRetry{MaxRetries: -1}, wantErr: "retry.maxRetries must not be negative"},
+		{retry: &esv1beta1.WebhookRetry{MaxRetries: 1000}, wantErr: "retry.maxRetries must not exceed 10"},
+		{retry: &esv1beta1.WebhookRetry{Backoff: &metav1.Duration{Duration: -time.Second}}, wantErr: "retry.backoff must not be negative"},
+	}
+	for _, row := range tbl {
+		testStore := makeClusterSecretStore("http://localhost", args{URL: "/api/secret"})
+		testStore.Spec.Provider.Webhook.Retry = row.retry
+		err := (&Provider{}).ValidateStore(testStore)
+		if row.wantErr == "" && err != nil {
+			t.Errorf("%+v: unexpected error: %s", row.retry, err)
+		}
+		if row.wantErr != "" && (err == nil || err.Error() != row.wantErr) {
+			t.Errorf("%+v: unexpected error: %v (expected '%s')", row.retry, err, row.wantErr)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tbl := []struct {
+		value    string
+		wantWait time.Duration
+		wantOk   bool
+	}{
+		{value: ""},
+		{value: "invalid"},
+		{value: "-1"},
+		{value: "5", wantWait: 5 * time.Second, wantOk: true},
+		{value: now.Add(10 * time.Second).Format(http.TimeFormat), wantWait: 10 * time.Second, wantOk: true},
+		{value: now.Add(-10 * time.Second).Format(http.TimeFormat), wantOk: true},
+	}
+	for _, row := range tbl {
+		wait, ok := parseRetryAfter(row.value, now)
+		if wait != row.wantWait || ok != row.wantOk {
+			t.Errorf("%q: unexpected result %s, %t (expected %s, %t)", row.value, wait, ok, row.wantWait, row.wantOk)
+		}
+	}
+}
+
+func TestWebhookClientTLS(t *testing.T) {
+	certPEM, keyPEM := makeClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if len(req.TLS.PeerCertificates) == 0 || req.TLS.PeerCertificates[0].Subject.CommonName != "external-secrets" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		rw.Write([]byte("secret-value"))
+	}))
+	ts.TLS = &tls.Config{
+		ClientCAs:  clientCAs,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		MinVersion: tls.VersionTLS12,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	namespace := "testnamespace"
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "client-tls",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"tls.crt": certPEM,
+			"tls.key": keyPEM,
+		},
+	}).Build()
+
+	testStore := makeClusterSecretStore(ts.URL, args{URL: "/api/secret"})
+	testStore.Spec.Provider.Webhook.CABundle = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	testStore.Spec.Provider.Webhook.ClientTLS = &esv1beta1.WebhookClientTLS{
+		CertSecretRef: esmeta.SecretKeySelector{Name: "client-tls", Key: "tls.crt", Namespace: &namespace},
+		KeySecretRef:  esmeta.SecretKeySelector{Name: "client-tls", Key: "tls.key", Namespace: &namespace},
+	}
+	client, err := (&Provider{}).NewClient(context.Background(), testStore, kube, namespace)
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+	secret, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "key"})
+	if err != nil || string(secret) != "secret-value" {
+		t.Errorf("unexpected result: '%s', %v", secret, err)
+	}
+
+	// the server rejects clients without certificate
+	testStore.Spec.Provider.Webhook.ClientTLS = nil
+	client, err = (&Provider{}).NewClient(context.Background(), testStore, kube, namespace)
+	if err != nil {
+		t.Fatalf("error creating client: %s", err.Error())
+	}
+	if _, err = client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "key"}); err == nil {
+		t.Errorf("expected error without client certificate")
+	}
+}
+
+func makeClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "external-secrets"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/certificatemanager/client"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/common"
 )
@@ -75,6 +76,14 @@ func (g *certificateManagerSecretGetter) GetSecretMap(ctx context.Context, iamTo
 	}, nil
 }
 
+func (g *certificateManagerSecretGetter) GetSecretMetadata(ctx context.Context, iamToken, resourceID, versionID string) (*common.SecretMetadata, error) {
+	return nil, fmt.Errorf("fetching metadata is not supported by Certificate Manager")
+}
+
+func (g *certificateManagerSecretGetter) GetAllSecrets(ctx context.Context, iamToken, folderID string, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, fmt.Errorf("GetAllSecrets not supported by Certificate Manager")
+}
+
 func trimAndJoin(elems ...string) string {
 	var sb strings.Builder
 	for _, elem := range elems {
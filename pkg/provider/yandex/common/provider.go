@@ -99,6 +99,7 @@ type IamToken struct {
 
 type SecretsClientInput struct {
 	APIEndpoint   string
+	FolderID      string
 	AuthorizedKey esmeta.SecretKeySelector
 	CACertificate *esmeta.SecretKeySelector
 }
@@ -177,7 +178,7 @@ func (p *YandexCloudProvider) NewClient(ctx context.Context, store esv1beta1.Gen
 		return nil, fmt.Errorf("failed to create IAM token: %w", err)
 	}
 
-	return &yandexCloudSecretsClient{secretGetter, iamToken.Token}, nil
+	return &yandexCloudSecretsClient{secretGetter, iamToken.Token, input.FolderID}, nil
 }
 
 func (p *YandexCloudProvider) getOrCreateSecretGetter(ctx context.Context, apiEndpoint string, authorizedKey *iamkey.Key, caCertificate []byte) (SecretGetter, error) {
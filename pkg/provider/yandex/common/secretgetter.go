@@ -15,10 +15,20 @@ package common
 
 import (
 	"context"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
 
 // Adapts the secrets received from a remote Yandex.Cloud service for the format expected by v1beta1.SecretsClient.
 type SecretGetter interface {
 	GetSecret(ctx context.Context, iamToken, resourceID, versionID, property string) ([]byte, error)
 	GetSecretMap(ctx context.Context, iamToken, resourceID, versionID string) (map[string][]byte, error)
+	GetSecretMetadata(ctx context.Context, iamToken, resourceID, versionID string) (*SecretMetadata, error)
+	GetAllSecrets(ctx context.Context, iamToken, folderID string, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error)
+}
+
+// Metadata of a secret version, returned instead of the secret value when MetadataPolicy is Fetch.
+type SecretMetadata struct {
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels"`
 }
@@ -15,7 +15,9 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
@@ -27,18 +29,45 @@ var _ esv1beta1.SecretsClient = &yandexCloudSecretsClient{}
 type yandexCloudSecretsClient struct {
 	secretGetter SecretGetter
 	iamToken     string
+	folderID     string
 }
 
+const (
+	descriptionProperty = "description"
+	labelsProperty      = "labels"
+	labelPropertyPrefix = "labels."
+)
+
 func (c *yandexCloudSecretsClient) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf("GetAllSecrets not supported")
+	return c.secretGetter.GetAllSecrets(ctx, c.iamToken, c.folderID, ref)
 }
 
 func (c *yandexCloudSecretsClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		metadata, err := c.secretGetter.GetSecretMetadata(ctx, c.iamToken, ref.Key, ref.Version)
+		if err != nil {
+			return nil, err
+		}
+		return getMetadataProperty(metadata, ref.Property)
+	}
 	return c.secretGetter.GetSecret(ctx, c.iamToken, ref.Key, ref.Version, ref.Property)
 }
 
 func (c *yandexCloudSecretsClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		metadata, err := c.secretGetter.GetSecretMetadata(ctx, c.iamToken, ref.Key, ref.Version)
+		if err != nil {
+			return nil, err
+		}
+		labels, err := json.Marshal(metadata.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal labels: %w", err)
+		}
+		return map[string][]byte{
+			descriptionProperty: []byte(metadata.Description),
+			labelsProperty:      labels,
+		}, nil
+	}
 	return c.secretGetter.GetSecretMap(ctx, c.iamToken, ref.Key, ref.Version)
 }
 
@@ -49,3 +78,33 @@ func (c *yandexCloudSecretsClient) Close(ctx context.Context) error {
 func (c *yandexCloudSecretsClient) Validate() (esv1beta1.ValidationResult, error) {
 	return esv1beta1.ValidationResultReady, nil
 }
+
+// Selects the given property of the metadata: the version description, all labels as JSON
+// or a single label prefixed with 'labels.'. An empty property returns the whole metadata as JSON.
+func getMetadataProperty(metadata *SecretMetadata, property string) ([]byte, error) {
+	switch {
+	case property == "":
+		out, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		return out, nil
+	case property == descriptionProperty:
+		return []byte(metadata.Description), nil
+	case property == labelsProperty:
+		out, err := json.Marshal(metadata.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal labels: %w", err)
+		}
+		return out, nil
+	case strings.HasPrefix(property, labelPropertyPrefix):
+		label := strings.TrimPrefix(property, labelPropertyPrefix)
+		value, ok := metadata.Labels[label]
+		if !ok {
+			return nil, fmt.Errorf("label '%s' not found", label)
+		}
+		return []byte(value), nil
+	default:
+		return nil, fmt.Errorf("unsupported metadata property '%s'", property)
+	}
+}
@@ -19,7 +19,10 @@ import (
 	api "github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
 )
 
-// Requests the payload of the given secret from Lockbox.
+// Requests the payload and the metadata of secrets from Lockbox.
 type LockboxClient interface {
 	GetPayloadEntries(ctx context.Context, iamToken, secretID, versionID string) ([]*api.Payload_Entry, error)
+	GetSecret(ctx context.Context, iamToken, secretID string) (*api.Secret, error)
+	ListSecrets(ctx context.Context, iamToken, folderID string) ([]*api.Secret, error)
+	ListVersions(ctx context.Context, iamToken, secretID string) ([]*api.Version, error)
 }
@@ -41,6 +41,18 @@ func (c *fakeLockboxClient) GetPayloadEntries(ctx context.Context, iamToken, sec
 	return c.fakeLockboxServer.getEntries(iamToken, secretID, versionID)
 }
 
+func (c *fakeLockboxClient) GetSecret(ctx context.Context, iamToken, secretID string) (*api.Secret, error) {
+	return c.fakeLockboxServer.getSecret(iamToken, secretID)
+}
+
+func (c *fakeLockboxClient) ListSecrets(ctx context.Context, iamToken, folderID string) ([]*api.Secret, error) {
+	return c.fakeLockboxServer.listSecrets(iamToken, folderID)
+}
+
+func (c *fakeLockboxClient) ListVersions(ctx context.Context, iamToken, secretID string) ([]*api.Version, error) {
+	return c.fakeLockboxServer.listVersions(iamToken, secretID)
+}
+
 // Fakes Yandex Lockbox service backend.
 type FakeLockboxServer struct {
 	secretMap  map[secretKey]secretValue   // secret specific data
@@ -57,6 +69,11 @@ type secretKey struct {
 
 type secretValue struct {
 	expectedAuthorizedKey *iamkey.Key // authorized key expected to access the secret
+	folderID              string
+	name                  string
+	labels                map[string]string
+	status                api.Secret_Status
+	versionIDs            []string // in order of creation, the last one is the current version
 }
 
 type versionKey struct {
@@ -65,7 +82,8 @@ type versionKey struct {
 }
 
 type versionValue struct {
-	entries []*api.Payload_Entry
+	entries     []*api.Payload_Entry
+	description string
 }
 
 type tokenKey struct {
@@ -88,12 +106,24 @@ func NewFakeLockboxServer(clock clock.Clock, tokenExpirationDuration time.Durati
 }
 
 func (s *FakeLockboxServer) CreateSecret(authorizedKey *iamkey.Key, entries ...*api.Payload_Entry) (string, string) {
+	return s.CreateSecretInFolder(authorizedKey, "", "", nil, entries...)
+}
+
+// Creates a secret which can be listed in the given folder.
+func (s *FakeLockboxServer) CreateSecretInFolder(authorizedKey *iamkey.Key, folderID, name string, labels map[string]string, entries ...*api.Payload_Entry) (string, string) {
 	secretID := uuid.NewString()
 	versionID := uuid.NewString()
 
-	s.secretMap[secretKey{secretID}] = secretValue{authorizedKey}
-	s.versionMap[versionKey{secretID, ""}] = versionValue{entries} // empty versionID corresponds to the latest version
-	s.versionMap[versionKey{secretID, versionID}] = versionValue{entries}
+	s.secretMap[secretKey{secretID}] = secretValue{
+		expectedAuthorizedKey: authorizedKey,
+		folderID:              folderID,
+		name:                  name,
+		labels:                labels,
+		status:                api.Secret_ACTIVE,
+		versionIDs:            []string{versionID},
+	}
+	s.versionMap[versionKey{secretID, ""}] = versionValue{entries: entries} // empty versionID corresponds to the latest version
+	s.versionMap[versionKey{secretID, versionID}] = versionValue{entries: entries}
 
 	return secretID, versionID
 }
@@ -101,12 +131,28 @@ func (s *FakeLockboxServer) CreateSecret(authorizedKey *iamkey.Key, entries ...*
 func (s *FakeLockboxServer) AddVersion(secretID string, entries ...*api.Payload_Entry) string {
 	versionID := uuid.NewString()
 
-	s.versionMap[versionKey{secretID, ""}] = versionValue{entries} // empty versionID corresponds to the latest version
-	s.versionMap[versionKey{secretID, versionID}] = versionValue{entries}
+	s.versionMap[versionKey{secretID, ""}] = versionValue{entries: entries} // empty versionID corresponds to the latest version
+	s.versionMap[versionKey{secretID, versionID}] = versionValue{entries: entries}
+
+	secret := s.secretMap[secretKey{secretID}]
+	secret.versionIDs = append(secret.versionIDs, versionID)
+	s.secretMap[secretKey{secretID}] = secret
 
 	return versionID
 }
 
+func (s *FakeLockboxServer) SetVersionDescription(secretID, versionID, description string) {
+	version := s.versionMap[versionKey{secretID, versionID}]
+	version.description = description
+	s.versionMap[versionKey{secretID, versionID}] = version
+}
+
+func (s *FakeLockboxServer) DeactivateSecret(secretID string) {
+	secret := s.secretMap[secretKey{secretID}]
+	secret.status = api.Secret_INACTIVE
+	s.secretMap[secretKey{secretID}] = secret
+}
+
 func (s *FakeLockboxServer) NewIamToken(authorizedKey *iamkey.Key) *common.IamToken {
 	token := uuid.NewString()
 	expiresAt := s.clock.CurrentTime().Add(s.tokenExpirationDuration)
@@ -121,16 +167,99 @@ func (s *FakeLockboxServer) getEntries(iamToken, secretID, versionID string) ([]
 	if _, ok := s.versionMap[versionKey{secretID, versionID}]; !ok {
 		return nil, fmt.Errorf("version not found")
 	}
+	if err := s.authorize(iamToken, secretID); err != nil {
+		return nil, err
+	}
+
+	return s.versionMap[versionKey{secretID, versionID}].entries, nil
+}
+
+func (s *FakeLockboxServer) getSecret(iamToken, secretID string) (*api.Secret, error) {
+	if _, ok := s.secretMap[secretKey{secretID}]; !ok {
+		return nil, fmt.Errorf("secret not found")
+	}
+	if err := s.authorize(iamToken, secretID); err != nil {
+		return nil, err
+	}
+
+	return s.toSecret(secretID), nil
+}
+
+func (s *FakeLockboxServer) listSecrets(iamToken, folderID string) ([]*api.Secret, error) {
+	if err := s.authenticate(iamToken); err != nil {
+		return nil, err
+	}
+
+	var secrets []*api.Secret
+	for key, secret := range s.secretMap {
+		if secret.folderID != folderID || s.authorize(iamToken, key.secretID) != nil {
+			continue
+		}
+		secrets = append(secrets, s.toSecret(key.secretID))
+	}
+	return secrets, nil
+}
+
+func (s *FakeLockboxServer) listVersions(iamToken, secretID string) ([]*api.Version, error) {
+	if _, ok := s.secretMap[secretKey{secretID}]; !ok {
+		return nil, fmt.Errorf("secret not found")
+	}
+	if err := s.authorize(iamToken, secretID); err != nil {
+		return nil, err
+	}
+
+	versionIDs := s.secretMap[secretKey{secretID}].versionIDs
+	versions := make([]*api.Version, 0, len(versionIDs))
+	for _, versionID := range versionIDs {
+		versions = append(versions, s.toVersion(secretID, versionID))
+	}
+	return versions, nil
+}
+
+func (s *FakeLockboxServer) toSecret(secretID string) *api.Secret {
+	secret := s.secretMap[secretKey{secretID}]
+	return &api.Secret{
+		Id:             secretID,
+		FolderId:       secret.folderID,
+		Name:           secret.name,
+		Labels:         secret.labels,
+		Status:         secret.status,
+		CurrentVersion: s.toVersion(secretID, secret.versionIDs[len(secret.versionIDs)-1]),
+	}
+}
+
+func (s *FakeLockboxServer) toVersion(secretID, versionID string) *api.Version {
+	version := s.versionMap[versionKey{secretID, versionID}]
+	entryKeys := make([]string, 0, len(version.entries))
+	for _, entry := range version.entries {
+		entryKeys = append(entryKeys, entry.Key)
+	}
+	return &api.Version{
+		Id:               versionID,
+		SecretId:         secretID,
+		Description:      version.description,
+		Status:           api.Version_ACTIVE,
+		PayloadEntryKeys: entryKeys,
+	}
+}
+
+func (s *FakeLockboxServer) authenticate(iamToken string) error {
 	if _, ok := s.tokenMap[tokenKey{iamToken}]; !ok {
-		return nil, fmt.Errorf("unauthenticated")
+		return fmt.Errorf("unauthenticated")
 	}
 
 	if s.tokenMap[tokenKey{iamToken}].expiresAt.Before(s.clock.CurrentTime()) {
-		return nil, fmt.Errorf("iam token expired")
+		return fmt.Errorf("iam token expired")
+	}
+	return nil
+}
+
+func (s *FakeLockboxServer) authorize(iamToken, secretID string) error {
+	if err := s.authenticate(iamToken); err != nil {
+		return err
 	}
 	if !cmp.Equal(s.tokenMap[tokenKey{iamToken}].authorizedKey, s.secretMap[secretKey{secretID}].expectedAuthorizedKey, cmpopts.IgnoreUnexported(iamkey.Key{})) {
-		return nil, fmt.Errorf("permission denied")
+		return fmt.Errorf("permission denied")
 	}
-
-	return s.versionMap[versionKey{secretID, versionID}].entries, nil
+	return nil
 }
@@ -26,6 +26,7 @@ import (
 // Real/gRPC implementation of LockboxClient.
 type grpcLockboxClient struct {
 	lockboxPayloadClient api.PayloadServiceClient
+	lockboxSecretClient  api.SecretServiceClient
 }
 
 func NewGrpcLockboxClient(ctx context.Context, apiEndpoint string, authorizedKey *iamkey.Key, caCertificate []byte) (LockboxClient, error) {
@@ -39,7 +40,17 @@ func NewGrpcLockboxClient(ctx context.Context, apiEndpoint string, authorizedKey
 	if err != nil {
 		return nil, err
 	}
-	return &grpcLockboxClient{api.NewPayloadServiceClient(conn)}, nil
+	secretConn, err := common.NewGrpcConnection(
+		ctx,
+		apiEndpoint,
+		"lockbox", // taken from https://api.cloud.yandex.net/endpoints
+		authorizedKey,
+		caCertificate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcLockboxClient{api.NewPayloadServiceClient(conn), api.NewSecretServiceClient(secretConn)}, nil
 }
 
 func (c *grpcLockboxClient) GetPayloadEntries(ctx context.Context, iamToken, secretID, versionID string) ([]*api.Payload_Entry, error) {
@@ -56,3 +67,59 @@ func (c *grpcLockboxClient) GetPayloadEntries(ctx context.Context, iamToken, sec
 	}
 	return payload.Entries, nil
 }
+
+func (c *grpcLockboxClient) GetSecret(ctx context.Context, iamToken, secretID string) (*api.Secret, error) {
+	return c.lockboxSecretClient.Get(
+		ctx,
+		&api.GetSecretRequest{
+			SecretId: secretID,
+		},
+		grpc.PerRPCCredentials(common.PerRPCCredentials{IamToken: iamToken}),
+	)
+}
+
+func (c *grpcLockboxClient) ListSecrets(ctx context.Context, iamToken, folderID string) ([]*api.Secret, error) {
+	var secrets []*api.Secret
+	pageToken := ""
+	for {
+		response, err := c.lockboxSecretClient.List(
+			ctx,
+			&api.ListSecretsRequest{
+				FolderId:  folderID,
+				PageToken: pageToken,
+			},
+			grpc.PerRPCCredentials(common.PerRPCCredentials{IamToken: iamToken}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, response.Secrets...)
+		if response.NextPageToken == "" {
+			return secrets, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
+
+func (c *grpcLockboxClient) ListVersions(ctx context.Context, iamToken, secretID string) ([]*api.Version, error) {
+	var versions []*api.Version
+	pageToken := ""
+	for {
+		response, err := c.lockboxSecretClient.ListVersions(
+			ctx,
+			&api.ListVersionsRequest{
+				SecretId:  secretID,
+				PageToken: pageToken,
+			},
+			grpc.PerRPCCredentials(common.PerRPCCredentials{IamToken: iamToken}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, response.Versions...)
+		if response.NextPageToken == "" {
+			return versions, nil
+		}
+		pageToken = response.NextPageToken
+	}
+}
@@ -602,6 +602,150 @@ func TestGetSecretMapByVersionID(t *testing.T) {
 	tassert.Equal(t, map[string][]byte{newKey: []byte(newVal)}, data)
 }
 
+func TestGetAllSecrets(t *testing.T) {
+	ctx := context.Background()
+	namespace := uuid.NewString()
+	authorizedKey := newFakeAuthorizedKey()
+	otherAuthorizedKey := newFakeAuthorizedKey()
+	const folderID = "folderID"
+
+	fakeClock := clock.NewFakeClock()
+	fakeLockboxServer := client.NewFakeLockboxServer(fakeClock, time.Hour)
+	fakeLockboxServer.CreateSecretInFolder(authorizedKey, folderID, "db-main", map[string]string{"env": "prod"},
+		textEntry("user", "admin"),
+		binaryEntry("password", []byte("secret")),
+	)
+	fakeLockboxServer.CreateSecretInFolder(authorizedKey, folderID, "db-replica", map[string]string{"env": "dev"},
+		textEntry("user", "reader"),
+	)
+	inactiveSecretID, _ := fakeLockboxServer.CreateSecretInFolder(authorizedKey, folderID, "db-inactive", map[string]string{"env": "prod"},
+		textEntry("user", "inactive"),
+	)
+	fakeLockboxServer.DeactivateSecret(inactiveSecretID)
+	fakeLockboxServer.CreateSecretInFolder(authorizedKey, "otherFolderID", "db-other-folder", map[string]string{"env": "prod"},
+		textEntry("user", "other-folder"),
+	)
+	fakeLockboxServer.CreateSecretInFolder(otherAuthorizedKey, folderID, "db-forbidden", map[string]string{"env": "prod"},
+		textEntry("user", "forbidden"),
+	)
+
+	k8sClient := clientfake.NewClientBuilder().Build()
+	const authorizedKeySecretName = "authorizedKeySecretName"
+	const authorizedKeySecretKey = "authorizedKeySecretKey"
+	err := createK8sSecret(ctx, t, k8sClient, namespace, authorizedKeySecretName, authorizedKeySecretKey, toJSON(t, authorizedKey))
+	tassert.Nil(t, err)
+	store := newYandexLockboxSecretStore("", namespace, authorizedKeySecretName, authorizedKeySecretKey)
+	store.GetSpec().Provider.YandexLockbox.FolderID = folderID
+
+	provider := newLockboxProvider(fakeClock, fakeLockboxServer)
+	secretsClient, err := provider.NewClient(ctx, store, k8sClient, namespace)
+	tassert.Nil(t, err)
+
+	data, err := secretsClient.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^db-"}, ConversionStrategy: esv1beta1.ExternalSecretConversionDefault})
+	tassert.Nil(t, err)
+	tassert.Equal(
+		t,
+		map[string][]byte{
+			"db-main_user":     []byte("admin"),
+			"db-main_password": []byte("secret"),
+			"db-replica_user":  []byte("reader"),
+		},
+		data,
+	)
+
+	data, err = secretsClient.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}, ConversionStrategy: esv1beta1.ExternalSecretConversionDefault})
+	tassert.Nil(t, err)
+	tassert.Equal(
+		t,
+		map[string][]byte{
+			"db-main_user":     []byte("admin"),
+			"db-main_password": []byte("secret"),
+		},
+		data,
+	)
+
+	data, err = secretsClient.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "replica$"}, Tags: map[string]string{"env": "prod"}})
+	tassert.Nil(t, err)
+	tassert.Empty(t, data)
+
+	path := "path"
+	_, err = secretsClient.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: &path})
+	tassert.EqualError(t, err, "find by path is not supported by Yandex Lockbox")
+}
+
+func TestGetAllSecretsWithoutFolderID(t *testing.T) {
+	ctx := context.Background()
+	namespace := uuid.NewString()
+	authorizedKey := newFakeAuthorizedKey()
+
+	fakeClock := clock.NewFakeClock()
+	fakeLockboxServer := client.NewFakeLockboxServer(fakeClock, time.Hour)
+
+	k8sClient := clientfake.NewClientBuilder().Build()
+	const authorizedKeySecretName = "authorizedKeySecretName"
+	const authorizedKeySecretKey = "authorizedKeySecretKey"
+	err := createK8sSecret(ctx, t, k8sClient, namespace, authorizedKeySecretName, authorizedKeySecretKey, toJSON(t, authorizedKey))
+	tassert.Nil(t, err)
+	store := newYandexLockboxSecretStore("", namespace, authorizedKeySecretName, authorizedKeySecretKey)
+
+	provider := newLockboxProvider(fakeClock, fakeLockboxServer)
+	secretsClient, err := provider.NewClient(ctx, store, k8sClient, namespace)
+	tassert.Nil(t, err)
+
+	_, err = secretsClient.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}})
+	tassert.EqualError(t, err, "folderID must be set on the SecretStore to find secrets")
+}
+
+func TestGetSecretMetadata(t *testing.T) {
+	ctx := context.Background()
+	namespace := uuid.NewString()
+	authorizedKey := newFakeAuthorizedKey()
+
+	fakeClock := clock.NewFakeClock()
+	fakeLockboxServer := client.NewFakeLockboxServer(fakeClock, time.Hour)
+	secretID, oldVersionID := fakeLockboxServer.CreateSecretInFolder(authorizedKey, "folderID", "name", map[string]string{"env": "prod", "team": "core"},
+		textEntry("k1", "v1"),
+	)
+	fakeLockboxServer.SetVersionDescription(secretID, oldVersionID, "initial")
+	newVersionID := fakeLockboxServer.AddVersion(secretID, textEntry("k1", "v2"))
+	fakeLockboxServer.SetVersionDescription(secretID, newVersionID, "rotated")
+
+	k8sClient := clientfake.NewClientBuilder().Build()
+	const authorizedKeySecretName = "authorizedKeySecretName"
+	const authorizedKeySecretKey = "authorizedKeySecretKey"
+	err := createK8sSecret(ctx, t, k8sClient, namespace, authorizedKeySecretName, authorizedKeySecretKey, toJSON(t, authorizedKey))
+	tassert.Nil(t, err)
+	store := newYandexLockboxSecretStore("", namespace, authorizedKeySecretName, authorizedKeySecretKey)
+
+	provider := newLockboxProvider(fakeClock, fakeLockboxServer)
+	secretsClient, err := provider.NewClient(ctx, store, k8sClient, namespace)
+	tassert.Nil(t, err)
+
+	fetch := esv1beta1.ExternalSecretMetadataPolicyFetch
+	data, err := secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: secretID, MetadataPolicy: fetch})
+	tassert.Nil(t, err)
+	tassert.JSONEq(t, `{"description":"rotated","labels":{"env":"prod","team":"core"}}`, string(data))
+
+	data, err = secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: secretID, Version: oldVersionID, MetadataPolicy: fetch, Property: "description"})
+	tassert.Nil(t, err)
+	tassert.Equal(t, "initial", string(data))
+
+	data, err = secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: secretID, MetadataPolicy: fetch, Property: "labels.team"})
+	tassert.Nil(t, err)
+	tassert.Equal(t, "core", string(data))
+
+	_, err = secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: secretID, MetadataPolicy: fetch, Property: "labels.missing"})
+	tassert.EqualError(t, err, "label 'missing' not found")
+
+	_, err = secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: secretID, Version: "missing", MetadataPolicy: fetch})
+	tassert.EqualError(t, err, "version 'missing' not found")
+
+	dataMap, err := secretsClient.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: secretID, MetadataPolicy: fetch})
+	tassert.Nil(t, err)
+	tassert.Equal(t, "rotated", string(dataMap["description"]))
+	tassert.JSONEq(t, `{"env":"prod","team":"core"}`, string(dataMap["labels"]))
+}
+
 // helper functions
 
 func newLockboxProvider(clock clock.Clock, fakeLockboxServer *client.FakeLockboxServer) *common.YandexCloudProvider {
@@ -20,8 +20,11 @@ import (
 
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
 
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/common"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/lockbox/client"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 // Implementation of common.SecretGetter.
@@ -81,6 +84,103 @@ func (g *lockboxSecretGetter) GetSecretMap(ctx context.Context, iamToken, resour
 	return secretMap, nil
 }
 
+func (g *lockboxSecretGetter) GetSecretMetadata(ctx context.Context, iamToken, resourceID, versionID string) (*common.SecretMetadata, error) {
+	secret, err := g.lockboxClient.GetSecret(ctx, iamToken, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to request secret to get metadata: %w", err)
+	}
+
+	version := secret.CurrentVersion
+	if versionID != "" {
+		versions, err := g.lockboxClient.ListVersions(ctx, iamToken, resourceID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to request secret versions to get metadata: %w", err)
+		}
+		version = findVersionByID(versions, versionID)
+	}
+	if version == nil {
+		return nil, fmt.Errorf("version '%s' not found", versionID)
+	}
+
+	labels := make(map[string]string, len(secret.Labels))
+	for k, v := range secret.Labels {
+		labels[k] = v
+	}
+	return &common.SecretMetadata{
+		Description: version.Description,
+		Labels:      labels,
+	}, nil
+}
+
+// Returns the payload entries of the active secrets in the folder matching the given name and labels.
+// Every entry is returned under the key '<secret name>/<entry key>'.
+func (g *lockboxSecretGetter) GetAllSecrets(ctx context.Context, iamToken, folderID string, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if folderID == "" {
+		return nil, fmt.Errorf("folderID must be set on the SecretStore to find secrets")
+	}
+	if ref.Path != nil {
+		return nil, fmt.Errorf("find by path is not supported by Yandex Lockbox")
+	}
+
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+
+	secrets, err := g.lockboxClient.ListSecrets(ctx, iamToken, folderID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list secrets in folder '%s': %w", folderID, err)
+	}
+
+	secretMap := make(map[string][]byte)
+	for _, secret := range secrets {
+		if secret.Status != lockbox.Secret_ACTIVE || secret.CurrentVersion == nil {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(secret.Name) {
+			continue
+		}
+		if !hasLabels(secret.Labels, ref.Tags) {
+			continue
+		}
+
+		entries, err := g.lockboxClient.GetPayloadEntries(ctx, iamToken, secret.Id, "")
+		if err != nil {
+			return nil, fmt.Errorf("unable to request secret payload of '%s': %w", secret.Name, err)
+		}
+		for _, entry := range entries {
+			value, err := getValueAsBinary(entry)
+			if err != nil {
+				return nil, err
+			}
+			secretMap[secret.Name+"/"+entry.Key] = value
+		}
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, secretMap)
+}
+
+func hasLabels(secretLabels, labels map[string]string) bool {
+	for k, v := range labels {
+		if val, ok := secretLabels[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
+func findVersionByID(versions []*lockbox.Version, versionID string) *lockbox.Version {
+	for i := range versions {
+		if versions[i].Id == versionID {
+			return versions[i]
+		}
+	}
+	return nil
+}
+
 func getValueAsIs(entry *lockbox.Payload_Entry) (interface{}, error) {
 	switch entry.Value.(type) {
 	case *lockbox.Payload_Entry_TextValue:
@@ -18,16 +18,27 @@ import (
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	v1 "github.com/external-secrets/external-secrets/pkg/template/v1"
 	v2 "github.com/external-secrets/external-secrets/pkg/template/v2"
+	v3 "github.com/external-secrets/external-secrets/pkg/template/v3"
 )
 
 type ExecFunc func(tpl, data map[string][]byte, secret *corev1.Secret) error
 
-func EngineForVersion(version esapi.TemplateEngineVersion) (ExecFunc, error) {
+// Metadata is the context of a template beyond the secret data,
+// only the v3 engine passes it to the templates.
+type Metadata struct {
+	ExternalSecret v3.Metadata
+	Tags           map[string]map[string]string
+}
+
+func EngineForVersion(version esapi.TemplateEngineVersion, metadata Metadata) (ExecFunc, error) {
 	switch version {
 	case esapi.TemplateEngineV1:
 		return v1.Execute, nil
 	case esapi.TemplateEngineV2:
 		return v2.Execute, nil
+	case esapi.TemplateEngineV3:
+		engine := &v3.Engine{Metadata: metadata.ExternalSecret, Tags: metadata.Tags}
+		return engine.Execute, nil
 	}
 
 	// in case we run with a old v1alpha1 CRD
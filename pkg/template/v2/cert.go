@@ -0,0 +1,95 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+)
+
+const (
+	errNoPEMBlock = "no pem block found"
+)
+
+// derToPem encodes DER data as PEM block of the type, e.g: CERTIFICATE.
+func derToPem(pemType, input string) (string, error) {
+	return pemEncode(input, pemType)
+}
+
+// pemToDer returns the DER data of the first PEM block.
+func pemToDer(input string) (string, error) {
+	block, _ := pem.Decode([]byte(input))
+	if block == nil {
+		return "", errors.New(errNoPEMBlock)
+	}
+	return string(block.Bytes), nil
+}
+
+// certExpiry returns the end of the validity of the certificate, use it
+// with date to format it, e.g: {{ .cert | certExpiry | date "2006-01-02" }}.
+func certExpiry(input string) (time.Time, error) {
+	cert, err := parseCertificate(input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+// certSubject returns the subject of the certificate, e.g: CN=foo,O=Acme.
+func certSubject(input string) (string, error) {
+	cert, err := parseCertificate(input)
+	if err != nil {
+		return "", err
+	}
+	return cert.Subject.String(), nil
+}
+
+// certSANs returns the DNS names, IP addresses, email addresses and URIs of the certificate.
+func certSANs(input string) ([]string, error) {
+	cert, err := parseCertificate(input)
+	if err != nil {
+		return nil, err
+	}
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.EmailAddresses)+len(cert.URIs))
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans, nil
+}
+
+// parseCertificate parses the first PEM encoded certificate, or the input as DER
+// if it is not PEM encoded.
+func parseCertificate(input string) (*x509.Certificate, error) {
+	data := []byte(input)
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		return x509.ParseCertificate(data)
+	}
+	_, certs, err := parseKeyAndCerts(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New(errNoCertificate)
+	}
+	return certs[0], nil
+}
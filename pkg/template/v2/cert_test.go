@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDerToPem(t *testing.T) {
+	cert := readTestdata(t, leafCertPath)
+	der, err := pemToDer(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := derToPem(pemTypeCertificate, der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(cert, got); diff != "" {
+		t.Errorf("derToPem(pemToDer()) = diff:\n%s", diff)
+	}
+	if _, err := pemToDer("not pem"); !ErrorContains(err, errNoPEMBlock) {
+		t.Errorf("pemToDer() error = %v, wantErr %v", err, errNoPEMBlock)
+	}
+}
+
+func TestCertificateInfo(t *testing.T) {
+	cert := readTestdata(t, chainPath)
+	der, err := pemToDer(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, input := range map[string]string{"pem": cert, "der": der} {
+		t.Run(name, func(t *testing.T) {
+			expiry, err := certExpiry(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := time.Date(2022, 2, 10, 10, 25, 31, 0, time.UTC); !expiry.Equal(want) {
+				t.Errorf("certExpiry() = %v, want %v", expiry, want)
+			}
+			subject, err := certSubject(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if subject != "CN=foo" {
+				t.Errorf("certSubject() = %q, want %q", subject, "CN=foo")
+			}
+			sans, err := certSANs(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff([]string{"foo"}, sans); diff != "" {
+				t.Errorf("certSANs() = diff:\n%s", diff)
+			}
+		})
+	}
+	if _, err := certSubject(readTestdata(t, leafKeyPath)); !ErrorContains(err, errNoCertificate) {
+		t.Errorf("certSubject() error = %v, wantErr %v", err, errNoCertificate)
+	}
+}
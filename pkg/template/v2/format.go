@@ -0,0 +1,475 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/ini.v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	errUnsupportedInput = "unsupported input type %T, expected a map"
+	errNonScalarValue   = "value of key %s is not a scalar"
+	errInvalidEnvKey    = "invalid env key %q"
+	errInvalidINIValue  = "value of key %s can not be represented in ini"
+	errParseEnv         = "unable to parse env at line %d: %s"
+	errParseProperties  = "unable to parse properties: malformed \\uxxxx encoding in %q"
+	errParseINI         = "unable to parse ini: %w"
+	errParseTOML        = "unable to parse toml: %w"
+	errParseYAML        = "unable to parse yaml: %w"
+)
+
+var envKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// toEnv returns the map as .env file. Values are single quoted, or double quoted
+// with escape sequences if they contain a single quote or line breaks.
+func toEnv(input interface{}) (string, error) {
+	values, err := toStringMap(input)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	for _, k := range sortedKeys(values) {
+		if !envKeyRegex.MatchString(k) {
+			return "", fmt.Errorf(errInvalidEnvKey, k)
+		}
+		fmt.Fprintf(buf, "%s=%s\n", k, quoteEnv(values[k]))
+	}
+	return buf.String(), nil
+}
+
+func quoteEnv(val string) string {
+	if !strings.ContainsAny(val, "'\n\r") {
+		return "'" + val + "'"
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`, "`", "\\`")
+	return `"` + r.Replace(val) + `"`
+}
+
+// fromEnv parses a .env file. Variables are not expanded.
+func fromEnv(input string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		idx := strings.Index(line, "=")
+		if idx < 0 {
+			return nil, fmt.Errorf(errParseEnv, lineNo, "missing =")
+		}
+		key := strings.TrimSpace(line[:idx])
+		if !envKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf(errParseEnv, lineNo, fmt.Sprintf("invalid key %q", key))
+		}
+		val := strings.TrimSpace(line[idx+1:])
+		if val == "" || (val[0] != '\'' && val[0] != '"') {
+			// unquoted values end at an inline comment
+			if idx := strings.Index(val, " #"); idx >= 0 {
+				val = strings.TrimSpace(val[:idx])
+			}
+			out[key] = val
+			continue
+		}
+		// quoted values may span multiple lines
+		quote := val[0]
+		val = val[1:]
+		for closingQuote(val, quote) < 0 {
+			i++
+			if i >= len(lines) {
+				return nil, fmt.Errorf(errParseEnv, lineNo, "missing closing quote")
+			}
+			val += "\n" + lines[i]
+		}
+		val = val[:closingQuote(val, quote)]
+		if quote == '"' {
+			val = unescapeEnv(val)
+		}
+		out[key] = val
+	}
+	return out, nil
+}
+
+// closingQuote returns the index of the closing quote, escaped quotes are skipped in double quoted values.
+func closingQuote(val string, quote byte) int {
+	for i := 0; i < len(val); i++ {
+		if quote == '"' && val[i] == '\\' {
+			i++
+			continue
+		}
+		if val[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeEnv(val string) string {
+	var sb strings.Builder
+	for i := 0; i < len(val); i++ {
+		if val[i] != '\\' || i+1 == len(val) {
+			sb.WriteByte(val[i])
+			continue
+		}
+		i++
+		switch val[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '\\', '"', '$', '`', '\'':
+			sb.WriteByte(val[i])
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(val[i])
+		}
+	}
+	return sb.String()
+}
+
+// toProperties returns the map as Java .properties file, non ASCII characters are escaped.
+func toProperties(input interface{}) (string, error) {
+	values, err := toStringMap(input)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	for _, k := range sortedKeys(values) {
+		fmt.Fprintf(buf, "%s=%s\n", escapeProperty(k, true), escapeProperty(values[k], false))
+	}
+	return buf.String(), nil
+}
+
+// escapeProperty escapes like java.util.Properties.store does.
+func escapeProperty(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\', '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(' ')
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			if r >= 0x20 && r <= 0x7e {
+				sb.WriteRune(r)
+				continue
+			}
+			for _, c := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&sb, `\u%04X`, c)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// fromProperties parses a Java .properties file.
+func fromProperties(input string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// join continuation lines, they end with an odd number of backslashes
+		for endsWithEscape(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		key, val := splitProperty(line)
+		k, err := unescapeProperty(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := unescapeProperty(val)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+func endsWithEscape(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits the line at the first unescaped separator: '=', ':' or whitespace.
+func splitProperty(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return line[:i], strings.TrimLeft(line[i+1:], " \t\f")
+		case ' ', '\t', '\f':
+			val := strings.TrimLeft(line[i:], " \t\f")
+			if val != "" && (val[0] == '=' || val[0] == ':') {
+				val = strings.TrimLeft(val[1:], " \t\f")
+			}
+			return line[:i], val
+		}
+	}
+	return line, ""
+}
+
+func unescapeProperty(s string) (string, error) {
+	var sb strings.Builder
+	var surrogates []uint16
+	flush := func() {
+		if len(surrogates) > 0 {
+			sb.WriteString(string(utf16.Decode(surrogates)))
+			surrogates = nil
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			flush()
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		if s[i] == 'u' {
+			if i+5 > len(s) {
+				return "", fmt.Errorf(errParseProperties, s)
+			}
+			c, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf(errParseProperties, s)
+			}
+			surrogates = append(surrogates, uint16(c))
+			i += 4
+			continue
+		}
+		flush()
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	flush()
+	return sb.String(), nil
+}
+
+// toINI returns the map as INI file. Values that are maps are written as sections.
+func toINI(input interface{}) (string, error) {
+	values, err := toInterfaceMap(input)
+	if err != nil {
+		return "", err
+	}
+	f := ini.Empty()
+	var sections []string
+	for _, k := range sortedKeys(values) {
+		if _, ok := values[k].(map[string]interface{}); ok {
+			sections = append(sections, k)
+			continue
+		}
+		if err := setINIKey(f.Section(""), k, values[k]); err != nil {
+			return "", err
+		}
+	}
+	for _, name := range sections {
+		section, err := f.NewSection(name)
+		if err != nil {
+			return "", err
+		}
+		keys := values[name].(map[string]interface{})
+		for _, k := range sortedKeys(keys) {
+			if err := setINIKey(section, k, keys[k]); err != nil {
+				return "", err
+			}
+		}
+	}
+	buf := &bytes.Buffer{}
+	if _, err := f.WriteTo(buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func setINIKey(section *ini.Section, k string, v interface{}) error {
+	val, err := scalarString(k, v)
+	if err != nil {
+		return err
+	}
+	// ini has no escaping, multi-line values are surrounded by """
+	if strings.Contains(val, `"""`) && strings.ContainsAny(val, "\n`") {
+		return fmt.Errorf(errInvalidINIValue, k)
+	}
+	_, err = section.NewKey(k, val)
+	return err
+}
+
+// fromINI parses an INI file. Keys of the default section are top-level, other sections are maps.
+func fromINI(input string) (map[string]interface{}, error) {
+	f, err := ini.LoadSources(ini.LoadOptions{IgnoreContinuation: true}, []byte(input))
+	if err != nil {
+		return nil, fmt.Errorf(errParseINI, err)
+	}
+	out := make(map[string]interface{})
+	for _, section := range f.Sections() {
+		keys := out
+		if section.Name() != ini.DefaultSection {
+			keys = make(map[string]interface{})
+			out[section.Name()] = keys
+		}
+		for _, key := range section.Keys() {
+			keys[key.Name()] = key.Value()
+		}
+	}
+	return out, nil
+}
+
+// toTOML returns the map as TOML document, maps are written as tables.
+func toTOML(input interface{}) (string, error) {
+	values, err := toInterfaceMap(input)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(values); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// fromTOML parses a TOML document.
+func fromTOML(input string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	if _, err := toml.Decode(input, &out); err != nil {
+		return nil, fmt.Errorf(errParseTOML, err)
+	}
+	return out, nil
+}
+
+// toYAML returns the input as YAML document.
+func toYAML(input interface{}) (string, error) {
+	out, err := yaml.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// fromYAML parses a YAML document, the values have the same types as with fromJson.
+func fromYAML(input string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(input), &out); err != nil {
+		return nil, fmt.Errorf(errParseYAML, err)
+	}
+	return out, nil
+}
+
+// toInterfaceMap converts the template data or a parsed document to a map.
+func toInterfaceMap(input interface{}) (map[string]interface{}, error) {
+	switch m := input.(type) {
+	case map[string]interface{}:
+		return m, nil
+	case map[string]string:
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			out[k] = v
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf(errUnsupportedInput, input)
+	}
+}
+
+// toStringMap converts the input to a flat map, values must be scalars.
+func toStringMap(input interface{}) (map[string]string, error) {
+	if m, ok := input.(map[string]string); ok {
+		return m, nil
+	}
+	m, err := toInterfaceMap(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		s, err := scalarString(k, v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = s
+	}
+	return out, nil
+}
+
+func scalarString(k string, v interface{}) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case bool, int, int64, float64:
+		return fmt.Sprint(val), nil
+	default:
+		return "", fmt.Errorf(errNonScalarValue, k)
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch val := m.(type) {
+	case map[string]string:
+		for k := range val {
+			keys = append(keys, k)
+		}
+	case map[string]interface{}:
+		for k := range val {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,222 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package template
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// formatData contains values that need quoting or escaping in most formats.
+var formatData = map[string]string{
+	"user":      "admin",
+	"password":  `p@ss w0rd'"$HOME\#;!=:`,
+	"multiline": "line1\nline2\r\n  indented",
+	"unicode":   "grüße 🔑",
+	"number":    "007",
+	"empty":     "",
+	"spaces":    "  padded  ",
+	"tls.crt":   otherCert,
+}
+
+func TestToEnv(t *testing.T) {
+	got, err := toEnv(map[string]string{
+		"USER":     "admin",
+		"PASSWORD": `it's "$HOME"`,
+		"EMPTY":    "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `EMPTY=''
+PASSWORD="it's \"\$HOME\""
+USER='admin'
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("toEnv() = diff:\n%s", diff)
+	}
+	if _, err := toEnv(map[string]string{"my key": "foo"}); !ErrorContains(err, `invalid env key "my key"`) {
+		t.Errorf("toEnv() unexpected error: %v", err)
+	}
+	if _, err := toEnv(map[string]interface{}{"nested": map[string]interface{}{}}); !ErrorContains(err, "value of key nested is not a scalar") {
+		t.Errorf("toEnv() unexpected error: %v", err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	got, err := fromEnv(`# comment
+export USER=admin # inline comment
+SINGLE='no \n escapes $HOME'
+DOUBLE="tab\tquote\"dollar\$"
+MULTI="line1
+line2"
+ SPACES =  trimmed  
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"USER":   "admin",
+		"SINGLE": `no \n escapes $HOME`,
+		"DOUBLE": "tab\tquote\"dollar$",
+		"MULTI":  "line1\nline2",
+		"SPACES": "trimmed",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fromEnv() = diff:\n%s", diff)
+	}
+	if _, err := fromEnv("FOO=\"unterminated\n"); !ErrorContains(err, "unable to parse env at line 1: missing closing quote") {
+		t.Errorf("fromEnv() unexpected error: %v", err)
+	}
+	if _, err := fromEnv("FOO\n"); !ErrorContains(err, "unable to parse env at line 1: missing =") {
+		t.Errorf("fromEnv() unexpected error: %v", err)
+	}
+}
+
+func TestToProperties(t *testing.T) {
+	got, err := toProperties(map[string]string{
+		"db.url":   "jdbc:postgresql://db:5432/app",
+		"my key":   " grüße\n",
+		"password": "a=b#c!d\\",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `db.url=jdbc\:postgresql\://db\:5432/app
+my\ key=\ gr\u00FC\u00DFe\n
+password=a\=b\#c\!d\\
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("toProperties() = diff:\n%s", diff)
+	}
+}
+
+func TestFromProperties(t *testing.T) {
+	got, err := fromProperties(`# comment
+! another comment
+db.url = jdbc:postgresql://db:5432/app
+user:admin
+name   value with spaces
+long = first, \
+       second
+unicode=ü🔑
+empty
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"db.url":  "jdbc:postgresql://db:5432/app",
+		"user":    "admin",
+		"name":    "value with spaces",
+		"long":    "first, second",
+		"unicode": "ü🔑",
+		"empty":   "",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fromProperties() = diff:\n%s", diff)
+	}
+	if _, err := fromProperties(`key=\u12`); !ErrorContains(err, "malformed \\uxxxx encoding") {
+		t.Errorf("fromProperties() unexpected error: %v", err)
+	}
+}
+
+func TestToINI(t *testing.T) {
+	got, err := toINI(map[string]interface{}{
+		"user": "admin",
+		"database": map[string]interface{}{
+			"host": "db",
+			"port": float64(5432),
+			"pass": "a;b#c",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "user = admin\n\n[database]\nhost = db\npass = `a;b#c`\nport = 5432\n\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("toINI() = diff:\n%s", diff)
+	}
+	if _, err := toINI(map[string]string{"bad": "\"\"\"\n"}); !ErrorContains(err, "value of key bad can not be represented in ini") {
+		t.Errorf("toINI() unexpected error: %v", err)
+	}
+}
+
+func TestToTOML(t *testing.T) {
+	got, err := toTOML(map[string]interface{}{
+		"user":   "admin",
+		"my key": "multi\nline",
+		"database": map[string]interface{}{
+			"port": float64(5432),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\"my key\" = \"multi\\nline\"\nuser = \"admin\"\n\n[database]\n  port = 5432.0\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("toTOML() = diff:\n%s", diff)
+	}
+	if _, err := fromTOML("key = "); !ErrorContains(err, "unable to parse toml") {
+		t.Errorf("fromTOML() unexpected error: %v", err)
+	}
+}
+
+func TestToYAML(t *testing.T) {
+	got, err := toYAML(map[string]string{"user": "admin", "number": "007", "multi": "a\nb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "multi: |-\n  a\n  b\nnumber: \"007\"\nuser: admin\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("toYAML() = diff:\n%s", diff)
+	}
+	if _, err := fromYAML("- not a map"); !ErrorContains(err, "unable to parse yaml") {
+		t.Errorf("fromYAML() unexpected error: %v", err)
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	want := make(map[string]interface{}, len(formatData))
+	for k, v := range formatData {
+		want[k] = v
+	}
+	tests := []struct {
+		name string
+		to   func(interface{}) (string, error)
+		from func(string) (map[string]interface{}, error)
+	}{
+		{name: "env", to: toEnv, from: fromEnv},
+		{name: "properties", to: toProperties, from: fromProperties},
+		{name: "ini", to: toINI, from: fromINI},
+		{name: "toml", to: toTOML, from: fromTOML},
+		{name: "yaml", to: toYAML, from: fromYAML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.to(formatData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.from(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("%s round trip = diff:\n%s\n%s", tt.name, diff, out)
+			}
+		})
+	}
+}
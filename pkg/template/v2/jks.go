@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package template
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+)
+
+const (
+	jksAlias    = "certificate"
+	jksCertType = "X.509"
+
+	errEmptyJKSPass = "jks requires a password"
+)
+
+// jksEncode returns a Java keystore with the private key and the certificates
+// as entry with the alias certificate, the first certificate has to be the one
+// of the key. The keystore and the key are protected with the password.
+func jksEncode(pass, key, cert string) (string, error) {
+	if pass == "" {
+		return "", errors.New(errEmptyJKSPass)
+	}
+	privateKey, _, err := parseKeyAndCerts(key)
+	if err != nil {
+		return "", err
+	}
+	if privateKey == nil {
+		return "", errors.New(errNoPrivateKey)
+	}
+	_, certs, err := parseKeyAndCerts(cert)
+	if err != nil {
+		return "", err
+	}
+	if err := matchKeyAndCert(privateKey, certs); err != nil {
+		return "", err
+	}
+	rand, err := deterministicRand(pass, privateKey, certs)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	chain := make([]keystore.Certificate, 0, len(certs))
+	for _, c := range certs {
+		chain = append(chain, keystore.Certificate{
+			Type:    jksCertType,
+			Content: c.Raw,
+		})
+	}
+
+	ks := keystore.New(keystore.WithCustomRandomNumberGenerator(rand))
+	err = ks.SetPrivateKeyEntry(jksAlias, keystore.PrivateKeyEntry{
+		// the creation date of the entry is the one of the certificate
+		CreationTime:     certs[0].NotBefore,
+		PrivateKey:       der,
+		CertificateChain: chain,
+	}, []byte(pass))
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := ks.Store(buf, []byte(pass)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
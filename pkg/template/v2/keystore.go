@@ -0,0 +1,160 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package template
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	errNoPrivateKey  = "no private key found"
+	errNoCertificate = "no certificate found"
+	errKeyMismatch   = "private key does not match the certificate"
+	errEncodePKCS12  = "unable to encode pkcs12: %s"
+)
+
+// pkcs12Encode returns a PKCS#12 archive with the private key and the
+// certificates, the first certificate has to be the one of the key.
+func pkcs12Encode(pass, key, cert string) (string, error) {
+	privateKey, _, err := parseKeyAndCerts(key)
+	if err != nil {
+		return "", err
+	}
+	if privateKey == nil {
+		return "", errors.New(errNoPrivateKey)
+	}
+	_, certs, err := parseKeyAndCerts(cert)
+	if err != nil {
+		return "", err
+	}
+	return encodePKCS12(pass, privateKey, certs)
+}
+
+// fullPemToPkcs12 returns a PKCS#12 archive with the private key and the
+// certificate chain of a PEM bundle. The chain is ordered from the leaf
+// to the root if possible.
+func fullPemToPkcs12(pass, input string) (string, error) {
+	privateKey, certs, err := parseKeyAndCerts(input)
+	if err != nil {
+		return "", err
+	}
+	if privateKey == nil {
+		return "", errors.New(errNoPrivateKey)
+	}
+	return encodePKCS12(pass, privateKey, orderChain(certs))
+}
+
+func encodePKCS12(pass string, privateKey interface{}, certs []*x509.Certificate) (string, error) {
+	if err := matchKeyAndCert(privateKey, certs); err != nil {
+		return "", err
+	}
+	rand, err := deterministicRand(pass, privateKey, certs)
+	if err != nil {
+		return "", err
+	}
+	pfx, err := gopkcs12.Encode(rand, privateKey, certs[0], certs[1:], pass)
+	if err != nil {
+		return "", fmt.Errorf(errEncodePKCS12, err)
+	}
+	return string(pfx), nil
+}
+
+// parseKeyAndCerts returns the first private key and all certificates of the PEM blocks.
+func parseKeyAndCerts(input string) (interface{}, []*x509.Certificate, error) {
+	var privateKey interface{}
+	var certs []*x509.Certificate
+	data := []byte(input)
+	for {
+		block, rest := pem.Decode(data)
+		data = rest
+		if block == nil {
+			break
+		}
+		if block.Type == pemTypeCertificate {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			certs = append(certs, cert)
+			continue
+		}
+		if privateKey != nil {
+			continue
+		}
+		key, err := parsePrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		privateKey = key
+	}
+	return privateKey, certs, nil
+}
+
+// matchKeyAndCert checks that the first certificate is the one of the private key.
+func matchKeyAndCert(privateKey interface{}, certs []*x509.Certificate) error {
+	if len(certs) == 0 {
+		return errors.New(errNoCertificate)
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return errors.New(errParsePrivKey)
+	}
+	publicKey, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(certs[0].PublicKey) {
+		return errors.New(errKeyMismatch)
+	}
+	return nil
+}
+
+// orderChain orders the certificates from the leaf to the root. If that is not
+// possible, e.g. with disjunct certificates, they are returned as they are.
+func orderChain(certs []*x509.Certificate) []*x509.Certificate {
+	var pemData []byte
+	for _, cert := range certs {
+		pemData = append(pemData, pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: cert.Raw})...)
+	}
+	ordered, err := FetchCertChains(pemData)
+	if err != nil {
+		return certs
+	}
+	_, orderedCerts, err := parseKeyAndCerts(string(ordered))
+	if err != nil {
+		return certs
+	}
+	return orderedCerts
+}
+
+// deterministicRand returns the randomness for salts and IVs of a keystore, derived
+// from its content. The same input results in the same keystore, so the secret does
+// not change on every refresh.
+func deterministicRand(pass string, privateKey interface{}, certs []*x509.Certificate) (io.Reader, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	secret := append([]byte(pass), der...)
+	for _, cert := range certs {
+		secret = append(secret, cert.Raw...)
+	}
+	return hkdf.New(sha256.New, secret, nil, []byte("external-secrets keystore")), nil
+}
@@ -0,0 +1,228 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package template
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	leafKeyPath          = "_testdata/foo.key"
+	leafCertPath         = "_testdata/foo.crt"
+	intermediateCertPath = "_testdata/intermediate-ca.crt"
+	rootCertPath         = "_testdata/root-ca.crt"
+	chainPath            = "_testdata/chain.pem"
+	pkcs12WithPassPath   = "_testdata/foo-withpass-1234.pfx"
+)
+
+func readTestdata(t *testing.T, paths ...string) string {
+	t.Helper()
+	var out []byte
+	for _, p := range paths {
+		c, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, c...)
+	}
+	return string(out)
+}
+
+func TestPkcs12Encode(t *testing.T) {
+	tests := []struct {
+		name      string
+		pass      string
+		key       string
+		cert      string
+		wantChain []string
+		wantErr   string
+	}{
+		{
+			name:      "leaf certificate without password",
+			key:       leafKeyPath,
+			cert:      leafCertPath,
+			wantChain: []string{leafCertPath},
+		},
+		{
+			name:      "certificate chain with password",
+			pass:      "1234",
+			key:       leafKeyPath,
+			cert:      chainPath,
+			wantChain: []string{leafCertPath, rootCertPath, intermediateCertPath},
+		},
+		{
+			name:    "key does not match certificate",
+			key:     leafKeyPath,
+			cert:    intermediateCertPath,
+			wantErr: errKeyMismatch,
+		},
+		{
+			name:    "no private key",
+			key:     leafCertPath,
+			cert:    leafCertPath,
+			wantErr: errNoPrivateKey,
+		},
+		{
+			name:    "no certificate",
+			key:     leafKeyPath,
+			cert:    leafKeyPath,
+			wantErr: errNoCertificate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pkcs12Encode(tt.pass, readTestdata(t, tt.key), readTestdata(t, tt.cert))
+			if !ErrorContains(err, tt.wantErr) {
+				t.Fatalf("pkcs12Encode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			again, err := pkcs12Encode(tt.pass, readTestdata(t, tt.key), readTestdata(t, tt.cert))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != again {
+				t.Errorf("pkcs12Encode() is not deterministic")
+			}
+			assertPkcs12(t, tt.pass, got, readTestdata(t, tt.wantChain...))
+		})
+	}
+}
+
+func TestFullPemToPkcs12(t *testing.T) {
+	full := readTestdata(t, chainPath, leafKeyPath)
+	got, err := fullPemToPkcs12("1234", full)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the chain is ordered from the leaf to the root
+	assertPkcs12(t, "1234", got, readTestdata(t, leafCertPath, intermediateCertPath, rootCertPath))
+
+	_, err = fullPemToPkcs12("1234", readTestdata(t, chainPath))
+	if !ErrorContains(err, errNoPrivateKey) {
+		t.Errorf("fullPemToPkcs12() error = %v, wantErr %v", err, errNoPrivateKey)
+	}
+}
+
+// assertPkcs12 decodes the archive with the template functions and an external library.
+func assertPkcs12(t *testing.T, pass, archive, wantChain string) {
+	t.Helper()
+	key, err := pkcs12keyPass(pass, archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantKey, err := pkcs12keyPass("", readTestdata(t, "_testdata/foo-nopass.pfx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(wantKey, key); diff != "" {
+		t.Errorf("pkcs12keyPass() = diff:\n%s", diff)
+	}
+	_, cert, caCerts, err := gopkcs12.DecodeChain([]byte(archive), pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(wantChain, pemChain(append([]*x509.Certificate{cert}, caCerts...))); diff != "" {
+		t.Errorf("pkcs12 chain = diff:\n%s", diff)
+	}
+}
+
+func TestJksEncode(t *testing.T) {
+	key := readTestdata(t, leafKeyPath)
+	chain := readTestdata(t, leafCertPath, intermediateCertPath, rootCertPath)
+	got, err := jksEncode("1234", key, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := jksEncode("1234", key, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != again {
+		t.Errorf("jksEncode() is not deterministic")
+	}
+
+	alias, gotKey, gotChain := readJKS(t, "1234", got)
+	if alias != jksAlias {
+		t.Errorf("jks alias = %q, want %q", alias, jksAlias)
+	}
+	// the openssl archive holds the same key and chain
+	wantKey, cert, caCerts, err := gopkcs12.DecodeChain([]byte(readTestdata(t, pkcs12WithPassPath)), "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(wantKey, gotKey) {
+		t.Errorf("jks private key does not match the one of %s", pkcs12WithPassPath)
+	}
+	if diff := cmp.Diff(pemChain(orderChain(append([]*x509.Certificate{cert}, caCerts...))), gotChain); diff != "" {
+		t.Errorf("jks chain = diff:\n%s", diff)
+	}
+
+	if err := keystore.New().Load(bytes.NewReader([]byte(got)), []byte("wrongpass")); err == nil {
+		t.Errorf("loading the keystore with a wrong password did not fail")
+	}
+	if _, err := jksEncode("", key, chain); !ErrorContains(err, errEmptyJKSPass) {
+		t.Errorf("jksEncode() error = %v, wantErr %v", err, errEmptyJKSPass)
+	}
+}
+
+// readJKS decodes the only private key entry of the keystore with an external library.
+func readJKS(t *testing.T, pass, data string) (string, interface{}, string) {
+	t.Helper()
+	ks := keystore.New()
+	if err := ks.Load(bytes.NewReader([]byte(data)), []byte(pass)); err != nil {
+		t.Fatal(err)
+	}
+	aliases := ks.Aliases()
+	if len(aliases) != 1 {
+		t.Fatalf("jks aliases = %v, want a single entry", aliases)
+	}
+	entry, err := ks.GetPrivateKeyEntry(aliases[0], []byte(pass))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(entry.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certs := make([]*x509.Certificate, 0, len(entry.CertificateChain))
+	for _, c := range entry.CertificateChain {
+		if c.Type != jksCertType {
+			t.Fatalf("jks certificate type = %q, want %q", c.Type, jksCertType)
+		}
+		cert, err := x509.ParseCertificate(c.Content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		certs = append(certs, cert)
+	}
+	return aliases[0], key, pemChain(certs)
+}
+
+func pemChain(certs []*x509.Certificate) string {
+	var chain []byte
+	for _, c := range certs {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: c.Raw})...)
+	}
+	return string(chain)
+}
@@ -46,7 +46,9 @@ type node struct {
 	isParent bool
 }
 
-func fetchCertChains(data []byte) ([]byte, error) {
+// FetchCertChains orders the PEM encoded certificates in data
+// from the leaf to the root certificate.
+func FetchCertChains(data []byte) ([]byte, error) {
 	var newCertChain []*x509.Certificate
 	var pemData []byte
 	nodes, err := pemToNodes(data)
@@ -96,7 +96,7 @@ func pkcs12certPass(pass, input string) (string, error) {
 	// try to order certificate chain. If it fails we return
 	// the unordered raw pem data.
 	// This fails if multiple leaf or disjunct certs are provided.
-	ordered, err := fetchCertChains(pemData)
+	ordered, err := FetchCertChains(pemData)
 	if err != nil {
 		return string(pemData), nil
 	}
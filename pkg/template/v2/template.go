@@ -28,7 +28,28 @@ var tplFuncs = tpl.FuncMap{
 	"pkcs12cert":     pkcs12cert,
 	"pkcs12certPass": pkcs12certPass,
 
+	"pkcs12Encode":    pkcs12Encode,
+	"fullPemToPkcs12": fullPemToPkcs12,
+	"jksEncode":       jksEncode,
+
 	"filterPEM": filterPEM,
+	"derToPem":  derToPem,
+	"pemToDer":  pemToDer,
+
+	"certExpiry":  certExpiry,
+	"certSubject": certSubject,
+	"certSANs":    certSANs,
+
+	"toEnv":          toEnv,
+	"fromEnv":        fromEnv,
+	"toProperties":   toProperties,
+	"fromProperties": fromProperties,
+	"toINI":          toINI,
+	"fromINI":        fromINI,
+	"toTOML":         toTOML,
+	"fromTOML":       fromTOML,
+	"toYAML":         toYAML,
+	"fromYAML":       fromYAML,
 
 	"jwkPublicKeyPem":  jwkPublicKeyPem,
 	"jwkPrivateKeyPem": jwkPrivateKeyPem,
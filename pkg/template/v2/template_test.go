@@ -377,6 +377,35 @@ func TestExecute(t *testing.T) {
 				"fn": []byte(pkcs12Cert),
 			},
 		},
+		{
+			name: "certificate subject and sans",
+			tpl: map[string][]byte{
+				"subject": []byte(`{{ .secret | certSubject }}`),
+				"sans":    []byte(`{{ .secret | certSANs | join "," }}`),
+				"expiry":  []byte(`{{ dateInZone "2006-01-02" (.secret | certExpiry) "UTC" }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte(otherCert),
+			},
+			expetedData: map[string][]byte{
+				"subject": []byte("CN=foo"),
+				"sans":    []byte("foo"),
+				"expiry":  []byte("2022-02-10"),
+			},
+		},
+		{
+			name: "whole data map as env file",
+			tpl: map[string][]byte{
+				".env": []byte(`{{ . | toEnv }}`),
+			},
+			data: map[string][]byte{
+				"USER":     []byte("admin"),
+				"PASSWORD": []byte("it's\n"),
+			},
+			expetedData: map[string][]byte{
+				".env": []byte("PASSWORD=\"it's\\n\"\nUSER='admin'\n"),
+			},
+		},
 	}
 
 	for i := range tbl {
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	tpl "text/template"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v2 "github.com/external-secrets/external-secrets/pkg/template/v2"
+)
+
+const (
+	errParse   = "unable to parse template at key %s: %s"
+	errExecute = "unable to execute template at key %s: %s"
+)
+
+// Context is the root of a v3 template.
+type Context struct {
+	// Data are the secret values as fetched from the provider.
+	Data map[string][]byte
+	// Strings are the secret values as strings.
+	Strings map[string]string
+	// Metadata of the ExternalSecret.
+	Metadata Metadata
+	// Tags of the remote secrets fetched with metadataPolicy Fetch, by secretKey.
+	Tags map[string]map[string]string
+}
+
+// Metadata of the ExternalSecret that is rendered.
+type Metadata struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Engine renders templates with the metadata of the ExternalSecret and the
+// tags of the remote secrets in addition to the secret data.
+type Engine struct {
+	Metadata Metadata
+	Tags     map[string]map[string]string
+}
+
+// Execute renders the secret data as template. If an error occurs processing is stopped immediately.
+func (e *Engine) Execute(tpl, data map[string][]byte, secret *corev1.Secret) error {
+	if tpl == nil {
+		return nil
+	}
+	root := e.context(data)
+	for k, v := range tpl {
+		val, err := execute(k, string(v), root)
+		if err != nil {
+			return fmt.Errorf(errExecute, k, err)
+		}
+		secret.Data[k] = val
+	}
+	return nil
+}
+
+func (e *Engine) context(data map[string][]byte) *Context {
+	strValData := make(map[string]string, len(data))
+	for k := range data {
+		strValData[k] = string(data[k])
+	}
+	tags := e.Tags
+	if tags == nil {
+		tags = make(map[string]map[string]string)
+	}
+	return &Context{
+		Data:     data,
+		Strings:  strValData,
+		Metadata: e.Metadata,
+		Tags:     tags,
+	}
+}
+
+func execute(k, val string, root *Context) ([]byte, error) {
+	// a missing label, tag or key renders as empty string instead of <no value>
+	t, err := tpl.New(k).
+		Option("missingkey=zero").
+		Funcs(v2.FuncMap()).
+		Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf(errParse, k, err)
+	}
+	buf := bytes.NewBuffer(nil)
+	err = t.Execute(buf, root)
+	if err != nil {
+		return nil, fmt.Errorf(errExecute, k, err)
+	}
+	return buf.Bytes(), nil
+}
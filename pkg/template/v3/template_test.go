@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestExecute(t *testing.T) {
+	engine := &Engine{
+		Metadata: Metadata{
+			Name:        "db-credentials",
+			Namespace:   "payments",
+			Labels:      map[string]string{"team": "payments"},
+			Annotations: map[string]string{"example.com/owner": "alice"},
+		},
+		Tags: map[string]map[string]string{
+			"password": {"env": "prod", "rotation": "30d"},
+		},
+	}
+	tbl := []struct {
+		name        string
+		tpl         map[string][]byte
+		data        map[string][]byte
+		expetedData map[string][]byte
+		expErr      string
+	}{
+		{
+			name: "test empty",
+			tpl:  nil,
+			data: nil,
+		},
+		{
+			name: "strings with dashes and dots",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ index .Strings "db-user" }}:{{ index .Strings "db.password" }}`),
+			},
+			data: map[string][]byte{
+				"db-user":     []byte("admin"),
+				"db.password": []byte("s3cr3t"),
+			},
+			expetedData: map[string][]byte{
+				"foo": []byte("admin:s3cr3t"),
+			},
+		},
+		{
+			name: "binary data",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .Data.blob | toString | b64enc }} {{ len .Data.blob }}`),
+			},
+			data: map[string][]byte{
+				"blob": {0xde, 0xad, 0xbe, 0xef},
+			},
+			expetedData: map[string][]byte{
+				"foo": []byte("3q2+7w== 4"),
+			},
+		},
+		{
+			name: "metadata",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .Metadata.Namespace }}/{{ .Metadata.Name }} {{ .Metadata.Labels.team }} {{ index .Metadata.Annotations "example.com/owner" }}`),
+			},
+			expetedData: map[string][]byte{
+				"foo": []byte("payments/db-credentials payments alice"),
+			},
+		},
+		{
+			name: "tags",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ range $k, $v := .Tags.password }}{{ $k }}={{ $v }};{{ end }}`),
+			},
+			expetedData: map[string][]byte{
+				"foo": []byte("env=prod;rotation=30d;"),
+			},
+		},
+		{
+			name: "v2 functions",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .Strings.secret | b64dec | upper }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte("Zm9v"),
+			},
+			expetedData: map[string][]byte{
+				"foo": []byte("FOO"),
+			},
+		},
+		{
+			name: "v2 root is not available",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .secret }}`),
+			},
+			data: map[string][]byte{
+				"secret": []byte("foo"),
+			},
+			expErr: "can't evaluate field secret",
+		},
+		{
+			name: "parse error",
+			tpl: map[string][]byte{
+				"foo": []byte(`{{ .Strings.secret `),
+			},
+			expErr: "unable to parse template at key foo",
+		},
+	}
+
+	for i := range tbl {
+		row := tbl[i]
+		t.Run(row.name, func(t *testing.T) {
+			sec := &corev1.Secret{
+				Data: make(map[string][]byte),
+			}
+			err := engine.Execute(row.tpl, row.data, sec)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %s, expected: %s", err, row.expErr)
+			}
+			if row.expetedData == nil {
+				return
+			}
+			assert.EqualValues(t, row.expetedData, sec.Data)
+		})
+	}
+}
+
+func TestExecuteWithoutMetadata(t *testing.T) {
+	sec := &corev1.Secret{
+		Data: make(map[string][]byte),
+	}
+	tpl := map[string][]byte{
+		"foo": []byte(`{{ .Metadata.Labels.team }}{{ .Tags.password.env }}{{ .Strings.secret }}`),
+	}
+	err := (&Engine{}).Execute(tpl, map[string][]byte{"secret": []byte("bar")}, sec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.EqualValues(t, "bar", string(sec.Data["foo"]))
+}
+
+func ErrorContains(out error, want string) bool {
+	if out == nil {
+		return want == ""
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(out.Error(), want)
+}
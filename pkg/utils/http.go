@@ -0,0 +1,29 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"io"
+	"strings"
+)
+
+// maxErrorBody limits how much of an error response is added to the error message.
+const maxErrorBody = 512
+
+// ReadErrorBody reads the start of an error response body to add it to the error message.
+func ReadErrorBody(body io.Reader) string {
+	raw, _ := io.ReadAll(io.LimitReader(body, maxErrorBody))
+	return strings.TrimSpace(string(raw))
+}
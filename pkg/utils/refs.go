@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	errMissingRefNamespace = "missing namespace for %s on kind ClusterSecretStore"
+	errFetchSecret         = "could not fetch secret %s: %w"
+	errMissingSecretKey    = "missing key %s in secret %s"
+	errFetchConfigMap      = "could not fetch configmap %s: %w"
+	errMissingConfigMapKey = "missing key %s in configmap %s"
+	errUnknownCAProvider   = "unknown caProvider type %q"
+	errInvalidCA           = "could not append the CA certificate"
+)
+
+// ValidateCAProvider checks the type of the caProvider and that it has a
+// namespace if the store is a ClusterSecretStore.
+func ValidateCAProvider(store esv1beta1.GenericStore, caProvider *esv1beta1.CAProvider) error {
+	if caProvider == nil {
+		return nil
+	}
+	switch caProvider.Type {
+	case esv1beta1.CAProviderTypeSecret, esv1beta1.CAProviderTypeConfigMap:
+	default:
+		return fmt.Errorf(errUnknownCAProvider, caProvider.Type)
+	}
+	if store.GetObjectKind().GroupVersionKind().Kind == esv1beta1.ClusterSecretStoreKind && caProvider.Namespace == nil {
+		return fmt.Errorf(errMissingRefNamespace, caProvider.Name)
+	}
+	return nil
+}
+
+// FetchSecretKey reads the key of a secret referenced by a store of the given
+// kind in namespace.
+func FetchSecretKey(ctx context.Context, kube client.Client, storeKind, namespace string, ref *esmeta.SecretKeySelector) ([]byte, error) {
+	key, err := refObjectKey(storeKind, namespace, ref.Name, ref.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf(errFetchSecret, ref.Name, err)
+	}
+	val, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf(errMissingSecretKey, ref.Key, ref.Name)
+	}
+	return val, nil
+}
+
+// FetchCACertificate reads the certificate a caProvider of a store of the
+// given kind in namespace points to.
+func FetchCACertificate(ctx context.Context, kube client.Client, storeKind, namespace string, caProvider *esv1beta1.CAProvider) ([]byte, error) {
+	switch caProvider.Type {
+	case esv1beta1.CAProviderTypeSecret:
+		return FetchSecretKey(ctx, kube, storeKind, namespace, &esmeta.SecretKeySelector{
+			Name:      caProvider.Name,
+			Key:       caProvider.Key,
+			Namespace: caProvider.Namespace,
+		})
+	case esv1beta1.CAProviderTypeConfigMap:
+		key, err := refObjectKey(storeKind, namespace, caProvider.Name, caProvider.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		configMap := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, key, configMap); err != nil {
+			return nil, fmt.Errorf(errFetchConfigMap, caProvider.Name, err)
+		}
+		val, ok := configMap.Data[caProvider.Key]
+		if !ok {
+			return nil, fmt.Errorf(errMissingConfigMapKey, caProvider.Key, caProvider.Name)
+		}
+		return []byte(val), nil
+	default:
+		return nil, fmt.Errorf(errUnknownCAProvider, caProvider.Type)
+	}
+}
+
+// NewCAHTTPClient returns a http client trusting the caBundle and the
+// certificate of the caProvider. Without either it uses the system roots.
+func NewCAHTTPClient(ctx context.Context, kube client.Client, storeKind, namespace string, caBundle []byte, caProvider *esv1beta1.CAProvider) (*http.Client, error) {
+	if len(caBundle) == 0 && caProvider == nil {
+		return &http.Client{}, nil
+	}
+	caCertPool := x509.NewCertPool()
+	if len(caBundle) > 0 && !caCertPool.AppendCertsFromPEM(caBundle) {
+		return nil, errors.New(errInvalidCA)
+	}
+	if caProvider != nil {
+		cert, err := FetchCACertificate(ctx, kube, storeKind, namespace, caProvider)
+		if err != nil {
+			return nil, err
+		}
+		if !caCertPool.AppendCertsFromPEM(cert) {
+			return nil, errors.New(errInvalidCA)
+		}
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				RootCAs:    caCertPool,
+			},
+		},
+	}, nil
+}
+
+// refObjectKey returns the key of an object referenced by a store. Only a
+// ClusterSecretStore may reference another namespace, and it has to.
+func refObjectKey(storeKind, namespace, name string, refNamespace *string) (client.ObjectKey, error) {
+	key := client.ObjectKey{Name: name, Namespace: namespace}
+	if storeKind == esv1beta1.ClusterSecretStoreKind {
+		if refNamespace == nil {
+			return key, fmt.Errorf(errMissingRefNamespace, name)
+		}
+		key.Namespace = *refNamespace
+	}
+	return key, nil
+}
@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestFetchCACertificate(t *testing.T) {
+	other := "other"
+	kube := clientfake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "default"},
+			Data:       map[string][]byte{"ca.crt": []byte("secret-ca")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: other},
+			Data:       map[string]string{"ca.crt": "configmap-ca"},
+		},
+	).Build()
+	tbl := []struct {
+		name       string
+		storeKind  string
+		caProvider esv1beta1.CAProvider
+		expValue   string
+		expErr     string
+	}{
+		{
+			name:       "secret in the store namespace",
+			storeKind:  esv1beta1.SecretStoreKind,
+			caProvider: esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeSecret, Name: "ca", Key: "ca.crt"},
+			expValue:   "secret-ca",
+		},
+		{
+			name:       "configmap in another namespace",
+			storeKind:  esv1beta1.ClusterSecretStoreKind,
+			caProvider: esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeConfigMap, Name: "ca", Key: "ca.crt", Namespace: &other},
+			expValue:   "configmap-ca",
+		},
+		{
+			name:       "missing namespace on ClusterSecretStore",
+			storeKind:  esv1beta1.ClusterSecretStoreKind,
+			caProvider: esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeSecret, Name: "ca", Key: "ca.crt"},
+			expErr:     "missing namespace for ca on kind ClusterSecretStore",
+		},
+		{
+			name:       "missing key",
+			storeKind:  esv1beta1.SecretStoreKind,
+			caProvider: esv1beta1.CAProvider{Type: esv1beta1.CAProviderTypeSecret, Name: "ca", Key: "tls.crt"},
+			expErr:     "missing key tls.crt in secret ca",
+		},
+		{
+			name:       "unknown type",
+			storeKind:  esv1beta1.SecretStoreKind,
+			caProvider: esv1beta1.CAProvider{Type: "Vault", Name: "ca", Key: "ca.crt"},
+			expErr:     `unknown caProvider type "Vault"`,
+		},
+	}
+	for _, row := range tbl {
+		t.Run(row.name, func(t *testing.T) {
+			out, err := FetchCACertificate(context.Background(), kube, row.storeKind, "default", &row.caProvider)
+			if !ErrorContains(err, row.expErr) {
+				t.Fatalf("unexpected error: %v, expected: '%s'", err, row.expErr)
+			}
+			if string(out) != row.expValue {
+				t.Errorf("unexpected value: %q, expected: %q", out, row.expValue)
+			}
+		})
+	}
+}
+
+func TestFetchSecretKeyNamespace(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("t0ken")},
+	}).Build()
+	other := "other"
+	// a SecretStore only reads its own namespace
+	out, err := FetchSecretKey(context.Background(), kube, esv1beta1.SecretStoreKind, "default", &esmeta.SecretKeySelector{
+		Name: "token", Key: "token", Namespace: &other,
+	})
+	if err != nil || string(out) != "t0ken" {
+		t.Fatalf("unexpected result: %q, %v", out, err)
+	}
+}
@@ -15,9 +15,11 @@ limitations under the License.
 package utils
 
 import (
-
+	"bytes"
 	// nolint:gosec
 	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -26,6 +28,7 @@ import (
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -75,6 +78,185 @@ func convert(strategy esv1beta1.ExternalSecretConversionStrategy, str string) st
 	return strings.Join(newName, "")
 }
 
+// DecodeMap decodes the values of a secret map with the decoding strategy.
+func DecodeMap(strategy esv1beta1.ExternalSecretDecodingStrategy, in map[string][]byte) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		val, err := Decode(strategy, v)
+		if err != nil {
+			return nil, fmt.Errorf("failure decoding key %v: %w", k, err)
+		}
+		out[k] = val
+	}
+	return out, nil
+}
+
+// Decode decodes a value with the decoding strategy. The base64 strategies
+// accept values with or without padding, Auto only decodes strict, padded
+// base64 and returns all other values as they are.
+func Decode(strategy esv1beta1.ExternalSecretDecodingStrategy, in []byte) ([]byte, error) {
+	switch strategy {
+	case "", esv1beta1.ExternalSecretDecodeNone:
+		return in, nil
+	case esv1beta1.ExternalSecretDecodeBase64:
+		return decodeBase64(base64.StdEncoding, in)
+	case esv1beta1.ExternalSecretDecodeBase64URL:
+		return decodeBase64(base64.URLEncoding, in)
+	case esv1beta1.ExternalSecretDecodeHex:
+		out := make([]byte, hex.DecodedLen(len(bytes.TrimSpace(in))))
+		_, err := hex.Decode(out, bytes.TrimSpace(in))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode hex: %w", err)
+		}
+		return out, nil
+	case esv1beta1.ExternalSecretDecodeAuto:
+		return decodeAuto(in), nil
+	default:
+		return nil, fmt.Errorf("decoding strategy %v is not supported", strategy)
+	}
+}
+
+func decodeBase64(enc *base64.Encoding, in []byte) ([]byte, error) {
+	in = bytes.TrimSpace(in)
+	if !bytes.HasSuffix(in, []byte("=")) {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	out := make([]byte, enc.DecodedLen(len(in)))
+	n, err := enc.Decode(out, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64: %w", err)
+	}
+	return out[:n], nil
+}
+
+// decodeAuto decodes the value if it is strict, padded base64 with the
+// standard or URL safe alphabet. A value made only of letters is more likely
+// a plain word than base64, so it is only decoded if the result is text.
+func decodeAuto(in []byte) []byte {
+	trimmed := bytes.TrimSpace(in)
+	size := len(trimmed) - bytes.Count(trimmed, []byte("\n")) - bytes.Count(trimmed, []byte("\r"))
+	if size == 0 || size%4 != 0 {
+		return in
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding.Strict(), base64.URLEncoding.Strict()} {
+		out := make([]byte, enc.DecodedLen(len(trimmed)))
+		n, err := enc.Decode(out, trimmed)
+		if err != nil {
+			continue
+		}
+		if onlyLetters(trimmed) && !utf8.Valid(out[:n]) {
+			return in
+		}
+		return out[:n]
+	}
+	return in
+}
+
+func onlyLetters(in []byte) bool {
+	for _, c := range in {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// TransformKeys renames the keys of a secret map with the name transformer.
+// Like ConvertKeys it fails if two keys are renamed to the same key.
+func TransformKeys(transformer esv1beta1.SecretNameTransformer, in map[string][]byte) (map[string][]byte, error) {
+	if transformer == "" {
+		return in, nil
+	}
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		key, err := TransformName(transformer, k)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := out[key]; exists {
+			return nil, fmt.Errorf("secret name collision during transformation: %s", key)
+		}
+		out[key] = v
+	}
+	return out, nil
+}
+
+// TransformName renames a secret, the words of the name are separated by
+// non-alphanumeric characters or by case changes, e.g. dbPassword or DB_PASSWORD.
+func TransformName(transformer esv1beta1.SecretNameTransformer, name string) (string, error) {
+	words := splitWords(name)
+	switch transformer {
+	case "":
+		return name, nil
+	case esv1beta1.SecretNameTransformerUpperSnake:
+		return strings.ToUpper(strings.Join(words, "_")), nil
+	case esv1beta1.SecretNameTransformerLowerSnake:
+		return strings.ToLower(strings.Join(words, "_")), nil
+	case esv1beta1.SecretNameTransformerLowerKebab:
+		return strings.ToLower(strings.Join(words, "-")), nil
+	case esv1beta1.SecretNameTransformerTFVar:
+		return "TF_VAR_" + strings.ToLower(strings.Join(words, "_")), nil
+	case esv1beta1.SecretNameTransformerCamel, esv1beta1.SecretNameTransformerUpperCamel:
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 || transformer == esv1beta1.SecretNameTransformerUpperCamel {
+				rs := []rune(word)
+				rs[0] = unicode.ToUpper(rs[0])
+				word = string(rs)
+			}
+			words[i] = word
+		}
+		return strings.Join(words, ""), nil
+	default:
+		return "", ValidateNameTransformer(transformer)
+	}
+}
+
+// ValidateNameTransformer checks that the name transformer is empty or known to TransformName.
+func ValidateNameTransformer(transformer esv1beta1.SecretNameTransformer) error {
+	switch transformer {
+	case "",
+		esv1beta1.SecretNameTransformerUpperSnake,
+		esv1beta1.SecretNameTransformerLowerSnake,
+		esv1beta1.SecretNameTransformerLowerKebab,
+		esv1beta1.SecretNameTransformerCamel,
+		esv1beta1.SecretNameTransformerUpperCamel,
+		esv1beta1.SecretNameTransformerTFVar:
+		return nil
+	}
+	return fmt.Errorf("unknown name transformer %q", transformer)
+}
+
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+	rs := []rune(name)
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		// a new word starts at an upper case letter after a lower case letter or digit,
+		// or at the last upper case letter of an acronym followed by a lower case letter
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			nextIsLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsNumber(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
 // MergeStringMap performs a deep clone from src to dest.
 func MergeStringMap(dest, src map[string]string) {
 	for k, v := range src {
@@ -16,6 +16,7 @@ package utils
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -226,6 +227,194 @@ func TestConvertKeys(t *testing.T) {
 	}
 }
 
+func TestDecodeMap(t *testing.T) {
+	type args struct {
+		strategy esv1beta1.ExternalSecretDecodingStrategy
+		in       map[string][]byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name: "no decoding",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeNone,
+				in: map[string][]byte{
+					"foo": []byte("YmFy"),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte("YmFy"),
+			},
+		},
+		{
+			name: "base64 with and without padding",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeBase64,
+				in: map[string][]byte{
+					"foo": []byte("YmFyPz4="),
+					"bar": []byte("YmFyPz4\n"),
+					"baz": []byte("YmFyPz4"),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte("bar?>"),
+				"bar": []byte("bar?>"),
+				"baz": []byte("bar?>"),
+			},
+		},
+		{
+			name: "base64url",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeBase64URL,
+				in: map[string][]byte{
+					"foo": []byte("YmFyPz4"),
+					"bar": []byte("_-8="),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte("bar?>"),
+				"bar": {0xff, 0xef},
+			},
+		},
+		{
+			name: "hex",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeHex,
+				in: map[string][]byte{
+					"foo": []byte("626172"),
+				},
+			},
+			want: map[string][]byte{
+				"foo": []byte("bar"),
+			},
+		},
+		{
+			name: "auto keeps values that are not encoded",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeAuto,
+				in: map[string][]byte{
+					"std":       []byte("YmFyPz4="),
+					"url":       []byte("_-8="),
+					"letters":   []byte("YWJj"),
+					"plain":     []byte("bar?>"),
+					"unpadded":  []byte("YmFyPz4"),
+					"password":  []byte("password"),
+					"hunter2":   []byte("hunter2"),
+					"bad-trail": []byte("YR=="),
+				},
+			},
+			want: map[string][]byte{
+				"std":       []byte("bar?>"),
+				"url":       {0xff, 0xef},
+				"letters":   []byte("abc"),
+				"plain":     []byte("bar?>"),
+				"unpadded":  []byte("YmFyPz4"),
+				"password":  []byte("password"),
+				"hunter2":   []byte("hunter2"),
+				"bad-trail": []byte("YR=="),
+			},
+		},
+		{
+			name: "error names the key",
+			args: args{
+				strategy: esv1beta1.ExternalSecretDecodeBase64,
+				in: map[string][]byte{
+					"foo": []byte("bar?>"),
+				},
+			},
+			wantErr: "failure decoding key foo: failed to decode base64",
+		},
+		{
+			name: "unsupported strategy",
+			args: args{
+				strategy: "rot13",
+				in: map[string][]byte{
+					"foo": []byte("bar"),
+				},
+			},
+			wantErr: "decoding strategy rot13 is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeMap(tt.args.strategy, tt.args.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("DecodeMap() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeMap() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformKeys(t *testing.T) {
+	in := map[string][]byte{
+		"DB_PASSWORD":     []byte(`a`),
+		"apiToken":        []byte(`b`),
+		"HTTPServer-port": []byte(`c`),
+		"s3.bucket.name":  []byte(`d`),
+	}
+	tests := []struct {
+		transformer esv1beta1.SecretNameTransformer
+		want        []string
+	}{
+		{"", []string{"DB_PASSWORD", "apiToken", "HTTPServer-port", "s3.bucket.name"}},
+		{esv1beta1.SecretNameTransformerUpperSnake, []string{"DB_PASSWORD", "API_TOKEN", "HTTP_SERVER_PORT", "S3_BUCKET_NAME"}},
+		{esv1beta1.SecretNameTransformerLowerSnake, []string{"db_password", "api_token", "http_server_port", "s3_bucket_name"}},
+		{esv1beta1.SecretNameTransformerLowerKebab, []string{"db-password", "api-token", "http-server-port", "s3-bucket-name"}},
+		{esv1beta1.SecretNameTransformerCamel, []string{"dbPassword", "apiToken", "httpServerPort", "s3BucketName"}},
+		{esv1beta1.SecretNameTransformerUpperCamel, []string{"DbPassword", "ApiToken", "HttpServerPort", "S3BucketName"}},
+		{esv1beta1.SecretNameTransformerTFVar, []string{"TF_VAR_db_password", "TF_VAR_api_token", "TF_VAR_http_server_port", "TF_VAR_s3_bucket_name"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.transformer), func(t *testing.T) {
+			got, err := TransformKeys(tt.transformer, in)
+			if err != nil {
+				t.Fatalf("TransformKeys() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("TransformKeys() = %v, want %v", got, tt.want)
+			}
+			for _, key := range tt.want {
+				if _, ok := got[key]; !ok {
+					t.Errorf("TransformKeys() = %v, missing %s", got, key)
+				}
+			}
+		})
+	}
+
+	_, err := TransformKeys(esv1beta1.SecretNameTransformerLowerKebab, map[string][]byte{"DB_PASSWORD": nil, "dbPassword": nil})
+	if err == nil {
+		t.Errorf("TransformKeys() expected collision error")
+	}
+	_, err = TransformKeys("unknown", in)
+	if err == nil {
+		t.Errorf("TransformKeys() expected unknown transformer error")
+	}
+}
+
+func TestValidateNameTransformer(t *testing.T) {
+	for _, transformer := range []esv1beta1.SecretNameTransformer{"", esv1beta1.SecretNameTransformerUpperCamel, esv1beta1.SecretNameTransformerTFVar} {
+		if err := ValidateNameTransformer(transformer); err != nil {
+			t.Errorf("ValidateNameTransformer(%q) error = %v", transformer, err)
+		}
+	}
+	if err := ValidateNameTransformer("screaming"); err == nil || err.Error() != `unknown name transformer "screaming"` {
+		t.Errorf("ValidateNameTransformer() error = %v, want unknown name transformer", err)
+	}
+}
+
 func TestValidate(t *testing.T) {
 	err := NetworkValidate("http://google.com", 10*time.Second)
 	if err != nil {